@@ -23,3 +23,23 @@ func TestAdd(t *testing.T) {
 	b, _ := r.Values.Load(requestKey)
 	assert.Len(t, b, 2)
 }
+
+func TestJSONLDFunc(t *testing.T) {
+	r := web.CreateRequest(nil, nil)
+	ctx := web.ContextWithRequest(context.Background(), r)
+
+	jsonLD := new(JSONLDFunc).Func(ctx).(func() string)
+	assert.Equal(t, "", jsonLD())
+
+	Add(ctx, Crumb{Title: "Home", URL: "https://example.com/"})
+	Add(ctx, Crumb{Title: "Shirts"})
+
+	assert.JSONEq(t, `{
+		"@context": "https://schema.org",
+		"@type": "BreadcrumbList",
+		"itemListElement": [
+			{"@type": "ListItem", "position": 1, "name": "Home", "item": "https://example.com/"},
+			{"@type": "ListItem", "position": 2, "name": "Shirts"}
+		]
+	}`, jsonLD())
+}
@@ -0,0 +1,67 @@
+package breadcrumbs
+
+import (
+	"context"
+	"encoding/json"
+
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// JSONLDFunc is exported as a template function
+	JSONLDFunc struct{}
+
+	// jsonLDBreadcrumbList is the schema.org BreadcrumbList
+	jsonLDBreadcrumbList struct {
+		Context         string           `json:"@context"`
+		Type            string           `json:"@type"`
+		ItemListElement []jsonLDListItem `json:"itemListElement"`
+	}
+
+	jsonLDListItem struct {
+		Type     string `json:"@type"`
+		Position int    `json:"position"`
+		Name     string `json:"name"`
+		Item     string `json:"item,omitempty"`
+	}
+)
+
+// BuildJSONLD returns the schema.org BreadcrumbList of the crumbs as JSON-LD string - or an empty string if there are no crumbs
+func BuildJSONLD(crumbs []Crumb) string {
+	if len(crumbs) == 0 {
+		return ""
+	}
+
+	list := jsonLDBreadcrumbList{
+		Context:         "https://schema.org",
+		Type:            "BreadcrumbList",
+		ItemListElement: make([]jsonLDListItem, 0, len(crumbs)),
+	}
+	for i, crumb := range crumbs {
+		list.ItemListElement = append(list.ItemListElement, jsonLDListItem{
+			Type:     "ListItem",
+			Position: i + 1,
+			Name:     crumb.Title,
+			Item:     crumb.URL,
+		})
+	}
+
+	result, err := json.Marshal(list)
+	if err != nil {
+		return ""
+	}
+	return string(result)
+}
+
+// Func returns the BreadcrumbList of the breadcrumbs added to the current request
+func (tf *JSONLDFunc) Func(ctx context.Context) interface{} {
+	return func() string {
+		req := web.RequestFromContext(ctx)
+		if req == nil {
+			return ""
+		}
+		breadcrumbs, _ := req.Values.Load(requestKey)
+		crumbs, _ := breadcrumbs.([]Crumb)
+		return BuildJSONLD(crumbs)
+	}
+}
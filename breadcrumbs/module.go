@@ -2,6 +2,7 @@ package breadcrumbs
 
 import (
 	"flamingo.me/dingo"
+	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/flamingo/v3/framework/web"
 )
 
@@ -11,6 +12,7 @@ type Module struct{}
 // Configure module
 func (m *Module) Configure(injector *dingo.Injector) {
 	web.BindRoutes(injector, new(routes))
+	flamingo.BindTemplateFunc(injector, "breadcrumbsJsonLd", new(JSONLDFunc))
 }
 
 type routes struct {
@@ -32,6 +32,7 @@ type (
 		logger              flamingo.Logger
 		defaultDeliveryCode string
 		restrictionService  *validation.RestrictionService
+		clock               productDomain.Clock
 		deleteEmptyDelivery bool
 		// optionals - these may be nil
 		cartValidator     validation.Validator
@@ -66,6 +67,7 @@ func (cs *CartService) Inject(
 	deliveryInfoBuilder cartDomain.DeliveryInfoBuilder,
 	restrictionService *validation.RestrictionService,
 	authManager *application.AuthManager,
+	clock productDomain.Clock,
 	logger flamingo.Logger,
 	config *struct {
 		DefaultDeliveryCode string `inject:"config:commerce.cart.defaultDeliveryCode,optional"`
@@ -85,6 +87,7 @@ func (cs *CartService) Inject(
 	cs.deliveryInfoBuilder = deliveryInfoBuilder
 	cs.restrictionService = restrictionService
 	cs.authManager = authManager
+	cs.clock = clock
 	cs.logger = logger.WithField("module", "cart").WithField("category", "application.cartService")
 	if config != nil {
 		cs.defaultDeliveryCode = config.DefaultDeliveryCode
@@ -603,6 +606,22 @@ func (cs *CartService) checkProductForAddRequest(ctx context.Context, session *w
 		}
 	}
 
+	if bundleProduct, ok := product.(productDomain.BundleProduct); ok {
+		bundleWithActiveChoices, err := bundleProduct.GetBundleWithActiveChoices(addRequest.BundleConfiguration)
+		if err != nil {
+			return addRequest, nil, fmt.Errorf("cart.application.cartservice - AddProduct:Invalid bundle configuration: %v", err)
+		}
+		if !bundleWithActiveChoices.IsSaleable() {
+			return addRequest, nil, errors.New("cart.application.cartservice - AddProduct:Bundle contains components that are not saleable")
+		}
+		addRequest.BundleConfiguration = bundleWithActiveChoices.Configuration()
+		product = bundleWithActiveChoices
+	}
+
+	if !cs.isSaleable(product, addRequest.VariantMarketplaceCode) {
+		return addRequest, nil, &validation.AddToCartNotAllowed{Reason: validation.ReasonNotSaleable}
+	}
+
 	// Now Validate the Item with the optional registered ItemValidator
 	if cs.itemValidator != nil {
 		return addRequest, product, cs.itemValidator.Validate(ctx, session, deliveryCode, addRequest, product)
@@ -611,6 +630,18 @@ func (cs *CartService) checkProductForAddRequest(ctx context.Context, session *w
 	return addRequest, product, nil
 }
 
+// isSaleable checks the saleable flag and window of the product - for configurables the requested variant is checked
+func (cs *CartService) isSaleable(product productDomain.BasicProduct, variantMarketplaceCode string) bool {
+	if configurable, ok := product.(productDomain.ConfigurableProduct); ok {
+		withActiveVariant, err := configurable.GetConfigurableWithActiveVariant(variantMarketplaceCode)
+		if err != nil {
+			return false
+		}
+		product = withActiveVariant
+	}
+	return product.IsSaleable() && product.SaleableData().IsSaleableAt(cs.clock.Now())
+}
+
 func (cs *CartService) checkProductQtyRestrictions(ctx context.Context, product productDomain.BasicProduct, cart *cartDomain.Cart, qtyToCheck int, deliveryCode string, itemID string) error {
 	restrictionResult := cs.restrictionService.RestrictQty(ctx, product, cart, deliveryCode)
 
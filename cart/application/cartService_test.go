@@ -7,7 +7,12 @@ import (
 	"flamingo.me/flamingo-commerce/v3/cart/domain/validation"
 
 
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 
 	cartApplication "flamingo.me/flamingo-commerce/v3/cart/application"
 	cartDomain "flamingo.me/flamingo-commerce/v3/cart/domain/cart"
@@ -109,6 +114,7 @@ func TestCartService_DeleteSavedSessionGuestCartID(t *testing.T) {
 				tt.fields.DeliveryInfoBuilder,
 				tt.fields.RestrictionService,
 				authmanager,
+				productDomain.SystemClock{},
 				tt.fields.Logger,
 				tt.fields.config,
 				nil,
@@ -130,3 +136,63 @@ func TestCartService_DeleteSavedSessionGuestCartID(t *testing.T) {
 		})
 	}
 }
+
+type saleableProductService struct{}
+
+func (saleableProductService) Get(_ context.Context, marketplaceCode string) (productDomain.BasicProduct, error) {
+	launch := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	switch marketplaceCode {
+	case "scheduled":
+		return productDomain.SimpleProduct{
+			BasicProductData: productDomain.BasicProductData{MarketPlaceCode: marketplaceCode},
+			Saleable:         productDomain.Saleable{IsSaleable: true, SaleableFrom: launch.Add(time.Hour)},
+		}, nil
+	case "configurable":
+		return productDomain.ConfigurableProduct{
+			BasicProductData: productDomain.BasicProductData{MarketPlaceCode: marketplaceCode},
+			Variants: []productDomain.Variant{
+				{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "expired"}, Saleable: productDomain.Saleable{IsSaleable: true, SaleableTo: launch.Add(-time.Hour)}},
+			},
+		}, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestCartService_AddProductNotSaleable(t *testing.T) {
+	receiver := &cartApplication.CartReceiverService{}
+	receiver.Inject(
+		new(MockGuestCartServiceAdapter),
+		new(MockCustomerCartService),
+		nil,
+		&authApplication.AuthManager{},
+		&authApplication.UserService{},
+		flamingo.NullLogger{},
+		nil,
+		nil,
+	)
+
+	cs := &cartApplication.CartService{}
+	cs.Inject(
+		receiver,
+		saleableProductService{},
+		new(MockEventPublisher),
+		nil,
+		new(MockDeliveryInfoBuilder),
+		nil,
+		&authApplication.AuthManager{},
+		productDomain.FixedClock{Time: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)},
+		flamingo.NullLogger{},
+		nil,
+		nil,
+	)
+
+	for _, addRequest := range []cartDomain.AddRequest{
+		{MarketplaceCode: "scheduled", Qty: 1},
+		{MarketplaceCode: "configurable", VariantMarketplaceCode: "expired", Qty: 1},
+	} {
+		_, err := cs.AddProduct(context.Background(), web.EmptySession(), "delivery", addRequest)
+		if assert.Error(t, err, addRequest.MarketplaceCode) {
+			assert.Equal(t, validation.ReasonNotSaleable, err.(*validation.AddToCartNotAllowed).MessageCode())
+		}
+	}
+}
@@ -67,6 +67,7 @@ func (e *EventReceiver) Notify(ctx context.Context, event flamingo.Event) {
 			for _, item := range d.Cartitems {
 				e.logger.WithContext(ctx).Debug("Merging item from guest to user cart %v", item)
 				addRequest := e.cartService.BuildAddRequest(ctx, item.MarketplaceCode, item.VariantMarketPlaceCode, item.Qty)
+				addRequest.BundleConfiguration = item.BundleConfiguration()
 				e.cartService.AddProduct(ctx, currentEvent.Session, d.DeliveryInfo.Code, addRequest)
 			}
 		}
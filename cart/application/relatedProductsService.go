@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/cart/domain/decorator"
+	productApplication "flamingo.me/flamingo-commerce/v3/product/application"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// RelatedProductsService aggregates the related products (cross-sell, up-sell, accessories) of all cart items
+	RelatedProductsService struct {
+		relationService *productApplication.RelationService
+	}
+)
+
+// Inject dependencies
+func (s *RelatedProductsService) Inject(relationService *productApplication.RelationService) {
+	s.relationService = relationService
+}
+
+// GetRelatedProducts returns up to limit related products of the given relation type (all types if empty) for all items of the cart.
+// Products that are already in the cart are excluded. A limit <= 0 means the configured default limit
+func (s *RelatedProductsService) GetRelatedProducts(ctx context.Context, cart decorator.DecoratedCart, relationType string, limit int) ([]productDomain.BasicProduct, error) {
+	inCart := cart.GetAllMarketplaceCodes()
+
+	var sources []string
+	for _, item := range cart.GetAllDecoratedItems() {
+		sources = append(sources, item.Item.MarketplaceCode)
+	}
+
+	return s.relationService.GetRelatedProductsForMany(ctx, sources, inCart, relationType, limit)
+}
@@ -0,0 +1,69 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cartApplication "flamingo.me/flamingo-commerce/v3/cart/application"
+	cartDomain "flamingo.me/flamingo-commerce/v3/cart/domain/cart"
+	"flamingo.me/flamingo-commerce/v3/cart/domain/decorator"
+	productApplication "flamingo.me/flamingo-commerce/v3/product/application"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	relationServiceStub map[string][]productDomain.ProductRelation
+
+	relatedProductServiceStub struct{}
+)
+
+func (relatedProductServiceStub) Get(_ context.Context, marketplaceCode string) (productDomain.BasicProduct, error) {
+	return productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: marketplaceCode}}, nil
+}
+
+func (s relationServiceStub) GetRelations(_ context.Context, marketplaceCode string, _ ...string) ([]productDomain.ProductRelation, error) {
+	return s[marketplaceCode], nil
+}
+
+func TestRelatedProductsService_GetRelatedProducts(t *testing.T) {
+	relationService := new(productApplication.RelationService)
+	relationService.Inject(relatedProductServiceStub{}, flamingo.NullLogger{}, nil, &struct {
+		ProductRelationService productDomain.ProductRelationService `inject:",optional"`
+	}{ProductRelationService: relationServiceStub{
+		"camera": {
+			{Type: productDomain.RelationTypeAccessory, MarketplaceCode: "lens"},
+			{Type: productDomain.RelationTypeAccessory, MarketplaceCode: "camerabag"},
+			{Type: productDomain.RelationTypeAccessory, MarketplaceCode: "battery"},
+		},
+		"camerabag": {
+			{Type: productDomain.RelationTypeCrossSell, MarketplaceCode: "camera"},
+			{Type: productDomain.RelationTypeCrossSell, MarketplaceCode: "tripod"},
+		},
+	}})
+
+	service := new(cartApplication.RelatedProductsService)
+	service.Inject(relationService)
+
+	cart := decorator.DecoratedCart{
+		DecoratedDeliveries: []decorator.DecoratedDelivery{
+			{DecoratedItems: []decorator.DecoratedCartItem{
+				{Item: cartDomain.Item{MarketplaceCode: "camera"}},
+				{Item: cartDomain.Item{MarketplaceCode: "camerabag"}},
+			}},
+			{DecoratedItems: []decorator.DecoratedCartItem{
+				{Item: cartDomain.Item{MarketplaceCode: "kit", BundleItems: []cartDomain.BundleItem{{MarketplaceCode: "lens"}}}},
+			}},
+		},
+	}
+
+	products, err := service.GetRelatedProducts(context.Background(), cart, "", 0)
+	assert.NoError(t, err)
+	var codes []string
+	for _, product := range products {
+		codes = append(codes, product.BaseData().MarketPlaceCode)
+	}
+	assert.Equal(t, []string{"battery", "tripod"}, codes, "products in the cart are excluded")
+}
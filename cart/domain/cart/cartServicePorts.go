@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
 	"flamingo.me/flamingo/v3/core/oauth/domain"
 	"flamingo.me/flamingo/v3/framework/flamingo"
 
@@ -53,6 +54,8 @@ type (
 		MarketplaceCode        string
 		Qty                    int
 		VariantMarketplaceCode string
+		// BundleConfiguration contains the chosen components - only relevant for bundle products
+		BundleConfiguration productDomain.BundleConfiguration
 	}
 
 	// ItemUpdateCommand defines the update item command
@@ -46,6 +46,21 @@ type (
 
 		// AppliedDiscounts contains the details about the discounts applied to this item - they can be "itemrelated" or not
 		AppliedDiscounts []ItemDiscount
+
+		// BundleItems are the child components of a bundle item - the item prices are the aggregated prices of the components
+		BundleItems []BundleItem
+	}
+
+	// BundleItem is a child component of a bundle item
+	BundleItem struct {
+		// OptionCode references the bundle option the component was chosen for
+		OptionCode      string
+		MarketplaceCode string
+		ProductName     string
+		// Qty of the component per bundle - the total qty is Qty * Item.Qty
+		Qty int
+		// SinglePrice of one component
+		SinglePrice priceDomain.Price
 	}
 
 	// ItemDiscount value object
@@ -69,6 +84,28 @@ type (
 	ItemBuilderProvider func() *ItemBuilder
 )
 
+// IsBundle - returns true if the item has bundle components
+func (i Item) IsBundle() bool {
+	return len(i.BundleItems) > 0
+}
+
+// BundleConfiguration returns the chosen components of a bundle item
+func (i Item) BundleConfiguration() domain.BundleConfiguration {
+	if !i.IsBundle() {
+		return nil
+	}
+	configuration := make(domain.BundleConfiguration, len(i.BundleItems))
+	for _, bundleItem := range i.BundleItems {
+		configuration[bundleItem.OptionCode] = domain.BundleChoiceConfiguration{MarketplaceCode: bundleItem.MarketplaceCode, Qty: bundleItem.Qty}
+	}
+	return configuration
+}
+
+// TotalQty returns the total qty of the component in the given item
+func (b BundleItem) TotalQty(item Item) int {
+	return b.Qty * item.Qty
+}
+
 // TotalTaxAmount - returns total tax amount as price
 func (i Item) TotalTaxAmount() priceDomain.Price {
 	return i.RowTaxes.TotalAmount()
@@ -163,10 +200,18 @@ func (f *ItemBuilder) SetFromItem(item Item) *ItemBuilder {
 	f.AddDiscounts(item.AppliedDiscounts...)
 	f.SetSinglePriceGross(item.SinglePriceGross)
 	f.SetSinglePriceNet(item.SinglePriceNet)
+	f.SetBundleItems(item.BundleItems...)
 
 	return f
 }
 
+// SetBundleItems sets the child components of a bundle item
+func (f *ItemBuilder) SetBundleItems(bundleItems ...BundleItem) *ItemBuilder {
+	f.init()
+	f.itemInBuilding.BundleItems = append([]BundleItem(nil), bundleItems...)
+	return f
+}
+
 // SetVariantMarketPlaceCode sets VariantMarketPlaceCode (only for configurable_with_variant relevant)
 func (f *ItemBuilder) SetVariantMarketPlaceCode(id string) *ItemBuilder {
 	f.init()
@@ -333,6 +378,20 @@ func (f *ItemBuilder) SetByProduct(product domain.BasicProduct) *ItemBuilder {
 
 	}
 
+	if bundle, ok := product.(domain.BundleProductWithActiveChoices); ok {
+		bundleItems := make([]BundleItem, 0, len(bundle.ActiveChoices))
+		for _, activeChoice := range bundle.ActiveChoices {
+			bundleItems = append(bundleItems, BundleItem{
+				OptionCode:      activeChoice.OptionCode,
+				MarketplaceCode: activeChoice.Choice.MarketPlaceCode,
+				ProductName:     activeChoice.Choice.Title,
+				Qty:             activeChoice.Qty,
+				SinglePrice:     activeChoice.Choice.ActivePrice.GetFinalPrice(),
+			})
+		}
+		f.SetBundleItems(bundleItems...)
+	}
+
 	if f.configUseGrossPrice {
 		f.SetSinglePriceGross(product.SaleableData().ActivePrice.GetFinalPrice())
 		f.CalculatePricesAndTaxAmountsFromSinglePriceGross()
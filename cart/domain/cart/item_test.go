@@ -9,6 +9,7 @@ import (
 
 	cartDomain "flamingo.me/flamingo-commerce/v3/cart/domain/cart"
 	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
 )
 
 func TestItem_PriceCalculation(t *testing.T) {
@@ -74,3 +75,40 @@ func assertPricesWithLikelyEqual(t *testing.T, p1 priceDomain.Price, p2 priceDom
 	assert.True(t, p1.LikelyEqual(p2), fmt.Sprintf("%v (%f != %f)", msg, p1.FloatAmount(), p2.FloatAmount()))
 
 }
+
+func TestItemBuild_Bundle(t *testing.T) {
+	choice := func(marketplaceCode string, price float64) productDomain.BundleChoice {
+		return productDomain.BundleChoice{
+			BasicProductData: productDomain.BasicProductData{MarketPlaceCode: marketplaceCode, Title: marketplaceCode},
+			Saleable:         productDomain.Saleable{IsSaleable: true, ActivePrice: productDomain.PriceInfo{Default: priceDomain.NewFromFloat(price, "EUR")}},
+			Qty:              1,
+		}
+	}
+	bundle := productDomain.BundleProduct{
+		BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "camera-kit", Title: "Camera Kit"},
+		BundleType:       productDomain.BundleTypeFixed,
+		Options: []productDomain.BundleOption{
+			{Code: "body", Required: true, Choices: []productDomain.BundleChoice{choice("body", 500)}},
+			{Code: "lens", Required: true, Choices: []productDomain.BundleChoice{choice("lens", 200)}},
+		},
+	}
+	bundleWithChoices, err := bundle.GetBundleWithActiveChoices(nil)
+	assert.NoError(t, err)
+
+	f := &cartDomain.ItemBuilder{}
+	item, err := f.SetQty(2).SetID("1").SetByProduct(bundleWithChoices).Build()
+	assert.NoError(t, err)
+	assert.True(t, item.IsBundle())
+	assert.Equal(t, "camera-kit", item.MarketplaceCode)
+	assert.Len(t, item.BundleItems, 2)
+	assert.Equal(t, 2, item.BundleItems[0].TotalQty(*item))
+	assertPricesWithLikelyEqual(t, priceDomain.NewFromFloat(1400, "EUR"), item.RowPriceNet, "RowPriceNet wrong")
+	assert.True(t, item.BundleConfiguration().Equal(bundleWithChoices.Configuration()))
+
+	copied, err := f.SetFromItem(*item).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, item.BundleItems, copied.BundleItems)
+
+	assert.False(t, cartDomain.Item{}.IsBundle())
+	assert.Nil(t, cartDomain.Item{}.BundleConfiguration())
+}
@@ -57,10 +57,15 @@ func (df *DecoratedCartFactory) Inject(
 // Create Factory method to get Decorated Cart
 func (df *DecoratedCartFactory) Create(ctx context.Context, Cart cart.Cart) *DecoratedCart {
 	decoratedCart := DecoratedCart{Cart: Cart, Logger: df.logger}
+	var allItems []cart.Item
+	for _, d := range Cart.Deliveries {
+		allItems = append(allItems, d.Cartitems...)
+	}
+	products := df.loadProducts(ctx, allItems)
 	for _, d := range Cart.Deliveries {
 		decoratedCart.DecoratedDeliveries = append(decoratedCart.DecoratedDeliveries, DecoratedDelivery{
 			Delivery:       d,
-			DecoratedItems: df.CreateDecorateCartItems(ctx, d.Cartitems),
+			DecoratedItems: df.decorateCartItems(ctx, d.Cartitems, products),
 		})
 	}
 	decoratedCart.Ctx = ctx
@@ -69,28 +74,37 @@ func (df *DecoratedCartFactory) Create(ctx context.Context, Cart cart.Cart) *Dec
 
 // CreateDecorateCartItems Factory method to get Decorated Cart
 func (df *DecoratedCartFactory) CreateDecorateCartItems(ctx context.Context, items []cart.Item) []DecoratedCartItem {
+	return df.decorateCartItems(ctx, items, df.loadProducts(ctx, items))
+}
+
+//loadProducts - loads the products of all items with one batch call
+func (df *DecoratedCartFactory) loadProducts(ctx context.Context, items []cart.Item) map[string]domain.BasicProduct {
+	marketplaceCodes := make([]string, len(items))
+	for i, cartitem := range items {
+		marketplaceCodes[i] = cartitem.MarketplaceCode
+	}
+	products, err := domain.GetMany(ctx, df.productService, marketplaceCodes...)
+	if err != nil {
+		df.logger.WithContext(ctx).Error("cart.decorator - error loading products for items", err)
+	}
+	return products
+}
+
+func (df *DecoratedCartFactory) decorateCartItems(ctx context.Context, items []cart.Item, products map[string]domain.BasicProduct) []DecoratedCartItem {
 	var decoratedItems []DecoratedCartItem
 	for _, cartitem := range items {
-		decoratedItem := df.decorateCartItem(ctx, cartitem)
+		decoratedItem := df.decorateCartItem(ctx, cartitem, products)
 		decoratedItems = append(decoratedItems, decoratedItem)
 	}
 	return decoratedItems
 }
 
 //decorateCartItem factory method
-func (df *DecoratedCartFactory) decorateCartItem(ctx context.Context, cartitem cart.Item) DecoratedCartItem {
+func (df *DecoratedCartFactory) decorateCartItem(ctx context.Context, cartitem cart.Item, products map[string]domain.BasicProduct) DecoratedCartItem {
 	decorateditem := DecoratedCartItem{Item: cartitem}
-	product, e := df.productService.Get(ctx, cartitem.MarketplaceCode)
-	if e != nil {
-		df.logger.WithContext(ctx).Error("cart.decorator - no product for item", e)
-		if product == nil {
-			//To avoid errors if consumers want to access the product data
-			product = domain.SimpleProduct{
-				BasicProductData: domain.BasicProductData{
-					Title: cartitem.ProductName + "[outdated]",
-				},
-			}
-		}
+	product, found := products[cartitem.MarketplaceCode]
+	if !found {
+		df.logger.WithContext(ctx).Error("cart.decorator - no product for item", domain.ProductNotFound{MarketplaceCode: cartitem.MarketplaceCode})
 		return decorateditem
 	}
 	if product.Type() == domain.TypeConfigurable {
@@ -107,10 +121,48 @@ func (df *DecoratedCartFactory) decorateCartItem(ctx context.Context, cartitem c
 			}
 		}
 	}
+	if product.Type() == domain.TypeBundle {
+		if bundle, ok := product.(domain.BundleProduct); ok {
+			bundleWithChoices, err := bundle.GetBundleWithActiveChoices(cartitem.BundleConfiguration())
+			if err != nil {
+				product = domain.SimpleProduct{
+					BasicProductData: domain.BasicProductData{
+						Title: cartitem.ProductName + "[outdated]",
+					},
+				}
+			} else {
+				product = bundleWithChoices
+			}
+		}
+	}
 	decorateditem.Product = product
 	return decorateditem
 }
 
+// IsBundle - checks if current CartItem is a Bundle Product
+func (dci DecoratedCartItem) IsBundle() bool {
+	if dci.Product == nil {
+		return false
+	}
+	return dci.Product.Type() == domain.TypeBundleWithActiveChoices
+}
+
+// GetBundleChoices returns the chosen components of the bundle
+func (dci DecoratedCartItem) GetBundleChoices() []domain.BundleActiveChoice {
+	if !dci.IsBundle() {
+		return nil
+	}
+	return dci.Product.(domain.BundleProductWithActiveChoices).ActiveChoices
+}
+
+// IsBundleInStock - checks if all components of the bundle are in stock
+func (dci DecoratedCartItem) IsBundleInStock() bool {
+	if !dci.IsBundle() {
+		return false
+	}
+	return dci.Product.(domain.BundleProductWithActiveChoices).IsInStock()
+}
+
 // IsConfigurable - checks if current CartItem is a Configurable Product
 func (dci DecoratedCartItem) IsConfigurable() bool {
 	if dci.Product == nil {
@@ -199,6 +251,26 @@ func (dc DecoratedCart) GetAllDecoratedItems() []DecoratedCartItem {
 	return allItems
 }
 
+// GetAllMarketplaceCodes returns the marketplace codes of all products in the cart - including variants and bundle components
+func (dc DecoratedCart) GetAllMarketplaceCodes() []string {
+	var codes []string
+	known := make(map[string]bool)
+	add := func(code string) {
+		if code != "" && !known[code] {
+			known[code] = true
+			codes = append(codes, code)
+		}
+	}
+	for _, item := range dc.GetAllDecoratedItems() {
+		add(item.Item.MarketplaceCode)
+		add(item.Item.VariantMarketPlaceCode)
+		for _, bundleItem := range item.Item.BundleItems {
+			add(bundleItem.MarketplaceCode)
+		}
+	}
+	return codes
+}
+
 // GetDecoratedDeliveryByCode getter
 func (dc DecoratedCart) GetDecoratedDeliveryByCode(deliveryCode string) (*DecoratedDelivery, bool) {
 	for _, dd := range dc.DecoratedDeliveries {
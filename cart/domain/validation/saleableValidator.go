@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/cart/domain/decorator"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// SaleableCartValidator flags cart items whose product is not saleable anymore (e.g. the saleable window has closed).
+	// It is not bound by default - bind it as Validator or call it from your own Validator
+	SaleableCartValidator struct {
+		clock domain.Clock
+	}
+)
+
+const (
+	// ReasonNotSaleable is the AddToCartNotAllowed reason for products that are not saleable
+	ReasonNotSaleable = "product_not_saleable"
+	// ErrorMessageKeyNotSaleable is the ErrorMessageKey for cart items that are not saleable anymore
+	ErrorMessageKeyNotSaleable = "item_not_saleable"
+)
+
+var _ Validator = new(SaleableCartValidator)
+
+// Inject dependencies
+func (v *SaleableCartValidator) Inject(clock domain.Clock) {
+	v.clock = clock
+}
+
+// Validate returns an ItemValidationError for each item that is not saleable anymore
+func (v *SaleableCartValidator) Validate(ctx context.Context, session *web.Session, decoratedCart *decorator.DecoratedCart) Result {
+	result := Result{}
+	if decoratedCart == nil {
+		return result
+	}
+
+	at := v.clock.Now()
+	for _, item := range decoratedCart.GetAllDecoratedItems() {
+		if item.Product == nil {
+			continue
+		}
+		if !domain.IsProductSaleableAt(item.Product, at) {
+			result.ItemResults = append(result.ItemResults, ItemValidationError{
+				ItemID:          item.Item.ID,
+				ErrorMessageKey: ErrorMessageKeyNotSaleable,
+			})
+		}
+	}
+	return result
+}
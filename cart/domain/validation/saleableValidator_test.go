@@ -0,0 +1,38 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/cart/domain/cart"
+	"flamingo.me/flamingo-commerce/v3/cart/domain/decorator"
+	"flamingo.me/flamingo-commerce/v3/cart/domain/validation"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+func TestSaleableCartValidator_Validate(t *testing.T) {
+	end := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	decoratedCart := &decorator.DecoratedCart{
+		DecoratedDeliveries: []decorator.DecoratedDelivery{
+			{
+				DecoratedItems: []decorator.DecoratedCartItem{
+					{Item: cart.Item{ID: "1"}, Product: domain.SimpleProduct{Saleable: domain.Saleable{IsSaleable: true}}},
+					{Item: cart.Item{ID: "2"}, Product: domain.SimpleProduct{Saleable: domain.Saleable{IsSaleable: true, SaleableTo: end}}},
+				},
+			},
+		},
+	}
+
+	validator := new(validation.SaleableCartValidator)
+	validator.Inject(domain.FixedClock{Time: end.Add(-time.Hour)})
+	assert.True(t, validator.Validate(context.Background(), nil, decoratedCart).IsValid())
+
+	validator.Inject(domain.FixedClock{Time: end.Add(time.Hour)})
+	result := validator.Validate(context.Background(), nil, decoratedCart)
+	assert.False(t, result.IsValid())
+	assert.False(t, result.HasErrorForItem("1"))
+	assert.Equal(t, validation.ErrorMessageKeyNotSaleable, result.GetErrorMessageKeyForItem("2"))
+}
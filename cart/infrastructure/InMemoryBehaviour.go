@@ -164,7 +164,7 @@ func (cob *InMemoryBehaviour) AddToCart(ctx context.Context, cart *domaincart.Ca
 	itemFound := false
 
 	for i, item := range delivery.Cartitems {
-		if item.MarketplaceCode == addRequest.MarketplaceCode {
+		if item.MarketplaceCode == addRequest.MarketplaceCode && item.BundleConfiguration().Equal(cartItem.BundleConfiguration()) {
 			delivery.Cartitems[i] = *cartItem
 			itemFound = true
 		}
@@ -196,6 +196,14 @@ func (cob *InMemoryBehaviour) buildItemForCart(ctx context.Context, addRequest d
 	if err != nil {
 		return nil, err
 	}
+
+	if bundle, ok := product.(domain.BundleProduct); ok {
+		product, err = bundle.GetBundleWithActiveChoices(addRequest.BundleConfiguration)
+		if err != nil {
+			return nil, errors.Wrap(err, "cart.infrastructure.InMemoryBehaviour: invalid bundle configuration")
+		}
+	}
+
 	itemBuilder.SetQty(addRequest.Qty).AddTaxInfo("default", big.NewFloat(cob.defaultTaxRate), nil).SetByProduct(product).SetID(strconv.Itoa(rand.Int())).SetExternalReference(strconv.Itoa(rand.Int()))
 
 	return itemBuilder.Build()
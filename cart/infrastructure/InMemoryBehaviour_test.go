@@ -5,8 +5,11 @@ import (
 	"testing"
 
 	domaincart "flamingo.me/flamingo-commerce/v3/cart/domain/cart"
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
 	"flamingo.me/flamingo/v3/framework/flamingo"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInMemoryBehaviour_CleanCart(t *testing.T) {
@@ -189,3 +192,70 @@ func TestInMemoryBehaviour_CleanDelivery(t *testing.T) {
 		})
 	}
 }
+
+type bundleProductService struct{}
+
+func (bundleProductService) Get(_ context.Context, marketplaceCode string) (domain.BasicProduct, error) {
+	choice := func(marketplaceCode string, price float64) domain.BundleChoice {
+		return domain.BundleChoice{
+			BasicProductData: domain.BasicProductData{MarketPlaceCode: marketplaceCode, Title: marketplaceCode},
+			Saleable:         domain.Saleable{IsSaleable: true, ActivePrice: domain.PriceInfo{Default: priceDomain.NewFromFloat(price, "EUR")}},
+			Qty:              1,
+		}
+	}
+	return domain.BundleProduct{
+		BasicProductData: domain.BasicProductData{MarketPlaceCode: marketplaceCode, Title: "Camera Kit"},
+		BundleType:       domain.BundleTypeConfigurable,
+		Options: []domain.BundleOption{
+			{Code: "body", Required: true, Choices: []domain.BundleChoice{choice("body", 500)}},
+			{Code: "lens", Required: true, Choices: []domain.BundleChoice{choice("lens-50mm", 200), choice("lens-85mm", 300)}},
+		},
+	}, nil
+}
+
+func TestInMemoryBehaviour_AddToCartBundle(t *testing.T) {
+	cob := &InMemoryBehaviour{}
+	cob.Inject(
+		&InMemoryCartStorage{},
+		bundleProductService{},
+		flamingo.NullLogger{},
+		func() *domaincart.ItemBuilder {
+			return &domaincart.ItemBuilder{}
+		},
+		func() *domaincart.DeliveryBuilder {
+			return &domaincart.DeliveryBuilder{}
+		},
+		func() *domaincart.Builder {
+			return &domaincart.Builder{}
+		},
+		nil,
+		nil,
+	)
+	cart := &domaincart.Cart{ID: "17"}
+	if err := cob.cartStorage.StoreCart(cart); err != nil {
+		t.Fatalf("cart could not be initialized")
+	}
+
+	addRequest := domaincart.AddRequest{
+		MarketplaceCode:     "camera-kit",
+		Qty:                 1,
+		BundleConfiguration: domain.BundleConfiguration{"lens": {MarketplaceCode: "lens-85mm"}},
+	}
+	cart, _, err := cob.AddToCart(context.Background(), cart, "dev-1", addRequest)
+	assert.NoError(t, err)
+
+	addRequest.BundleConfiguration = domain.BundleConfiguration{"lens": {MarketplaceCode: "lens-50mm"}}
+	cart, _, err = cob.AddToCart(context.Background(), cart, "dev-1", addRequest)
+	assert.NoError(t, err)
+
+	items := cart.Deliveries[0].Cartitems
+	if assert.Len(t, items, 2, "bundles with different components are separate items") {
+		assert.Equal(t, "lens-85mm", items[0].BundleConfiguration()["lens"].MarketplaceCode)
+		assert.Equal(t, 800.0, items[0].SinglePriceNet.FloatAmount())
+		assert.Equal(t, 700.0, items[1].SinglePriceNet.FloatAmount())
+	}
+
+	addRequest.BundleConfiguration = domain.BundleConfiguration{"lens": {MarketplaceCode: "lens-35mm"}}
+	_, _, err = cob.AddToCart(context.Background(), cart, "dev-1", addRequest)
+	assert.Error(t, err)
+}
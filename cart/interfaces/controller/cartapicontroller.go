@@ -97,6 +97,7 @@ func (cc *CartAPIController) AddAction(ctx context.Context, r *web.Request) web.
 	deliveryCode, _ := r.Params["deliveryCode"]
 
 	addRequest := cc.cartService.BuildAddRequest(ctx, r.Params["marketplaceCode"], variantMarketplaceCode, qtyInt)
+	addRequest.BundleConfiguration = bundleConfigurationFromRequest(r)
 	_, err := cc.cartService.AddProduct(ctx, r.Session(), deliveryCode, addRequest)
 
 	result := newResult()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/gob"
 	"strconv"
+	"strings"
 
 	"flamingo.me/flamingo-commerce/v3/cart/domain/decorator"
 	"flamingo.me/flamingo-commerce/v3/cart/domain/validation"
@@ -114,6 +115,7 @@ func (cc *CartViewController) AddAndViewAction(ctx context.Context, r *web.Reque
 	deliveryCode, _ := r.Params["deliveryCode"]
 
 	addRequest := cc.applicationCartService.BuildAddRequest(ctx, r.Params["marketplaceCode"], variantMarketplaceCode, qtyInt)
+	addRequest.BundleConfiguration = bundleConfigurationFromRequest(r)
 
 	product, err := cc.applicationCartService.AddProduct(ctx, r.Session(), deliveryCode, addRequest)
 	if notAllowedErr, ok := err.(*validation.AddToCartNotAllowed); ok {
@@ -132,6 +134,29 @@ func (cc *CartViewController) AddAndViewAction(ctx context.Context, r *web.Reque
 	return cc.responder.RouteRedirect("cart.view", nil)
 }
 
+// bundleConfigurationFromRequest reads the chosen bundle components from the request values
+// in the form bundle[<optionCode>]=<marketplaceCode> and the optional bundleQty[<optionCode>]=<qty>
+func bundleConfigurationFromRequest(r *web.Request) productDomain.BundleConfiguration {
+	if err := r.Request().ParseForm(); err != nil {
+		return nil
+	}
+
+	var configuration productDomain.BundleConfiguration
+	for key, values := range r.Request().Form {
+		if !strings.HasPrefix(key, "bundle[") || !strings.HasSuffix(key, "]") || len(values) == 0 {
+			continue
+		}
+		optionCode := strings.TrimSuffix(strings.TrimPrefix(key, "bundle["), "]")
+		if configuration == nil {
+			configuration = make(productDomain.BundleConfiguration)
+		}
+		qty, _ := strconv.Atoi(r.Request().Form.Get("bundleQty[" + optionCode + "]"))
+		configuration[optionCode] = productDomain.BundleChoiceConfiguration{MarketplaceCode: values[0], Qty: qty}
+	}
+
+	return configuration
+}
+
 // UpdateQtyAndViewAction the DecoratedCart View ( / cart)
 func (cc *CartViewController) UpdateQtyAndViewAction(ctx context.Context, r *web.Request) web.Result {
 
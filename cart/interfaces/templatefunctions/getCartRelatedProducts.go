@@ -0,0 +1,52 @@
+package templatefunctions
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/cart/application"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// GetCartRelatedProducts is exported as a template function
+	GetCartRelatedProducts struct {
+		cartReceiverService    *application.CartReceiverService
+		relatedProductsService *application.RelatedProductsService
+		logger                 flamingo.Logger
+	}
+)
+
+// Inject dependencies
+func (tf *GetCartRelatedProducts) Inject(
+	cartReceiverService *application.CartReceiverService,
+	relatedProductsService *application.RelatedProductsService,
+	logger flamingo.Logger,
+) {
+	tf.cartReceiverService = cartReceiverService
+	tf.relatedProductsService = relatedProductsService
+	tf.logger = logger.WithField(flamingo.LogKeyModule, "cart").WithField(flamingo.LogKeyCategory, "getCartRelatedProducts")
+}
+
+// Func defines the getCartRelatedProducts template function - it returns the related products of all cart items
+// (optionally restricted to one relation type) without the products that are already in the cart
+func (tf *GetCartRelatedProducts) Func(ctx context.Context) interface{} {
+	return func(relationType ...string) []productDomain.BasicProduct {
+		var relation string
+		if len(relationType) > 0 {
+			relation = relationType[0]
+		}
+		session := web.SessionFromContext(ctx)
+		cart, err := tf.cartReceiverService.ViewDecoratedCart(ctx, session)
+		if err != nil || cart == nil {
+			tf.logger.WithContext(ctx).Error("Error: cart.interfaces.templatefunc %v", err)
+			return nil
+		}
+		products, err := tf.relatedProductsService.GetRelatedProducts(ctx, *cart, relation, 0)
+		if err != nil {
+			tf.logger.WithContext(ctx).Error("Error: cart.interfaces.templatefunc %v", err)
+		}
+		return products
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"flamingo.me/flamingo-commerce/v3/cart/infrastructure"
 	"flamingo.me/flamingo-commerce/v3/cart/interfaces/controller"
 	"flamingo.me/flamingo-commerce/v3/cart/interfaces/templatefunctions"
+	"flamingo.me/flamingo-commerce/v3/product"
 	"flamingo.me/flamingo/v3/core/oauth"
 	"flamingo.me/flamingo/v3/framework/config"
 	"flamingo.me/flamingo/v3/framework/flamingo"
@@ -70,6 +71,7 @@ func (m *Module) Configure(injector *dingo.Injector) {
 	// TemplateFunction
 	flamingo.BindTemplateFunc(injector, "getCart", new(templatefunctions.GetCart))
 	flamingo.BindTemplateFunc(injector, "getDecoratedCart", new(templatefunctions.GetDecoratedCart))
+	flamingo.BindTemplateFunc(injector, "getCartRelatedProducts", new(templatefunctions.GetCartRelatedProducts))
 
 	injector.Bind((*cart.DeliveryInfoBuilder)(nil)).To(cart.DefaultDeliveryInfoBuilder{})
 
@@ -104,6 +106,7 @@ func (m *Module) Depends() []dingo.Module {
 	return []dingo.Module{
 		new(oauth.Module),
 		new(form.Module),
+		new(product.Module),
 	}
 }
 
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/category/domain"
+	searchApplication "flamingo.me/flamingo-commerce/v3/search/application"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type (
+	// FacetConfigProvider returns the facet configuration of a category from a category attribute - the attribute is a json string or a map
+	// in the structure of searchDomain.FacetConfig
+	FacetConfigProvider struct {
+		categoryService domain.CategoryService
+		attribute       string
+	}
+)
+
+var _ searchDomain.FacetConfigProvider = (*FacetConfigProvider)(nil)
+
+// Inject dependencies
+func (p *FacetConfigProvider) Inject(
+	categoryService domain.CategoryService,
+	config *struct {
+		Attribute string `inject:"config:commerce.category.facetConfigAttribute,optional"`
+	},
+) {
+	p.categoryService = categoryService
+	if config != nil {
+		p.attribute = config.Attribute
+	}
+}
+
+// FacetConfig returns the facet configuration of the category attribute - nil if the category or its attribute does not exist
+func (p *FacetConfigProvider) FacetConfig(ctx context.Context, categoryCode string) (*searchDomain.FacetConfig, error) {
+	if categoryCode == "" || p.attribute == "" {
+		return nil, nil
+	}
+
+	category, err := p.categoryService.Get(ctx, categoryCode)
+	if errors.Cause(err) == domain.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := category.Attribute(p.attribute)
+	if attribute == nil {
+		return nil, nil
+	}
+	facetConfig := new(searchDomain.FacetConfig)
+	if err := searchApplication.DecodeFacetConfig(attribute, facetConfig); err != nil {
+		return nil, errors.Wrapf(err, "invalid facet configuration of category %q", categoryCode)
+	}
+	return facetConfig, nil
+}
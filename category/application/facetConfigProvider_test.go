@@ -0,0 +1,58 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"flamingo.me/flamingo-commerce/v3/category/application"
+	"flamingo.me/flamingo-commerce/v3/category/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	categoryServiceStub struct {
+		categories map[string]domain.Category
+	}
+)
+
+func (s *categoryServiceStub) Tree(context.Context, string) (domain.Tree, error) {
+	return nil, nil
+}
+
+func (s *categoryServiceStub) Get(_ context.Context, categoryCode string) (domain.Category, error) {
+	category, ok := s.categories[categoryCode]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return category, nil
+}
+
+func TestFacetConfigProvider_FacetConfig(t *testing.T) {
+	provider := new(application.FacetConfigProvider)
+	provider.Inject(&categoryServiceStub{categories: map[string]domain.Category{
+		"shirts":  domain.CategoryData{CategoryCode: "shirts", CategoryAttributes: domain.Attributes{"facetConfig": `{"include": ["color"], "facets": {"color": {"maxItems": 3}}}`}},
+		"shoes":   domain.CategoryData{CategoryCode: "shoes", CategoryAttributes: domain.Attributes{"facetConfig": map[string]interface{}{"exclude": []interface{}{"brand"}}}},
+		"invalid": domain.CategoryData{CategoryCode: "invalid", CategoryAttributes: domain.Attributes{"facetConfig": "{"}},
+		"plain":   domain.CategoryData{CategoryCode: "plain"},
+	}}, &struct {
+		Attribute string `inject:"config:commerce.category.facetConfigAttribute,optional"`
+	}{Attribute: "facetConfig"})
+
+	facetConfig, err := provider.FacetConfig(context.Background(), "shirts")
+	assert.NoError(t, err)
+	assert.Equal(t, &searchDomain.FacetConfig{Include: []string{"color"}, Facets: map[string]searchDomain.FacetSettings{"color": {MaxItems: 3}}}, facetConfig)
+
+	facetConfig, err = provider.FacetConfig(context.Background(), "shoes")
+	assert.NoError(t, err)
+	assert.Equal(t, &searchDomain.FacetConfig{Exclude: []string{"brand"}}, facetConfig)
+
+	for _, code := range []string{"plain", "unknown", ""} {
+		facetConfig, err = provider.FacetConfig(context.Background(), code)
+		assert.NoError(t, err)
+		assert.Nil(t, facetConfig)
+	}
+
+	_, err = provider.FacetConfig(context.Background(), "invalid")
+	assert.Error(t, err)
+}
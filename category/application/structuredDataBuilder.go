@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/category/domain"
+	productApplication "flamingo.me/flamingo-commerce/v3/product/application"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// StructuredDataBuilder builds schema.org structured data (JSON-LD) of category pages.
+	// Bind your own implementation to replace the DefaultStructuredDataBuilder
+	StructuredDataBuilder interface {
+		// BuildCategory returns a CollectionPage with the products of the page as ItemList
+		BuildCategory(ctx context.Context, category domain.Category, products []productDomain.BasicProduct) productApplication.JSONLD
+	}
+
+	// DefaultStructuredDataBuilder builds a CollectionPage with an ItemList of the product urls
+	DefaultStructuredDataBuilder struct {
+		router            *web.Router
+		productURLService *productApplication.URLService
+	}
+)
+
+const schemaContext = "https://schema.org"
+
+var _ StructuredDataBuilder = new(DefaultStructuredDataBuilder)
+
+// Inject dependencies
+func (b *DefaultStructuredDataBuilder) Inject(router *web.Router, productURLService *productApplication.URLService) {
+	b.router = router
+	b.productURLService = productURLService
+}
+
+// BuildCategory returns the structured data of the category page - the urls are only added if the context belongs to a request
+func (b *DefaultStructuredDataBuilder) BuildCategory(ctx context.Context, category domain.Category, products []productDomain.BasicProduct) productApplication.JSONLD {
+	if category == nil {
+		return nil
+	}
+
+	items := make([]productApplication.JSONLD, 0, len(products))
+	for _, product := range products {
+		if product == nil {
+			continue
+		}
+		item := productApplication.JSONLD{
+			"@type":    "ListItem",
+			"position": len(items) + 1,
+			"name":     product.BaseData().Title,
+		}
+		if url := b.productURL(ctx, product); url != "" {
+			item["url"] = url
+		}
+		items = append(items, item)
+	}
+
+	data := productApplication.JSONLD{
+		"@context": schemaContext,
+		"@type":    "CollectionPage",
+		"name":     category.Name(),
+		"mainEntity": productApplication.JSONLD{
+			"@type":           "ItemList",
+			"numberOfItems":   len(items),
+			"itemListElement": items,
+		},
+	}
+	if url := b.categoryURL(ctx, category); url != "" {
+		data["url"] = url
+	}
+	return data
+}
+
+func (b *DefaultStructuredDataBuilder) categoryURL(ctx context.Context, category domain.Category) string {
+	r := web.RequestFromContext(ctx)
+	if r == nil || b.router == nil {
+		return ""
+	}
+	name, params := URLWithName(category.Code(), web.URLTitle(category.Name()))
+	url, err := b.router.Absolute(r, name, params)
+	if err != nil || url == nil {
+		return ""
+	}
+	return url.String()
+}
+
+func (b *DefaultStructuredDataBuilder) productURL(ctx context.Context, product productDomain.BasicProduct) string {
+	r := web.RequestFromContext(ctx)
+	if r == nil || b.productURLService == nil {
+		return ""
+	}
+	url, err := b.productURLService.GetAbsolute(r, product, "")
+	if err != nil {
+		return ""
+	}
+	return url
+}
@@ -0,0 +1,47 @@
+package application_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/category/application"
+	"flamingo.me/flamingo-commerce/v3/category/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+func TestDefaultStructuredDataBuilder_BuildCategory(t *testing.T) {
+	builder := new(application.DefaultStructuredDataBuilder)
+	builder.Inject(nil, nil)
+
+	category := domain.CategoryData{CategoryCode: "shirts", CategoryName: "Shirts"}
+	products := []productDomain.BasicProduct{
+		productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt-1", Title: "Pink shirt"}},
+		nil,
+		productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt-2", Title: "Blue shirt"}},
+	}
+
+	data, err := json.Marshal(builder.BuildCategory(context.Background(), category, products))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@context": "https://schema.org",
+		"@type": "CollectionPage",
+		"name": "Shirts",
+		"mainEntity": {
+			"@type": "ItemList",
+			"numberOfItems": 2,
+			"itemListElement": [
+				{"@type": "ListItem", "position": 1, "name": "Pink shirt"},
+				{"@type": "ListItem", "position": 2, "name": "Blue shirt"}
+			]
+		}
+	}`, string(data), "urls are only added for requests")
+
+	data, err = json.Marshal(builder.BuildCategory(context.Background(), category, nil))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"itemListElement":[]`, "categories without products have an empty list")
+
+	assert.Nil(t, builder.BuildCategory(context.Background(), nil, products))
+}
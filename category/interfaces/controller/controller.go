@@ -92,8 +92,12 @@ func (vc *ViewController) Get(c context.Context, request *web.Request) web.Resul
 		return vc.responder.URLRedirect(u).Permanent()
 	}
 
-	searchRequest := &searchApplication.SearchRequest{}
-	searchRequest.AddAdditionalFilters(searchdomain.NewKeyValueFilters(request.QueryAll())...)
+	// the category of the page replaces a category filter of the url
+	filterParams := request.QueryAll()
+	delete(filterParams, string(domain.CategoryKey))
+	searchRequest := &searchApplication.SearchRequest{
+		FilterParams: filterParams,
+	}
 	searchRequest.SetAdditionalFilter(domain.NewCategoryFacet(currentCategory.Code()))
 
 	products, err := vc.SearchService.Find(c, searchRequest)
@@ -0,0 +1,41 @@
+package templatefunctions
+
+import (
+	"context"
+	"encoding/json"
+
+	"flamingo.me/flamingo-commerce/v3/category/application"
+	"flamingo.me/flamingo-commerce/v3/category/domain"
+	productApplication "flamingo.me/flamingo-commerce/v3/product/application"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// CategoryJSONLD is exported as a template function
+	CategoryJSONLD struct {
+		StructuredDataBuilder application.StructuredDataBuilder `inject:""`
+		Logger                flamingo.Logger                   `inject:""`
+	}
+)
+
+// Func returns the schema.org structured data of the category page with the products of the search result as JSON-LD string -
+// to be used in a script tag of type "application/ld+json"
+func (tf *CategoryJSONLD) Func(ctx context.Context) interface{} {
+	return func(category domain.Category, searchResult *productApplication.SearchResult) string {
+		var products []productDomain.BasicProduct
+		if searchResult != nil {
+			products = searchResult.Products
+		}
+		data := tf.StructuredDataBuilder.BuildCategory(ctx, category, products)
+		if data == nil {
+			return ""
+		}
+		result, err := json.Marshal(data)
+		if err != nil {
+			tf.Logger.WithContext(ctx).WithField("category", "category").Error(err)
+			return ""
+		}
+		return string(result)
+	}
+}
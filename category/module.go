@@ -6,13 +6,17 @@ import (
 	"flamingo.me/flamingo-commerce/v3/category/domain"
 	"flamingo.me/flamingo-commerce/v3/category/infrastructure"
 	"flamingo.me/flamingo-commerce/v3/category/interfaces/controller"
+	"flamingo.me/flamingo-commerce/v3/category/interfaces/templatefunctions"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
 	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/flamingo/v3/framework/web"
 )
 
 // Module registers our profiler
 type Module struct {
 	useCategoryFixedAdapter bool
+	facetConfigAttribute    string
 }
 
 // URL to category
@@ -29,11 +33,13 @@ func URLWithName(code, name string) (string, map[string]string) {
 func (m *Module) Inject(
 	routerRegistry *web.RouterRegistry,
 	config *struct {
-		UseCategoryFixedAdapter bool `inject:"config:commerce.category.useCategoryFixedAdapter,optional"`
+		UseCategoryFixedAdapter bool   `inject:"config:commerce.category.useCategoryFixedAdapter,optional"`
+		FacetConfigAttribute    string `inject:"config:commerce.category.facetConfigAttribute,optional"`
 	},
 ) {
 	if config != nil {
 		m.useCategoryFixedAdapter = config.UseCategoryFixedAdapter
+		m.facetConfigAttribute = config.FacetConfigAttribute
 	}
 }
 
@@ -43,6 +49,11 @@ func (m *Module) Configure(injector *dingo.Injector) {
 		injector.Bind((*domain.CategoryService)(nil)).To(infrastructure.CategoryServiceFixed{})
 
 	}
+	if m.facetConfigAttribute != "" {
+		injector.BindMulti((*searchDomain.FacetConfigProvider)(nil)).To(application.FacetConfigProvider{})
+	}
+	injector.Bind((*application.StructuredDataBuilder)(nil)).To(application.DefaultStructuredDataBuilder{})
+	flamingo.BindTemplateFunc(injector, "categoryJsonLd", new(templatefunctions.CategoryJSONLD))
 	web.BindRoutes(injector, new(routes))
 	injector.Bind(new(application.RouterRouter)).To(new(web.Router))
 }
@@ -50,8 +61,9 @@ func (m *Module) Configure(injector *dingo.Injector) {
 // DefaultConfig for this module
 func (m *Module) DefaultConfig() config.Map {
 	return config.Map{
-		"commerce.category.view.template":       "category/category",
-		"commerce.category.view.teaserTemplate": "category/teaser",
+		"commerce.category.view.template":        "category/category",
+		"commerce.category.view.teaserTemplate":  "category/teaser",
+		"commerce.category.facetConfigAttribute": "",
 	}
 }
 
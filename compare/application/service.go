@@ -0,0 +1,131 @@
+package application
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/compare/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// Service manages the compare list of the session
+	Service struct {
+		productService productDomain.ProductService
+		logger         flamingo.Logger
+		maxProducts    int
+		attributes     []string
+	}
+)
+
+const (
+	// SessionKey is used to store the compare list in the session
+	SessionKey = "compare.list"
+
+	defaultMaxProducts = 4
+)
+
+// Inject dependencies
+func (s *Service) Inject(
+	productService productDomain.ProductService,
+	logger flamingo.Logger,
+	config *struct {
+		MaxProducts float64      `inject:"config:commerce.compare.maxProducts,optional"`
+		Attributes  config.Slice `inject:"config:commerce.compare.attributes,optional"`
+	},
+) {
+	s.productService = productService
+	s.logger = logger.WithField(flamingo.LogKeyModule, "compare").WithField(flamingo.LogKeyCategory, "application.Service")
+	s.maxProducts = defaultMaxProducts
+	if config != nil {
+		if config.MaxProducts > 0 {
+			s.maxProducts = int(config.MaxProducts)
+		}
+		var attributes []string
+		if err := config.Attributes.MapInto(&attributes); err == nil {
+			s.attributes = attributes
+		}
+	}
+}
+
+// Add the product (for configurables the variant) to the compare list - domain.ErrListFull is returned if the maximum number of products is reached
+func (s *Service) Add(ctx context.Context, session *web.Session, marketplaceCode string, variantMarketplaceCode string) error {
+	product, err := s.productService.Get(ctx, marketplaceCode)
+	if err != nil {
+		return err
+	}
+	if variantMarketplaceCode != "" {
+		configurable, ok := product.(productDomain.ConfigurableProduct)
+		if !ok || !configurable.HasVariant(variantMarketplaceCode) {
+			return errors.Wrapf(productDomain.ProductNotFound{MarketplaceCode: variantMarketplaceCode}, "product %q has no variant %q", marketplaceCode, variantMarketplaceCode)
+		}
+	}
+
+	list, err := s.List(session).Add(domain.Entry{MarketplaceCode: marketplaceCode, VariantMarketplaceCode: variantMarketplaceCode}, s.maxProducts)
+	if err != nil {
+		return err
+	}
+	session.Store(SessionKey, list)
+	return nil
+}
+
+// Remove the product (given by the marketplace code of the product or the variant) from the compare list
+func (s *Service) Remove(session *web.Session, marketplaceCode string) {
+	session.Store(SessionKey, s.List(session).Remove(marketplaceCode))
+}
+
+// Clear removes all products from the compare list
+func (s *Service) Clear(session *web.Session) {
+	session.Delete(SessionKey)
+}
+
+// List returns the compare list of the session
+func (s *Service) List(session *web.Session) domain.List {
+	if list, ok := session.Load(SessionKey); ok {
+		if list, ok := list.(domain.List); ok {
+			return list
+		}
+	}
+	return nil
+}
+
+// Products returns the compared products - configurables with their active variant. Products that are not found anymore or could not be loaded are skipped
+func (s *Service) Products(ctx context.Context, session *web.Session) ([]productDomain.BasicProduct, error) {
+	list := s.List(session)
+	loaded, err := productDomain.GetMany(ctx, s.productService, list.MarketplaceCodes()...)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("not all compared products could be loaded: ", err)
+	}
+
+	products := make([]productDomain.BasicProduct, 0, len(list))
+	for _, entry := range list {
+		product, ok := loaded[entry.MarketplaceCode]
+		if !ok {
+			continue
+		}
+		if configurable, ok := product.(productDomain.ConfigurableProduct); ok && entry.VariantMarketplaceCode != "" {
+			withActiveVariant, err := configurable.GetConfigurableWithActiveVariant(entry.VariantMarketplaceCode)
+			if err != nil {
+				s.logger.WithContext(ctx).Warn("compared variant not found: ", err)
+				continue
+			}
+			product = withActiveVariant
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+// Comparison returns the aligned specifications and attributes of the compared products
+func (s *Service) Comparison(ctx context.Context, session *web.Session) (*domain.Comparison, error) {
+	products, err := s.Products(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	comparison := domain.NewComparison(products, s.attributes)
+	return &comparison, nil
+}
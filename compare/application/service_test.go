@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/compare/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type productServiceStub struct{}
+
+func (productServiceStub) Get(_ context.Context, marketplaceCode string) (productDomain.BasicProduct, error) {
+	switch marketplaceCode {
+	case "shirt":
+		return productDomain.ConfigurableProduct{
+			BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt"},
+			Variants: []productDomain.Variant{
+				{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt-red", Attributes: productDomain.Attributes{"color": {Code: "color", RawValue: "red"}}}},
+			},
+		}, nil
+	case "unknown":
+		return nil, productDomain.ProductNotFound{MarketplaceCode: marketplaceCode}
+	case "failing":
+		return nil, errors.New("backend error")
+	}
+	return productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{
+		MarketPlaceCode: marketplaceCode,
+		Attributes:      productDomain.Attributes{"color": {Code: "color", RawValue: "white"}},
+	}}, nil
+}
+
+func TestService(t *testing.T) {
+	s := new(Service)
+	s.Inject(productServiceStub{}, flamingo.NullLogger{}, &struct {
+		MaxProducts float64      `inject:"config:commerce.compare.maxProducts,optional"`
+		Attributes  config.Slice `inject:"config:commerce.compare.attributes,optional"`
+	}{MaxProducts: 2})
+
+	ctx := context.Background()
+	session := web.EmptySession()
+
+	assert.NoError(t, s.Add(ctx, session, "mug", ""))
+	_, notFound := errors.Cause(s.Add(ctx, session, "unknown", "")).(productDomain.ProductNotFound)
+	assert.True(t, notFound)
+	_, notFound = errors.Cause(s.Add(ctx, session, "mug", "mug-red")).(productDomain.ProductNotFound)
+	assert.True(t, notFound, "variants can only be added for configurables")
+	assert.NoError(t, s.Add(ctx, session, "shirt", "shirt-red"))
+	assert.Equal(t, domain.ErrListFull, s.Add(ctx, session, "cup", ""))
+
+	comparison, err := s.Comparison(ctx, session)
+	assert.NoError(t, err)
+	if assert.Len(t, comparison.Products, 2) {
+		assert.Equal(t, productDomain.TypeConfigurableWithActiveVariant, comparison.Products[1].Type())
+	}
+	if assert.Len(t, comparison.Attributes, 1) {
+		assert.True(t, comparison.Attributes[0].IsDifferent)
+	}
+
+	s.Remove(session, "shirt-red")
+	assert.Equal(t, []string{"mug"}, s.List(session).MarketplaceCodes())
+	s.Clear(session)
+	assert.Empty(t, s.List(session))
+}
+
+func TestService_ComparisonSkipsFailingProducts(t *testing.T) {
+	s := new(Service)
+	s.Inject(productServiceStub{}, flamingo.NullLogger{}, nil)
+
+	ctx := context.Background()
+	session := web.EmptySession()
+	session.Store(SessionKey, domain.List{{MarketplaceCode: "mug"}, {MarketplaceCode: "failing"}, {MarketplaceCode: "unknown"}, {MarketplaceCode: "cup"}})
+
+	comparison, err := s.Comparison(ctx, session)
+	assert.NoError(t, err, "products that can't be loaded don't fail the comparison")
+	if assert.Len(t, comparison.Products, 2) {
+		assert.Equal(t, "mug", comparison.Products[0].BaseData().MarketPlaceCode)
+		assert.Equal(t, "cup", comparison.Products[1].BaseData().MarketPlaceCode)
+	}
+}
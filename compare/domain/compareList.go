@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/gob"
+	"errors"
+)
+
+type (
+	// Entry is a product on the compare list - variants are stored with the marketplace code of their configurable
+	Entry struct {
+		MarketplaceCode        string
+		VariantMarketplaceCode string
+	}
+
+	// List of compared products in the order they were added
+	List []Entry
+)
+
+// ErrListFull is returned if the maximum number of compared products is reached
+var ErrListFull = errors.New("the compare list is full")
+
+func init() {
+	gob.Register(List{})
+}
+
+// Add appends the entry - an entry of the same product (e.g. another variant of the configurable) is replaced.
+// If maxEntries is greater than 0 and reached ErrListFull is returned
+func (l List) Add(entry Entry, maxEntries int) (List, error) {
+	for i, existing := range l {
+		if existing.MarketplaceCode == entry.MarketplaceCode {
+			result := append(List(nil), l...)
+			result[i] = entry
+			return result, nil
+		}
+	}
+
+	if maxEntries > 0 && len(l) >= maxEntries {
+		return l, ErrListFull
+	}
+	return append(append(List(nil), l...), entry), nil
+}
+
+// Remove the entry of the product (given by the marketplace code of the product or of the variant)
+func (l List) Remove(marketplaceCode string) List {
+	result := make(List, 0, len(l))
+	for _, existing := range l {
+		if existing.MarketplaceCode != marketplaceCode && existing.VariantMarketplaceCode != marketplaceCode {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+// Contains checks if the product (given by the marketplace code of the product or of the variant) is on the list
+func (l List) Contains(marketplaceCode string) bool {
+	for _, existing := range l {
+		if existing.MarketplaceCode == marketplaceCode || existing.VariantMarketplaceCode == marketplaceCode {
+			return true
+		}
+	}
+	return false
+}
+
+// MarketplaceCodes of all entries
+func (l List) MarketplaceCodes() []string {
+	codes := make([]string, len(l))
+	for i, entry := range l {
+		codes[i] = entry.MarketplaceCode
+	}
+	return codes
+}
@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/compare/domain"
+)
+
+func TestList_Add(t *testing.T) {
+	var list domain.List
+	var err error
+
+	list, err = list.Add(domain.Entry{MarketplaceCode: "mug"}, 2)
+	assert.NoError(t, err)
+	list, err = list.Add(domain.Entry{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-red"}, 2)
+	assert.NoError(t, err)
+
+	list, err = list.Add(domain.Entry{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-blue"}, 2)
+	assert.NoError(t, err, "another variant replaces the entry")
+	assert.Equal(t, domain.List{{MarketplaceCode: "mug"}, {MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-blue"}}, list)
+
+	_, err = list.Add(domain.Entry{MarketplaceCode: "cup"}, 2)
+	assert.Equal(t, domain.ErrListFull, err)
+
+	assert.True(t, list.Contains("shirt-blue"))
+	list = list.Remove("shirt-blue")
+	assert.Equal(t, []string{"mug"}, list.MarketplaceCodes())
+	assert.False(t, list.Contains("shirt"))
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// Comparison aligns the specifications and attributes of the compared products
+	Comparison struct {
+		Products       []productDomain.BasicProduct `json:"products"`
+		Specifications []ComparisonGroup            `json:"specifications"`
+		Attributes     []ComparisonRow              `json:"attributes"`
+	}
+
+	// ComparisonGroup contains the rows of a specification group
+	ComparisonGroup struct {
+		Title string          `json:"title"`
+		Rows  []ComparisonRow `json:"rows"`
+	}
+
+	// ComparisonRow contains one value per product (in the order of Comparison.Products)
+	ComparisonRow struct {
+		// Code of the attribute - empty for specification entries
+		Code        string            `json:"code,omitempty"`
+		Label       string            `json:"label"`
+		Values      []ComparisonValue `json:"values"`
+		IsDifferent bool              `json:"isDifferent"`
+	}
+
+	// ComparisonValue of a product - Exists is false if the product has no value for the row
+	ComparisonValue struct {
+		Value  string `json:"value"`
+		Exists bool   `json:"exists"`
+	}
+
+	comparedData struct {
+		attributes     productDomain.Attributes
+		specifications productDomain.Specifications
+	}
+)
+
+// specificationsAttribute is the attribute code of the specifications - it is compared as specifications, not as attribute
+const specificationsAttribute = "specifications"
+
+// NewComparison builds the comparison of the products. Configurables are compared with the data of their active variant
+// (falling back to the data of the configurable). If attributeCodes are given only those attributes are compared,
+// otherwise all attributes of the products ordered by code. Numeric attributes with units are converted into the same unit
+func NewComparison(products []productDomain.BasicProduct, attributeCodes []string) Comparison {
+	comparison := Comparison{Products: products}
+
+	data := make([]comparedData, len(products))
+	for i, product := range products {
+		data[i] = dataOf(product)
+	}
+
+	if len(attributeCodes) == 0 {
+		attributeCodes = allAttributeCodes(data)
+	}
+	for _, code := range attributeCodes {
+		if row, ok := attributeRow(code, data); ok {
+			comparison.Attributes = append(comparison.Attributes, row)
+		}
+	}
+
+	comparison.Specifications = specificationGroups(data)
+	return comparison
+}
+
+// dataOf returns the attributes and specifications to compare - configurables use the active variant
+func dataOf(product productDomain.BasicProduct) comparedData {
+	withActiveVariant, ok := product.(productDomain.ConfigurableProductWithActiveVariant)
+	if !ok {
+		return comparedData{attributes: product.BaseData().Attributes, specifications: product.BaseData().GetSpecifications()}
+	}
+
+	attributes := make(productDomain.Attributes)
+	for code, attribute := range withActiveVariant.ConfigurableBaseData().Attributes {
+		attributes[code] = attribute
+	}
+	for code, attribute := range withActiveVariant.BaseData().Attributes {
+		attributes[code] = attribute
+	}
+
+	specifications := withActiveVariant.BaseData().GetSpecifications()
+	if len(specifications.Groups) == 0 {
+		specifications = withActiveVariant.ConfigurableBaseData().GetSpecifications()
+	}
+	return comparedData{attributes: attributes, specifications: specifications}
+}
+
+func allAttributeCodes(data []comparedData) []string {
+	known := make(map[string]bool)
+	var codes []string
+	for _, d := range data {
+		for code := range d.attributes {
+			if code == specificationsAttribute || known[code] {
+				continue
+			}
+			known[code] = true
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// attributeRow returns the row of the attribute - ok is false if no product has the attribute
+func attributeRow(code string, data []comparedData) (ComparisonRow, bool) {
+	row := ComparisonRow{Code: code, Values: make([]ComparisonValue, len(data))}
+
+	var found bool
+	var unitCode string
+	for i, d := range data {
+		attribute, ok := d.attributes[code]
+		if !ok {
+			continue
+		}
+		if !found {
+			found = true
+			unitCode = targetUnit(attribute)
+		}
+		if row.Label == "" {
+			row.Label = attribute.Label
+		}
+		row.Values[i] = ComparisonValue{Value: formatAttribute(attribute, unitCode), Exists: true}
+	}
+	if row.Label == "" {
+		row.Label = code
+	}
+
+	row.IsDifferent = isDifferent(row.Values)
+	return row, found
+}
+
+// targetUnit is the normalized unit of the attribute that is used for all values of the row
+func targetUnit(attribute productDomain.Attribute) string {
+	value, err := attribute.FloatValue()
+	if err != nil || !attribute.HasUnitCode() {
+		return ""
+	}
+	_, unitCode := productDomain.NormalizeUnit(value, attribute.UnitCode)
+	return unitCode
+}
+
+func formatAttribute(attribute productDomain.Attribute, unitCode string) string {
+	if attribute.HasMultipleValues() {
+		return strings.Join(attribute.Values(), ", ")
+	}
+	return attribute.FormatWithUnit(unitCode)
+}
+
+// specificationGroups aligns the specification groups and entries by title and label in the order of their first occurrence
+func specificationGroups(data []comparedData) []ComparisonGroup {
+	var groups []ComparisonGroup
+	groupIndex := make(map[string]int)
+	rowIndex := make(map[string]map[string]int)
+
+	for i, d := range data {
+		for _, group := range d.specifications.Groups {
+			g, ok := groupIndex[group.Title]
+			if !ok {
+				g = len(groups)
+				groupIndex[group.Title] = g
+				rowIndex[group.Title] = make(map[string]int)
+				groups = append(groups, ComparisonGroup{Title: group.Title})
+			}
+
+			for _, entry := range group.Entries {
+				r, ok := rowIndex[group.Title][entry.Label]
+				if !ok {
+					r = len(groups[g].Rows)
+					rowIndex[group.Title][entry.Label] = r
+					groups[g].Rows = append(groups[g].Rows, ComparisonRow{Label: entry.Label, Values: make([]ComparisonValue, len(data))})
+				}
+				groups[g].Rows[r].Values[i] = ComparisonValue{Value: strings.Join(entry.Values, ", "), Exists: true}
+			}
+		}
+	}
+
+	for g := range groups {
+		for r := range groups[g].Rows {
+			groups[g].Rows[r].IsDifferent = isDifferent(groups[g].Rows[r].Values)
+		}
+	}
+	return groups
+}
+
+func isDifferent(values []ComparisonValue) bool {
+	if len(values) < 2 {
+		return false
+	}
+	for _, value := range values[1:] {
+		if value != values[0] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/compare/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+func specifications(groups ...productDomain.SpecificationGroup) productDomain.Attribute {
+	return productDomain.Attribute{Code: "specifications", RawValue: productDomain.Specifications{Groups: groups}}
+}
+
+func TestNewComparison(t *testing.T) {
+	mug := productDomain.SimpleProduct{
+		BasicProductData: productDomain.BasicProductData{
+			MarketPlaceCode: "mug",
+			Attributes: productDomain.Attributes{
+				"weight":         {Code: "weight", Label: "Weight", RawValue: "1500", UnitCode: productDomain.GRAM},
+				"color":          {Code: "color", Label: "Color", RawValue: "white"},
+				"specifications": specifications(productDomain.SpecificationGroup{Title: "Material", Entries: []productDomain.SpecificationEntry{{Label: "Body", Values: []string{"ceramic"}}}}),
+			},
+		},
+	}
+	cup := productDomain.ConfigurableProduct{
+		BasicProductData: productDomain.BasicProductData{
+			MarketPlaceCode: "cup",
+			Attributes: productDomain.Attributes{
+				"color": {Code: "color", Label: "Color", RawValue: "white"},
+				"specifications": specifications(
+					productDomain.SpecificationGroup{Title: "Care", Entries: []productDomain.SpecificationEntry{{Label: "Dishwasher", Values: []string{"yes"}}}},
+					productDomain.SpecificationGroup{Title: "Material", Entries: []productDomain.SpecificationEntry{{Label: "Body", Values: []string{"glass"}}, {Label: "Lid", Values: []string{"steel", "silicone"}}}},
+				),
+			},
+		},
+		Variants: []productDomain.Variant{
+			{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "cup-large", Attributes: productDomain.Attributes{"weight": {Code: "weight", RawValue: "1.5", UnitCode: productDomain.KILOGRAM}}}},
+		},
+	}
+	cupLarge, err := cup.GetConfigurableWithActiveVariant("cup-large")
+	assert.NoError(t, err)
+
+	comparison := domain.NewComparison([]productDomain.BasicProduct{mug, cupLarge}, nil)
+	assert.Equal(t, []domain.ComparisonRow{
+		{Code: "color", Label: "Color", Values: []domain.ComparisonValue{{Value: "white", Exists: true}, {Value: "white", Exists: true}}},
+		{Code: "weight", Label: "Weight", Values: []domain.ComparisonValue{{Value: "1.5 kg", Exists: true}, {Value: "1.5 kg", Exists: true}}},
+	}, comparison.Attributes, "the attributes of the configurable are used for the variant and units are aligned")
+
+	assert.Equal(t, []domain.ComparisonGroup{
+		{Title: "Material", Rows: []domain.ComparisonRow{
+			{Label: "Body", Values: []domain.ComparisonValue{{Value: "ceramic", Exists: true}, {Value: "glass", Exists: true}}, IsDifferent: true},
+			{Label: "Lid", Values: []domain.ComparisonValue{{}, {Value: "steel, silicone", Exists: true}}, IsDifferent: true},
+		}},
+		{Title: "Care", Rows: []domain.ComparisonRow{
+			{Label: "Dishwasher", Values: []domain.ComparisonValue{{}, {Value: "yes", Exists: true}}, IsDifferent: true},
+		}},
+	}, comparison.Specifications)
+
+	comparison = domain.NewComparison([]productDomain.BasicProduct{mug, cupLarge}, []string{"weight", "unknown", "color"})
+	if assert.Len(t, comparison.Attributes, 2, "only the configured attributes are compared") {
+		assert.Equal(t, "weight", comparison.Attributes[0].Code)
+		assert.Equal(t, "color", comparison.Attributes[1].Code)
+	}
+}
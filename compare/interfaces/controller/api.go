@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"flamingo.me/flamingo-commerce/v3/compare/application"
+	"flamingo.me/flamingo-commerce/v3/compare/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// APIController handles the compare list as JSON
+	APIController struct {
+		responder *web.Responder
+		service   *application.Service
+		logger    flamingo.Logger
+	}
+
+	// APIResult is the JSON result of the compare api
+	APIResult struct {
+		Success    bool               `json:"success"`
+		Error      *APIError          `json:"error,omitempty"`
+		Comparison *domain.Comparison `json:"comparison,omitempty"`
+	}
+
+	// APIError contains details if success is false
+	APIError struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	}
+)
+
+// Inject dependencies
+func (c *APIController) Inject(responder *web.Responder, service *application.Service, logger flamingo.Logger) {
+	c.responder = responder
+	c.service = service
+	c.logger = logger.WithField(flamingo.LogKeyModule, "compare").WithField(flamingo.LogKeyCategory, "controller.APIController")
+}
+
+// GetAction returns the comparison of the products on the compare list
+func (c *APIController) GetAction(ctx context.Context, r *web.Request) web.Result {
+	return c.comparison(ctx, r)
+}
+
+// AddAction adds the product (with the optional variantcode) and returns the comparison
+func (c *APIController) AddAction(ctx context.Context, r *web.Request) web.Result {
+	if err := c.service.Add(ctx, r.Session(), r.Params["marketplacecode"], r.Params["variantcode"]); err != nil {
+		code := errorCode(err)
+		status := uint(http.StatusBadRequest)
+		if code == errorNotFound {
+			status = http.StatusNotFound
+		}
+		return c.responder.Data(APIResult{Error: &APIError{Message: err.Error(), Code: code}}).Status(status)
+	}
+	return c.comparison(ctx, r)
+}
+
+// RemoveAction removes the product and returns the comparison
+func (c *APIController) RemoveAction(ctx context.Context, r *web.Request) web.Result {
+	c.service.Remove(r.Session(), r.Params["marketplacecode"])
+	return c.comparison(ctx, r)
+}
+
+func (c *APIController) comparison(ctx context.Context, r *web.Request) web.Result {
+	comparison, err := c.service.Comparison(ctx, r.Session())
+	if err != nil {
+		c.logger.WithContext(ctx).Error("comparison could not be built: ", err)
+		return c.responder.Data(APIResult{Error: &APIError{Message: err.Error(), Code: "get_error"}}).Status(http.StatusInternalServerError)
+	}
+	return c.responder.Data(APIResult{Success: true, Comparison: comparison})
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/compare/application"
+	"flamingo.me/flamingo-commerce/v3/compare/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// ViewController renders the comparison and handles the add and remove actions
+	ViewController struct {
+		responder *web.Responder
+		service   *application.Service
+		logger    flamingo.Logger
+		template  string
+	}
+
+	// viewData is used for the comparison rendering
+	viewData struct {
+		Comparison *domain.Comparison
+		// ErrorMessageKey is set if the last add action failed (compare_list_full or compare_add_error)
+		ErrorMessageKey string
+	}
+)
+
+const (
+	errorFlashKey = "compare.view.error"
+
+	errorListFull = "compare_list_full"
+	errorNotFound = "product_not_found"
+	errorAdd      = "compare_add_error"
+)
+
+// Inject dependencies
+func (c *ViewController) Inject(
+	responder *web.Responder,
+	service *application.Service,
+	logger flamingo.Logger,
+	config *struct {
+		Template string `inject:"config:commerce.compare.template,optional"`
+	},
+) {
+	c.responder = responder
+	c.service = service
+	c.logger = logger.WithField(flamingo.LogKeyModule, "compare").WithField(flamingo.LogKeyCategory, "controller.ViewController")
+	if config != nil {
+		c.template = config.Template
+	}
+}
+
+// ViewAction renders the comparison of the products on the compare list
+func (c *ViewController) ViewAction(ctx context.Context, r *web.Request) web.Result {
+	comparison, err := c.service.Comparison(ctx, r.Session())
+	if err != nil {
+		return c.responder.ServerError(err)
+	}
+
+	data := viewData{Comparison: comparison}
+	if flashes := r.Session().Flashes(errorFlashKey); len(flashes) > 0 {
+		data.ErrorMessageKey, _ = flashes[0].(string)
+	}
+	return c.responder.Render(c.template, data)
+}
+
+// AddAction adds the product to the compare list and redirects to the comparison
+func (c *ViewController) AddAction(ctx context.Context, r *web.Request) web.Result {
+	if err := c.service.Add(ctx, r.Session(), r.Params["marketplacecode"], r.Params["variantcode"]); err != nil {
+		c.logger.WithContext(ctx).Warn("product could not be added: ", err)
+		r.Session().AddFlash(errorCode(err), errorFlashKey)
+	}
+	return c.responder.RouteRedirect("compare.view", nil)
+}
+
+// RemoveAction removes the product from the compare list and redirects to the comparison
+func (c *ViewController) RemoveAction(ctx context.Context, r *web.Request) web.Result {
+	c.service.Remove(r.Session(), r.Params["marketplacecode"])
+	return c.responder.RouteRedirect("compare.view", nil)
+}
+
+// errorCode maps the errors of the add action
+func errorCode(err error) string {
+	cause := errors.Cause(err)
+	if _, ok := cause.(productDomain.ProductNotFound); ok {
+		return errorNotFound
+	}
+	if cause == domain.ErrListFull {
+		return errorListFull
+	}
+	return errorAdd
+}
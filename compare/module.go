@@ -0,0 +1,64 @@
+package compare
+
+import (
+	"flamingo.me/dingo"
+
+	"flamingo.me/flamingo-commerce/v3/compare/interfaces/controller"
+	"flamingo.me/flamingo-commerce/v3/product"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+// Module for the product comparison
+type Module struct{}
+
+// Configure module
+func (m *Module) Configure(injector *dingo.Injector) {
+	web.BindRoutes(injector, new(routes))
+}
+
+// DefaultConfig for this module
+func (m *Module) DefaultConfig() config.Map {
+	return config.Map{
+		"commerce.compare": config.Map{
+			"template":    "compare/compare",
+			"maxProducts": float64(4),
+			"attributes":  config.Slice{},
+		},
+	}
+}
+
+// Depends on other modules
+func (m *Module) Depends() []dingo.Module {
+	return []dingo.Module{
+		new(product.Module),
+	}
+}
+
+type routes struct {
+	viewController *controller.ViewController
+	apiController  *controller.APIController
+}
+
+// Inject required dependencies
+func (r *routes) Inject(viewController *controller.ViewController, apiController *controller.APIController) {
+	r.viewController = viewController
+	r.apiController = apiController
+}
+
+// Routes of the compare module
+func (r *routes) Routes(registry *web.RouterRegistry) {
+	registry.HandleGet("compare.view", r.viewController.ViewAction)
+	registry.Route("/compare", "compare.view")
+	registry.HandleAny("compare.add", r.viewController.AddAction)
+	registry.Route("/compare/add/:marketplacecode", `compare.add(marketplacecode, variantcode?="")`)
+	registry.HandleAny("compare.remove", r.viewController.RemoveAction)
+	registry.Route("/compare/remove/:marketplacecode", "compare.remove(marketplacecode)")
+
+	registry.HandleGet("compare.api.get", r.apiController.GetAction)
+	registry.Route("/api/compare", "compare.api.get")
+	registry.HandlePost("compare.api.add", r.apiController.AddAction)
+	registry.Route("/api/compare/add/:marketplacecode", `compare.api.add(marketplacecode, variantcode?="")`)
+	registry.HandlePost("compare.api.remove", r.apiController.RemoveAction)
+	registry.Route("/api/compare/remove/:marketplacecode", "compare.api.remove(marketplacecode)")
+}
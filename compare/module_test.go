@@ -0,0 +1,14 @@
+package compare_test
+
+import (
+	"testing"
+
+	"flamingo.me/dingo"
+	"flamingo.me/flamingo-commerce/v3/compare"
+)
+
+func TestModule_Configure(t *testing.T) {
+	if err := dingo.TryModule(new(compare.Module)); err != nil {
+		t.Error(err)
+	}
+}
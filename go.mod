@@ -13,11 +13,13 @@ require (
 	github.com/pkg/errors v0.8.1
 	github.com/prometheus/procfs v0.0.0-20190225181712-6ed1f7e10411 // indirect
 	github.com/shopspring/decimal v0.0.0-20180709203117-cd690d0c9e24 // indirect
+	github.com/spf13/cobra v0.0.3
 	github.com/stretchr/testify v1.3.0
 	go.opencensus.io v0.19.1
 	golang.org/x/crypto v0.0.0-20190225124518-7f87c0fbb88b // indirect
 	golang.org/x/net v0.0.0-20190226193003-66a96c8a540e // indirect
 	golang.org/x/oauth2 v0.0.0-20190226191147-529b322ea346 // indirect
+	golang.org/x/sync v0.0.0-20181221193216-37e7f081c4d4
 	gopkg.in/go-playground/assert.v1 v1.2.1
 )
 
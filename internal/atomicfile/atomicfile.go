@@ -0,0 +1,42 @@
+// Package atomicfile replaces files only after they have been written completely, so that readers never see partially written files
+package atomicfile
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Write writes to a temporary file in the directory of the path, which replaces the file at the path only if write succeeded.
+// The temporary file is removed on errors, the file at the path is kept as it is
+func Write(path string, perm os.FileMode, write func(w io.Writer) error) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	err = write(file)
+	if err == nil {
+		err = file.Chmod(perm)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	return nil
+}
+
+// WriteFile writes the data like ioutil.WriteFile - but replaces the file at the path only if the data was written completely
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	return Write(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
@@ -58,23 +58,32 @@ func (rd *OrderDecorator) Create(ctx context.Context, order *Order) *DecoratedOr
 }
 
 func (rd *OrderDecorator) createDecoratedItems(ctx context.Context, items []*OrderItem) []*DecoratedOrderItem {
+	marketplaceCodes := make([]string, len(items))
+	for i, item := range items {
+		marketplaceCodes[i] = item.MarketplaceCode
+	}
+	products, err := domain.GetMany(ctx, rd.ProductService, marketplaceCodes...)
+	if err != nil {
+		rd.Logger.WithContext(ctx).Error("order.decorator - error loading products for items", err)
+	}
+
 	result := make([]*DecoratedOrderItem, len(items))
 	for i, item := range items {
-		result[i] = rd.createDecoratedItem(ctx, item)
+		result[i] = rd.createDecoratedItem(ctx, item, products)
 	}
 
 	return result
 }
 
-func (rd *OrderDecorator) createDecoratedItem(ctx context.Context, item *OrderItem) *DecoratedOrderItem {
+func (rd *OrderDecorator) createDecoratedItem(ctx context.Context, item *OrderItem, products map[string]domain.BasicProduct) *DecoratedOrderItem {
 	result := &DecoratedOrderItem{
 		Item: item,
 	}
 
-	product, err := rd.ProductService.Get(ctx, item.MarketplaceCode)
+	product, found := products[item.MarketplaceCode]
 	switch {
-	case err != nil:
-		rd.Logger.WithContext(ctx).Error("order.decorator - no product for item", err)
+	case !found:
+		rd.Logger.WithContext(ctx).Error("order.decorator - no product for item", domain.ProductNotFound{MarketplaceCode: item.MarketplaceCode})
 		// fallback to return something the frontend still could use
 		product = rd.createFallbackProduct(item)
 	case product.Type() == domain.TypeConfigurable && item.VariantMarketplaceCode != "":
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// normalizedAmount is the amount format accepted by big.Float after the separators are normalized
+	normalizedAmount = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+)
+
+// Parse reads a price from a string. The currency can be given after or before the amount - with or without whitespace in between.
+// If no currency is given the price has an empty currency.
+// Examples of supported formats: "12.99 EUR", "12.99EUR", "EUR 12.99", "-5 Points", "€12.99", "12.99"
+// Both "." and "," are accepted as decimal separator. If both are used the last one is the decimal separator and the other one
+// is ignored as grouping separator: "1.234,56 EUR" and "1,234.56 EUR" are both parsed as 1234.56 EUR.
+// A separator that occurs more than once is always treated as grouping separator ("1.000.000 EUR")
+func Parse(s string) (Price, error) {
+	amountText, currency := splitAmountAndCurrency(strings.TrimSpace(s))
+	if amountText == "" {
+		return Price{}, fmt.Errorf("price: no amount in %q", s)
+	}
+	for _, r := range currency {
+		if !isCurrencyRune(r) {
+			return Price{}, fmt.Errorf("price: invalid currency in %q", s)
+		}
+	}
+
+	normalized, err := normalizeAmount(amountText)
+	if err != nil {
+		return Price{}, fmt.Errorf("price: %v in %q", err, s)
+	}
+	amount, ok := new(big.Float).SetString(normalized)
+	if !ok {
+		return Price{}, fmt.Errorf("price: invalid amount in %q", s)
+	}
+
+	return NewFromBigFloat(*amount, currency), nil
+}
+
+// MustParse is like Parse but panics if the string cannot be parsed. Useful for tests and static defaults
+func MustParse(s string) Price {
+	price, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return price
+}
+
+// splitAmountAndCurrency returns the (trimmed) amount and currency part of the given string
+func splitAmountAndCurrency(s string) (string, string) {
+	currencyFirst := strings.IndexFunc(s, func(r rune) bool {
+		return !isCurrencyRune(r)
+	})
+	// currency before amount
+	if currencyFirst > 0 {
+		return strings.TrimSpace(s[currencyFirst:]), s[:currencyFirst]
+	}
+	if currencyFirst == -1 {
+		return "", s
+	}
+
+	// amount before currency
+	amountEnd := strings.IndexFunc(s, isCurrencyRune)
+	if amountEnd == -1 {
+		return s, ""
+	}
+	return strings.TrimSpace(s[:amountEnd]), strings.TrimSpace(s[amountEnd:])
+}
+
+// isCurrencyRune - currencies consist of letters (e.g. "EUR" or "Points") or currency symbols (e.g. "€")
+func isCurrencyRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.Sc, r)
+}
+
+// normalizeAmount removes grouping separators and uses "." as decimal separator
+func normalizeAmount(amount string) (string, error) {
+	dots := strings.Count(amount, ".")
+	commas := strings.Count(amount, ",")
+
+	decimalSeparator := ""
+	switch {
+	case dots > 0 && commas > 0:
+		decimalSeparator = "."
+		if strings.LastIndex(amount, ",") > strings.LastIndex(amount, ".") {
+			decimalSeparator = ","
+		}
+	case dots == 1:
+		decimalSeparator = "."
+	case commas == 1:
+		decimalSeparator = ","
+	}
+
+	if decimalSeparator != "" && strings.Count(amount, decimalSeparator) > 1 {
+		return "", fmt.Errorf("decimal separator %q used more than once", decimalSeparator)
+	}
+
+	integerPart := amount
+	if decimalSeparator != "" {
+		integerPart = amount[:strings.LastIndex(amount, decimalSeparator)]
+	}
+	groups := strings.Split(strings.Replace(integerPart, ",", ".", -1), ".")
+	for i, group := range groups {
+		if (i == 0 && group == "" && len(groups) > 1) || (i > 0 && len(group) != 3) {
+			return "", fmt.Errorf("invalid digit grouping %q", amount)
+		}
+	}
+
+	normalized := strings.Map(func(r rune) rune {
+		switch {
+		case string(r) == decimalSeparator:
+			return '.'
+		case r == '.' || r == ',':
+			return -1
+		}
+		return r
+	}, amount)
+
+	if !normalizedAmount.MatchString(normalized) {
+		return "", fmt.Errorf("invalid amount %q", amount)
+	}
+	return normalized, nil
+}
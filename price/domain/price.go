@@ -4,8 +4,10 @@ import (
 	"encoding"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"strings"
 )
 
@@ -37,11 +39,25 @@ type (
 		Amount   big.Float
 		Currency string
 	}
+
+	//priceDecodeAble is used to unmarshal the json representation - the amount is accepted as json number or string
+	priceDecodeAble struct {
+		Amount   json.Number
+		Currency string
+	}
 )
 
 var (
 	_ encoding.BinaryMarshaler   = Price{}
 	_ encoding.BinaryUnmarshaler = &Price{}
+	_ encoding.TextMarshaler     = Price{}
+	_ encoding.TextUnmarshaler   = &Price{}
+	_ json.Marshaler             = Price{}
+	_ json.Unmarshaler           = &Price{}
+	_ json.Marshaler             = Charges{}
+	_ json.Unmarshaler           = &Charges{}
+	_ encoding.TextMarshaler     = Charges{}
+	_ encoding.TextUnmarshaler   = &Charges{}
 )
 
 const (
@@ -446,6 +462,54 @@ func (p Price) MarshalJSON() (data []byte, err error) {
 	return r, e
 }
 
+//UnmarshalJSON - implements interface required by json unmarshal.
+//Besides the object written by MarshalJSON a string in one of the formats supported by Parse is accepted (e.g. "12.99 EUR")
+func (p *Price) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return p.UnmarshalText([]byte(text))
+	}
+
+	var pd priceDecodeAble
+	if err := json.Unmarshal(data, &pd); err != nil {
+		return err
+	}
+	amount := new(big.Float)
+	if pd.Amount != "" {
+		if _, ok := amount.SetString(pd.Amount.String()); !ok {
+			return fmt.Errorf("price: invalid amount %q", pd.Amount)
+		}
+	}
+	p.amount = *amount
+	p.currency = pd.Currency
+	return nil
+}
+
+//MarshalText - implements encoding.TextMarshaler. The price is written as amount followed by the currency, e.g. "12.99 EUR"
+func (p Price) MarshalText() (text []byte, err error) {
+	amount := p.amount.Text('f', -1)
+	if p.currency == "" {
+		return []byte(amount), nil
+	}
+	return []byte(amount + " " + p.currency), nil
+}
+
+//UnmarshalText - implements encoding.TextUnmarshaler. See Parse for the supported formats
+func (p *Price) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
 //MarshalBinary - implements interface required by gob
 func (p Price) MarshalBinary() (data []byte, err error) {
 	return json.Marshal(p)
@@ -483,6 +547,49 @@ func (p Charge) Add(add Charge) (Charge, error) {
 	return p, nil
 }
 
+//FormatText - returns the text representation of the charge "type:price" - if the value differs from the price it is appended: "type:price/value".
+//Charge does not implement encoding.TextMarshaler, so that the JSON and gob representation of charges stays the struct
+func (p Charge) FormatText() (string, error) {
+	price, err := p.Price.MarshalText()
+	if err != nil {
+		return "", err
+	}
+	result := p.Type + ":" + string(price)
+	if !p.Value.Equal(p.Price) {
+		value, err := p.Value.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		result = result + "/" + string(value)
+	}
+	return result, nil
+}
+
+//ParseCharge - reads the text representation written by Charge.FormatText. If no value is given the price is used as value
+func ParseCharge(text string) (Charge, error) {
+	parts := strings.SplitN(text, ":", 2)
+	if len(parts) != 2 {
+		return Charge{}, fmt.Errorf("charge: missing type in %q", text)
+	}
+	prices := strings.SplitN(parts[1], "/", 2)
+	price, err := Parse(prices[0])
+	if err != nil {
+		return Charge{}, err
+	}
+	value := price
+	if len(prices) == 2 {
+		value, err = Parse(prices[1])
+		if err != nil {
+			return Charge{}, err
+		}
+	}
+	return Charge{
+		Type:  strings.TrimSpace(parts[0]),
+		Price: price,
+		Value: value,
+	}, nil
+}
+
 //GetPayable - Rounds the charge
 func (p Charge) GetPayable() Charge {
 	p.Value = p.Value.GetPayable()
@@ -563,6 +670,57 @@ func (c Charges) AddCharge(toadd Charge) Charges {
 	return c
 }
 
+//MarshalJSON - implements interface required by json marshal - the charges are written as object by type
+func (c Charges) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.chargesByType)
+}
+
+//UnmarshalJSON - implements interface required by json unmarshal
+func (c *Charges) UnmarshalJSON(data []byte) error {
+	var chargesByType map[string]Charge
+	if err := json.Unmarshal(data, &chargesByType); err != nil {
+		return err
+	}
+	c.chargesByType = chargesByType
+	return nil
+}
+
+//MarshalText - implements encoding.TextMarshaler - the charges are written ordered by type and separated by ";", e.g. "main:10 EUR; points:100 Points/5 EUR"
+func (c Charges) MarshalText() ([]byte, error) {
+	types := make([]string, 0, len(c.chargesByType))
+	for ctype := range c.chargesByType {
+		types = append(types, ctype)
+	}
+	sort.Strings(types)
+
+	charges := make([]string, 0, len(types))
+	for _, ctype := range types {
+		text, err := c.chargesByType[ctype].FormatText()
+		if err != nil {
+			return nil, err
+		}
+		charges = append(charges, text)
+	}
+	return []byte(strings.Join(charges, "; ")), nil
+}
+
+//UnmarshalText - implements encoding.TextUnmarshaler - reads the format written by MarshalText
+func (c *Charges) UnmarshalText(text []byte) error {
+	chargesByType := make(map[string]Charge)
+	for _, part := range strings.Split(string(text), ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		charge, err := ParseCharge(strings.TrimSpace(part))
+		if err != nil {
+			return err
+		}
+		chargesByType[charge.Type] = charge
+	}
+	c.chargesByType = chargesByType
+	return nil
+}
+
 //Mul - returns new Charges with the given multiplied
 func (c Charges) Mul(qty int) Charges {
 	if c.chargesByType == nil {
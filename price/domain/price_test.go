@@ -3,6 +3,7 @@ package domain_test
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"math"
 	"math/big"
 
@@ -249,3 +250,207 @@ func TestCharges_Add(t *testing.T) {
 		Type:  "main",
 	}, charge)
 }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		want     domain.Price
+		wantFail bool
+	}{
+		{input: "12.99 EUR", want: domain.NewFromInt(1299, 100, "EUR")},
+		{input: "12.99EUR", want: domain.NewFromInt(1299, 100, "EUR")},
+		{input: "EUR 12.99", want: domain.NewFromInt(1299, 100, "EUR")},
+		{input: " EUR12.99 ", want: domain.NewFromInt(1299, 100, "EUR")},
+		{input: "12,99 EUR", want: domain.NewFromInt(1299, 100, "EUR")},
+		{input: "1.234,56 EUR", want: domain.NewFromInt(123456, 100, "EUR")},
+		{input: "1,234.56 USD", want: domain.NewFromInt(123456, 100, "USD")},
+		{input: "1.000.000 EUR", want: domain.NewFromInt(1000000, 1, "EUR")},
+		{input: "-5 Points", want: domain.NewFromInt(-5, 1, "Points")},
+		{input: "€12.99", want: domain.NewFromInt(1299, 100, "€")},
+		{input: "12.99", want: domain.NewFromInt(1299, 100, "")},
+		{input: "", wantFail: true},
+		{input: "EUR", wantFail: true},
+		{input: "12.99 EUR USD", wantFail: true},
+		{input: "12.9.9 EUR", wantFail: true},
+		{input: "1.2.3 EUR", wantFail: true},
+		{input: "1,234,56 EUR", wantFail: true},
+		{input: "12.99 E1", wantFail: true},
+		{input: "1e5 EUR", wantFail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := domain.Parse(tt.input)
+			if tt.wantFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.Currency(), got.Currency())
+			assert.True(t, tt.want.LikelyEqual(got), "expected %v got %v", tt.want.FloatAmount(), got.FloatAmount())
+		})
+	}
+
+	assert.Panics(t, func() { domain.MustParse("no price") })
+	assert.True(t, domain.MustParse("2.45 EUR").Equal(domain.MustParse("EUR 2,45")))
+}
+
+func TestPrice_MarshalText(t *testing.T) {
+	text, err := domain.NewFromInt(1299, 100, "EUR").MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "12.99 EUR", string(text))
+
+	text, err = domain.NewFromInt(-5, 1, "").MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "-5", string(text))
+
+	var price domain.Price
+	assert.NoError(t, price.UnmarshalText([]byte("12.99 EUR")))
+	assert.True(t, domain.MustParse("12.99 EUR").Equal(price))
+	assert.Error(t, price.UnmarshalText([]byte("twelve EUR")))
+}
+
+func TestPrice_UnmarshalJSON(t *testing.T) {
+	type withPrice struct {
+		Price domain.Price
+	}
+
+	original := withPrice{Price: domain.MustParse("12.99 EUR")}
+	data, err := json.Marshal(original)
+	assert.NoError(t, err)
+
+	var received withPrice
+	assert.NoError(t, json.Unmarshal(data, &received))
+	assert.True(t, original.Price.Equal(received.Price))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"Price":{"Amount":12.99,"Currency":"EUR"}}`), &received))
+	assert.True(t, domain.NewFromInt(1299, 100, "EUR").LikelyEqual(received.Price))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"Price":"1.234,50 EUR"}`), &received))
+	assert.True(t, domain.NewFromInt(123450, 100, "EUR").LikelyEqual(received.Price))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"Price":{"Amount":"abc","Currency":"EUR"}}`), &received))
+	assert.Error(t, json.Unmarshal([]byte(`{"Price":"abc"}`), &received))
+}
+
+func TestCharge_FormatText(t *testing.T) {
+	charge := domain.Charge{
+		Type:  "loyalty",
+		Price: domain.MustParse("100 Points"),
+		Value: domain.MustParse("5 EUR"),
+	}
+	text, err := charge.FormatText()
+	assert.NoError(t, err)
+	assert.Equal(t, "loyalty:100 Points/5 EUR", text)
+
+	received, err := domain.ParseCharge(text)
+	assert.NoError(t, err)
+	assert.Equal(t, charge.Type, received.Type)
+	assert.True(t, charge.Price.Equal(received.Price))
+	assert.True(t, charge.Value.Equal(received.Value))
+
+	received, err = domain.ParseCharge("main:10 EUR")
+	assert.NoError(t, err)
+	assert.True(t, received.Value.Equal(received.Price))
+	_, err = domain.ParseCharge("10 EUR")
+	assert.Error(t, err)
+}
+
+func TestCharge_JSONAndGob(t *testing.T) {
+	charge := domain.Charge{
+		Type:  "loyalty",
+		Price: domain.NewFromInt(100, 1, "Points"),
+		Value: domain.NewFromInt(5, 1, "EUR"),
+	}
+
+	data, err := json.Marshal(charge)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Price":{"Amount":"100","Currency":"Points"},"Value":{"Amount":"5","Currency":"EUR"},"Type":"loyalty"}`, string(data), "charges are json objects")
+
+	var fromJSON domain.Charge
+	assert.NoError(t, json.Unmarshal([]byte(`{"Price":{"Amount":"100","Currency":"Points"},"Value":{"Amount":"5","Currency":"EUR"},"Type":"loyalty"}`), &fromJSON))
+	assert.Equal(t, charge.Type, fromJSON.Type)
+	assert.True(t, charge.Price.Equal(fromJSON.Price))
+	assert.True(t, charge.Value.Equal(fromJSON.Value))
+
+	// the gob representation of a struct with the fields of a charge - as written before
+	type gobCharge struct {
+		Price domain.Price
+		Value domain.Price
+		Type  string
+	}
+	var buffer bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buffer).Encode(gobCharge{Price: charge.Price, Value: charge.Value, Type: charge.Type}))
+	var fromGob domain.Charge
+	assert.NoError(t, gob.NewDecoder(&buffer).Decode(&fromGob))
+	assert.Equal(t, charge.Type, fromGob.Type)
+	assert.True(t, charge.Price.Equal(fromGob.Price))
+	assert.True(t, charge.Value.Equal(fromGob.Value))
+
+	buffer.Reset()
+	assert.NoError(t, gob.NewEncoder(&buffer).Encode(charge))
+	var roundTrip gobCharge
+	assert.NoError(t, gob.NewDecoder(&buffer).Decode(&roundTrip), "charges are gob encoded as struct")
+	assert.True(t, charge.Value.Equal(roundTrip.Value))
+}
+
+func TestCharges_JSONAndText(t *testing.T) {
+	charges := domain.NewCharges(map[string]domain.Charge{
+		domain.ChargeTypeMain: {Type: domain.ChargeTypeMain, Price: domain.MustParse("10 EUR"), Value: domain.MustParse("10 EUR")},
+		"loyalty":             {Type: "loyalty", Price: domain.MustParse("100 Points"), Value: domain.MustParse("5 EUR")},
+	})
+
+	data, err := json.Marshal(charges)
+	assert.NoError(t, err)
+	var fromJSON domain.Charges
+	assert.NoError(t, json.Unmarshal(data, &fromJSON))
+	assert.Len(t, fromJSON.GetAllCharges(), 2)
+	assert.True(t, fromJSON.GetByTypeForced("loyalty").Value.Equal(domain.MustParse("5 EUR")))
+
+	text, err := charges.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "loyalty:100 Points/5 EUR; main:10 EUR", string(text))
+	var fromText domain.Charges
+	assert.NoError(t, fromText.UnmarshalText(text))
+	assert.Len(t, fromText.GetAllCharges(), 2)
+	assert.True(t, fromText.GetByTypeForced(domain.ChargeTypeMain).Price.Equal(domain.MustParse("10 EUR")))
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{"12.99 EUR", "EUR 12,99", "1.234,56 EUR", "-5 Points", "€0.1", "42", "1,000,000.5 USD"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		price, err := domain.Parse(input)
+		if err != nil {
+			return
+		}
+
+		text, err := price.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText failed for %q: %v", input, err)
+		}
+		fromText, err := domain.Parse(string(text))
+		if err != nil {
+			t.Fatalf("Parse of marshalled %q (from %q) failed: %v", text, input, err)
+		}
+		if !price.Equal(fromText) {
+			t.Fatalf("text round trip of %q changed the price: %q", input, text)
+		}
+		again, _ := fromText.MarshalText()
+		if string(again) != string(text) {
+			t.Fatalf("text round trip of %q is not stable: %q != %q", input, again, text)
+		}
+
+		data, err := json.Marshal(price)
+		if err != nil {
+			t.Fatalf("MarshalJSON failed for %q: %v", input, err)
+		}
+		var fromJSON domain.Price
+		if err := json.Unmarshal(data, &fromJSON); err != nil {
+			t.Fatalf("UnmarshalJSON of %s failed: %v", data, err)
+		}
+		if !price.Equal(fromJSON) {
+			t.Fatalf("json round trip of %q changed the price: %s", input, data)
+		}
+	})
+}
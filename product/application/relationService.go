@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// RelationService - Application service that returns the related products (cross-sell, up-sell, accessories) of products.
+	// It returns no products if no domain.ProductRelationService is bound
+	RelationService struct {
+		productService         domain.ProductService
+		productRelationService domain.ProductRelationService
+		logger                 flamingo.Logger
+		defaultLimit           int
+	}
+)
+
+const defaultRelationLimit = 10
+
+// Inject dependencies
+func (s *RelationService) Inject(
+	productService domain.ProductService,
+	logger flamingo.Logger,
+	config *struct {
+		Limit float64 `inject:"config:commerce.product.relations.limit,optional"`
+	},
+	optionals *struct {
+		ProductRelationService domain.ProductRelationService `inject:",optional"`
+	},
+) {
+	s.productService = productService
+	s.logger = logger.WithField(flamingo.LogKeyModule, "product").WithField(flamingo.LogKeyCategory, "application.RelationService")
+	s.defaultLimit = defaultRelationLimit
+	if config != nil && config.Limit > 0 {
+		s.defaultLimit = int(config.Limit)
+	}
+	if optionals != nil {
+		s.productRelationService = optionals.ProductRelationService
+	}
+}
+
+// DefaultLimit returns the configured number of related products that should be shown
+func (s *RelationService) DefaultLimit() int {
+	return s.defaultLimit
+}
+
+// GetRelatedProducts returns up to limit related products of the given type (all types if empty).
+// A limit <= 0 means the configured default limit
+func (s *RelationService) GetRelatedProducts(ctx context.Context, marketplaceCode string, relationType string, limit int) ([]domain.BasicProduct, error) {
+	return s.GetRelatedProductsForMany(ctx, []string{marketplaceCode}, nil, relationType, limit)
+}
+
+// GetRelatedProductsByType returns up to limit related products of all relation types, indexed by relation type
+func (s *RelationService) GetRelatedProductsByType(ctx context.Context, marketplaceCode string, limit int) (map[string][]domain.BasicProduct, error) {
+	if s.productRelationService == nil {
+		return nil, nil
+	}
+	relations, err := s.productRelationService.GetRelations(ctx, marketplaceCode)
+	if err != nil {
+		return nil, err
+	}
+
+	codesByType := make(map[string][]string)
+	var codes []string
+	for _, relation := range relations {
+		codesByType[relation.Type] = append(codesByType[relation.Type], relation.MarketplaceCode)
+		codes = append(codes, relation.MarketplaceCode)
+	}
+	products, err := s.getMany(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]domain.BasicProduct, len(codesByType))
+	for relationType, codes := range codesByType {
+		result[relationType] = s.collect(codes, products, map[string]bool{marketplaceCode: true}, limit)
+	}
+	return result, nil
+}
+
+// GetRelatedProductsForMany aggregates the related products of the given type (all types if empty) of several products.
+// The given products themselves and the excluded marketplace codes are not part of the result
+func (s *RelationService) GetRelatedProductsForMany(ctx context.Context, marketplaceCodes []string, excludeMarketplaceCodes []string, relationType string, limit int) ([]domain.BasicProduct, error) {
+	if s.productRelationService == nil || len(marketplaceCodes) == 0 {
+		return nil, nil
+	}
+
+	var relationTypes []string
+	if relationType != "" {
+		relationTypes = []string{relationType}
+	}
+
+	exclude := make(map[string]bool, len(marketplaceCodes)+len(excludeMarketplaceCodes))
+	for _, marketplaceCode := range append(append([]string{}, marketplaceCodes...), excludeMarketplaceCodes...) {
+		exclude[marketplaceCode] = true
+	}
+
+	var codes []string
+	for _, marketplaceCode := range marketplaceCodes {
+		relations, err := s.productRelationService.GetRelations(ctx, marketplaceCode, relationTypes...)
+		if err != nil {
+			return nil, err
+		}
+		for _, relation := range relations {
+			codes = append(codes, relation.MarketplaceCode)
+		}
+	}
+
+	products, err := s.getMany(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	return s.collect(codes, products, exclude, limit), nil
+}
+
+// getMany loads the products - products that could not be loaded are logged and skipped
+func (s *RelationService) getMany(ctx context.Context, codes []string) (map[string]domain.BasicProduct, error) {
+	products, err := domain.GetMany(ctx, s.productService, codes...)
+	if _, ok := err.(*domain.BatchGetError); ok {
+		s.logger.WithContext(ctx).Warn("related products could not be loaded: ", err)
+		return products, nil
+	}
+	return products, err
+}
+
+// collect returns the loaded products in the order of the codes without duplicates and excluded products
+func (s *RelationService) collect(codes []string, products map[string]domain.BasicProduct, exclude map[string]bool, limit int) []domain.BasicProduct {
+	if limit <= 0 {
+		limit = s.defaultLimit
+	}
+
+	seen := make(map[string]bool, len(codes))
+	var result []domain.BasicProduct
+	for _, code := range codes {
+		if len(result) >= limit {
+			break
+		}
+		if seen[code] || exclude[code] {
+			continue
+		}
+		seen[code] = true
+		if product, found := products[code]; found {
+			result = append(result, product)
+		}
+	}
+	return result
+}
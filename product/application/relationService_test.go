@@ -0,0 +1,104 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	relationProductService struct{}
+
+	relationServiceStub map[string][]domain.ProductRelation
+)
+
+func (relationProductService) Get(_ context.Context, marketplaceCode string) (domain.BasicProduct, error) {
+	if marketplaceCode == "unknown" {
+		return nil, domain.ProductNotFound{MarketplaceCode: marketplaceCode}
+	}
+	return domain.SimpleProduct{BasicProductData: domain.BasicProductData{MarketPlaceCode: marketplaceCode}}, nil
+}
+
+func (s relationServiceStub) GetRelations(_ context.Context, marketplaceCode string, relationTypes ...string) ([]domain.ProductRelation, error) {
+	var result []domain.ProductRelation
+	for _, relation := range s[marketplaceCode] {
+		if len(relationTypes) == 0 || relation.Type == relationTypes[0] {
+			result = append(result, relation)
+		}
+	}
+	return result, nil
+}
+
+func newRelationService(relations domain.ProductRelationService, limit float64) *application.RelationService {
+	service := new(application.RelationService)
+	service.Inject(relationProductService{}, flamingo.NullLogger{}, &struct {
+		Limit float64 `inject:"config:commerce.product.relations.limit,optional"`
+	}{Limit: limit}, &struct {
+		ProductRelationService domain.ProductRelationService `inject:",optional"`
+	}{ProductRelationService: relations})
+	return service
+}
+
+func codes(products []domain.BasicProduct) []string {
+	var result []string
+	for _, product := range products {
+		result = append(result, product.BaseData().MarketPlaceCode)
+	}
+	return result
+}
+
+func TestRelationService(t *testing.T) {
+	relations := relationServiceStub{
+		"camera": {
+			{Type: domain.RelationTypeCrossSell, MarketplaceCode: "memorycard"},
+			{Type: domain.RelationTypeAccessory, MarketplaceCode: "camerabag"},
+			{Type: domain.RelationTypeAccessory, MarketplaceCode: "unknown"},
+			{Type: domain.RelationTypeAccessory, MarketplaceCode: "battery"},
+			{Type: domain.RelationTypeUpSell, MarketplaceCode: "camera-pro"},
+		},
+		"lens": {
+			{Type: domain.RelationTypeAccessory, MarketplaceCode: "lenscap"},
+			{Type: domain.RelationTypeAccessory, MarketplaceCode: "camerabag"},
+			{Type: domain.RelationTypeAccessory, MarketplaceCode: "camera"},
+		},
+	}
+
+	t.Run("related products of one product", func(t *testing.T) {
+		service := newRelationService(relations, 10)
+		products, err := service.GetRelatedProducts(context.Background(), "camera", domain.RelationTypeAccessory, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"camerabag", "battery"}, codes(products))
+
+		products, err = service.GetRelatedProducts(context.Background(), "camera", "", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"memorycard", "camerabag"}, codes(products))
+	})
+
+	t.Run("related products by type", func(t *testing.T) {
+		service := newRelationService(relations, 1)
+		byType, err := service.GetRelatedProductsByType(context.Background(), "camera", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"memorycard"}, codes(byType[domain.RelationTypeCrossSell]))
+		assert.Equal(t, []string{"camerabag"}, codes(byType[domain.RelationTypeAccessory]))
+		assert.Equal(t, []string{"camera-pro"}, codes(byType[domain.RelationTypeUpSell]))
+	})
+
+	t.Run("aggregated related products exclude the given products", func(t *testing.T) {
+		service := newRelationService(relations, 10)
+		products, err := service.GetRelatedProductsForMany(context.Background(), []string{"camera", "lens"}, []string{"battery"}, domain.RelationTypeAccessory, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"camerabag", "lenscap"}, codes(products))
+	})
+
+	t.Run("no relation service bound", func(t *testing.T) {
+		service := newRelationService(nil, 10)
+		products, err := service.GetRelatedProducts(context.Background(), "camera", "", 0)
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+	})
+}
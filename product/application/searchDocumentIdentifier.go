@@ -0,0 +1,23 @@
+package application
+
+import (
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/search/application"
+	searchdomain "flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type (
+	// SearchDocumentIdentifier identifies product documents of federated search results by their marketplace code
+	SearchDocumentIdentifier struct{}
+)
+
+var _ application.DocumentIdentifier = (*SearchDocumentIdentifier)(nil)
+
+// DocumentID returns the marketplace code of products
+func (SearchDocumentIdentifier) DocumentID(_ string, document searchdomain.Document) (string, bool) {
+	product, ok := document.(domain.BasicProduct)
+	if !ok || product == nil {
+		return "", false
+	}
+	return "product:" + product.BaseData().MarketPlaceCode, true
+}
@@ -15,10 +15,13 @@ import (
 type (
 	// ProductSearchService - Application service that offers a more explicit way to search for  product results - on top of the domain.ProductSearchService
 	ProductSearchService struct {
-		SearchService         domain.SearchService         `inject:""`
-		PaginationInfoFactory *utils.PaginationInfoFactory `inject:""`
-		DefaultPageSize       float64                      `inject:"config:pagination.defaultPageSize,optional"`
-		Logger                flamingo.Logger              `inject:""`
+		SearchService         domain.SearchService            `inject:""`
+		PaginationInfoFactory *utils.PaginationInfoFactory    `inject:""`
+		DefaultPageSize       float64                         `inject:"config:pagination.defaultPageSize,optional"`
+		Logger                flamingo.Logger                 `inject:""`
+		QueryHooks            []searchdomain.RequestQueryHook `inject:",optional"`
+		// FacetConfiguration is applied to the facets of the results
+		FacetConfiguration *application.FacetConfigurationService `inject:""`
 	}
 
 	// SearchResult - much like the corresponding struct in search package, just that instead "Hits" we have a list of matching Products
@@ -44,13 +47,17 @@ func (s *ProductSearchService) Find(ctx context.Context, searchRequest *applicat
 	if searchRequest == nil {
 		searchRequest = &application.SearchRequest{}
 	}
+	if err := s.applyQueryHooks(ctx, currentURL, searchRequest); err != nil {
+		return nil, err
+	}
 	// pageSize can either be set in the request, or we use the configured default or if nothing set we rely on the ProductSearchService later
 	pageSize := searchRequest.PageSize
 	if pageSize == 0 {
 		pageSize = int(s.DefaultPageSize)
 	}
 
-	result, err := s.SearchService.Search(ctx, application.BuildFilters(*searchRequest, pageSize)...)
+	filters := application.BuildFilters(*searchRequest, pageSize)
+	result, err := s.SearchService.Search(ctx, filters...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,15 +72,17 @@ func (s *ProductSearchService) Find(ctx context.Context, searchRequest *applicat
 		}
 	}
 	paginationInfo := utils.BuildWith(utils.CurrentResultInfos{
-		LastPage:   result.SearchMeta.NumPages,
-		TotalHits:  result.SearchMeta.NumResults,
-		PageSize:   searchRequest.PageSize,
-		ActivePage: result.SearchMeta.Page,
+		LastPage:       result.SearchMeta.NumPages,
+		TotalHits:      result.SearchMeta.NumResults,
+		PageSize:       searchRequest.PageSize,
+		ActivePage:     result.SearchMeta.Page,
+		NextCursor:     result.SearchMeta.NextCursor,
+		PreviousCursor: result.SearchMeta.PreviousCursor,
 	}, *searchRequest.PaginationConfig, currentURL)
 
 	return &SearchResult{
 		SearchMeta:     result.SearchMeta,
-		Facets:         result.Facets,
+		Facets:         s.FacetConfiguration.Apply(ctx, filters, result.Facets),
 		Suggestions:    result.Suggestion,
 		Products:       result.Hits,
 		PaginationInfo: paginationInfo,
@@ -93,13 +102,17 @@ func (s *ProductSearchService) FindBy(ctx context.Context, attributeCode string,
 	if searchRequest == nil {
 		searchRequest = &application.SearchRequest{}
 	}
+	if err := s.applyQueryHooks(ctx, currentURL, searchRequest); err != nil {
+		return nil, err
+	}
 	// pageSize can either be set in the request, or we use the configured default or if nothing set we rely on the ProductSearchService later
 	pageSize := searchRequest.PageSize
 	if pageSize == 0 {
 		pageSize = int(s.DefaultPageSize)
 	}
 
-	result, err := s.SearchService.SearchBy(ctx, attributeCode, values, application.BuildFilters(*searchRequest, pageSize)...)
+	filters := application.BuildFilters(*searchRequest, pageSize)
+	result, err := s.SearchService.SearchBy(ctx, attributeCode, values, filters...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,17 +127,28 @@ func (s *ProductSearchService) FindBy(ctx context.Context, attributeCode string,
 		}
 	}
 	paginationInfo := utils.BuildWith(utils.CurrentResultInfos{
-		LastPage:   result.SearchMeta.NumPages,
-		TotalHits:  result.SearchMeta.NumResults,
-		PageSize:   searchRequest.PageSize,
-		ActivePage: result.SearchMeta.Page,
+		LastPage:       result.SearchMeta.NumPages,
+		TotalHits:      result.SearchMeta.NumResults,
+		PageSize:       searchRequest.PageSize,
+		ActivePage:     result.SearchMeta.Page,
+		NextCursor:     result.SearchMeta.NextCursor,
+		PreviousCursor: result.SearchMeta.PreviousCursor,
 	}, *searchRequest.PaginationConfig, currentURL)
 
 	return &SearchResult{
 		SearchMeta:     result.SearchMeta,
-		Facets:         result.Facets,
+		Facets:         s.FacetConfiguration.Apply(ctx, append([]searchdomain.Filter{searchdomain.NewKeyValueFilter(attributeCode, values)}, filters...), result.Facets),
 		Suggestions:    result.Suggestion,
 		Products:       result.Hits,
 		PaginationInfo: paginationInfo,
 	}, nil
 }
+
+// applyQueryHooks runs the query hooks - a domain.RedirectError of a hook is returned to the caller
+func (s *ProductSearchService) applyQueryHooks(ctx context.Context, currentURL *url.URL, searchRequest *application.SearchRequest) error {
+	path := ""
+	if currentURL != nil {
+		path = currentURL.Path
+	}
+	return application.ApplyQueryHooks(ctx, s.QueryHooks, path, searchRequest)
+}
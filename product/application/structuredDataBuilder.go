@@ -0,0 +1,255 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// JSONLD is a schema.org JSON-LD object
+	JSONLD map[string]interface{}
+
+	// StructuredDataBuilder builds schema.org structured data (JSON-LD) of products.
+	// Bind your own implementation to replace the DefaultStructuredDataBuilder
+	StructuredDataBuilder interface {
+		// BuildProduct returns a Product (or a ProductGroup for configurables without active variant)
+		BuildProduct(ctx context.Context, product domain.BasicProduct) JSONLD
+	}
+
+	// StructuredDataEnricher can be multibound to add project specific properties to the structured data of a product
+	StructuredDataEnricher interface {
+		Enrich(ctx context.Context, product domain.BasicProduct, data JSONLD)
+	}
+
+	// DefaultStructuredDataBuilder builds Product, ProductGroup, Offer and AggregateOffer structured data
+	DefaultStructuredDataBuilder struct {
+		urlService     *URLService
+		enrichers      []StructuredDataEnricher
+		mediaBaseURL   string
+		brandAttribute string
+		gtinAttribute  string
+		mpnAttribute   string
+	}
+)
+
+const (
+	schemaContext                 = "https://schema.org"
+	schemaInStock                 = "https://schema.org/InStock"
+	schemaLimitedAvailability     = "https://schema.org/LimitedAvailability"
+	schemaOutOfStock              = "https://schema.org/OutOfStock"
+	stockLevelLow                 = "low"
+	structuredDataDateFormat      = "2006-01-02"
+	structuredDataImageMediaUsage = domain.MediaUsageDetail
+)
+
+var _ StructuredDataBuilder = new(DefaultStructuredDataBuilder)
+
+// Inject dependencies
+func (b *DefaultStructuredDataBuilder) Inject(
+	urlService *URLService,
+	config *struct {
+		MediaBaseURL   string `inject:"config:commerce.product.structuredData.mediaBaseUrl,optional"`
+		BrandAttribute string `inject:"config:commerce.product.structuredData.brandAttribute,optional"`
+		GTINAttribute  string `inject:"config:commerce.product.structuredData.gtinAttribute,optional"`
+		MPNAttribute   string `inject:"config:commerce.product.structuredData.mpnAttribute,optional"`
+	},
+	optionals *struct {
+		Enrichers []StructuredDataEnricher `inject:",optional"`
+	},
+) {
+	b.urlService = urlService
+	if config != nil {
+		b.mediaBaseURL = config.MediaBaseURL
+		b.brandAttribute = config.BrandAttribute
+		b.gtinAttribute = config.GTINAttribute
+		b.mpnAttribute = config.MPNAttribute
+	}
+	if optionals != nil {
+		b.enrichers = optionals.Enrichers
+	}
+}
+
+// BuildProduct returns the structured data of the product
+func (b *DefaultStructuredDataBuilder) BuildProduct(ctx context.Context, product domain.BasicProduct) JSONLD {
+	if product == nil {
+		return nil
+	}
+
+	var data JSONLD
+	switch p := product.(type) {
+	case domain.ConfigurableProduct:
+		data = b.productGroup(ctx, p)
+	case domain.ConfigurableProductWithActiveVariant:
+		data = b.product(p.ActiveVariant.BasicProductData, p.ActiveVariant.Saleable, b.url(ctx, p, ""))
+		data["isVariantOf"] = JSONLD{
+			"@type":          "ProductGroup",
+			"productGroupID": p.ConfigurableBaseData().MarketPlaceCode,
+			"name":           p.ConfigurableBaseData().Title,
+		}
+	default:
+		data = b.product(product.BaseData(), product.SaleableData(), b.url(ctx, product, ""))
+		if bundle, ok := product.(domain.BundleProduct); ok {
+			data["offers"].(JSONLD)["availability"] = availability(bundle.IsInStock(), "")
+		}
+	}
+	data["@context"] = schemaContext
+
+	for _, enricher := range b.enrichers {
+		enricher.Enrich(ctx, product, data)
+	}
+	return data
+}
+
+func (b *DefaultStructuredDataBuilder) product(baseData domain.BasicProductData, saleable domain.Saleable, url string) JSONLD {
+	data := b.baseData("Product", baseData, url)
+	data["sku"] = baseData.MarketPlaceCode
+	b.addAttribute(data, "brand", baseData, b.brandAttribute)
+	b.addAttribute(data, "gtin", baseData, b.gtinAttribute)
+	b.addAttribute(data, "mpn", baseData, b.mpnAttribute)
+	if brand, ok := data["brand"]; ok {
+		data["brand"] = JSONLD{"@type": "Brand", "name": brand}
+	}
+	data["offers"] = b.offer(baseData, saleable, url)
+	return data
+}
+
+func (b *DefaultStructuredDataBuilder) productGroup(ctx context.Context, configurable domain.ConfigurableProduct) JSONLD {
+	data := b.baseData("ProductGroup", configurable.BasicProductData, b.url(ctx, configurable, ""))
+	data["productGroupID"] = configurable.MarketPlaceCode
+	b.addAttribute(data, "brand", configurable.BasicProductData, b.brandAttribute)
+	if brand, ok := data["brand"]; ok {
+		data["brand"] = JSONLD{"@type": "Brand", "name": brand}
+	}
+	if len(configurable.VariantVariationAttributes) > 0 {
+		data["variesBy"] = configurable.VariantVariationAttributes
+	}
+
+	variants := make([]JSONLD, 0, len(configurable.Variants))
+	var offers []JSONLD
+	for _, variant := range configurable.Variants {
+		variantData := b.product(variant.BasicProductData, variant.Saleable, b.url(ctx, configurable, variant.MarketPlaceCode))
+		variants = append(variants, variantData)
+		offers = append(offers, variantData["offers"].(JSONLD))
+	}
+	data["hasVariant"] = variants
+	if aggregateOffer := aggregateOffer(offers); aggregateOffer != nil {
+		data["offers"] = aggregateOffer
+	}
+	return data
+}
+
+func (b *DefaultStructuredDataBuilder) baseData(schemaType string, baseData domain.BasicProductData, url string) JSONLD {
+	data := JSONLD{
+		"@type": schemaType,
+		"name":  baseData.Title,
+	}
+	if description := baseData.ShortDescription; description != "" {
+		data["description"] = description
+	} else if baseData.Description != "" {
+		data["description"] = baseData.Description
+	}
+	if url != "" {
+		data["url"] = url
+	}
+	if images := b.images(baseData); len(images) > 0 {
+		data["image"] = images
+	}
+	if baseData.MainCategory.Name != "" {
+		data["category"] = baseData.MainCategory.Name
+	}
+	return data
+}
+
+func (b *DefaultStructuredDataBuilder) offer(baseData domain.BasicProductData, saleable domain.Saleable, url string) JSONLD {
+	price := saleable.ActivePrice.GetFinalPrice().GetPayable()
+	offer := JSONLD{
+		"@type":         "Offer",
+		"price":         price.FloatAmount(),
+		"priceCurrency": price.Currency(),
+		"availability":  availability(baseData.IsInStock() && saleable.IsSaleable, baseData.StockLevel),
+	}
+	if url != "" {
+		offer["url"] = url
+	}
+	if !saleable.SaleableTo.IsZero() {
+		offer["priceValidUntil"] = saleable.SaleableTo.Format(structuredDataDateFormat)
+	}
+	return offer
+}
+
+// aggregateOffer combines the offers of the variants
+func aggregateOffer(offers []JSONLD) JSONLD {
+	if len(offers) == 0 {
+		return nil
+	}
+	lowPrice, highPrice := offers[0]["price"].(float64), offers[0]["price"].(float64)
+	for _, offer := range offers[1:] {
+		if offer["priceCurrency"] != offers[0]["priceCurrency"] {
+			return nil
+		}
+		price := offer["price"].(float64)
+		if price < lowPrice {
+			lowPrice = price
+		}
+		if price > highPrice {
+			highPrice = price
+		}
+	}
+	return JSONLD{
+		"@type":         "AggregateOffer",
+		"lowPrice":      lowPrice,
+		"highPrice":     highPrice,
+		"priceCurrency": offers[0]["priceCurrency"],
+		"offerCount":    len(offers),
+	}
+}
+
+func availability(inStock bool, stockLevel string) string {
+	if !inStock {
+		return schemaOutOfStock
+	}
+	if stockLevel == stockLevelLow {
+		return schemaLimitedAvailability
+	}
+	return schemaInStock
+}
+
+func (b *DefaultStructuredDataBuilder) images(baseData domain.BasicProductData) []string {
+	var images []string
+	for _, media := range baseData.Media {
+		if media.Usage != structuredDataImageMediaUsage || media.Reference == "" {
+			continue
+		}
+		if strings.HasPrefix(media.Reference, "http://") || strings.HasPrefix(media.Reference, "https://") {
+			images = append(images, media.Reference)
+			continue
+		}
+		images = append(images, b.mediaBaseURL+media.Reference)
+	}
+	return images
+}
+
+func (b *DefaultStructuredDataBuilder) addAttribute(data JSONLD, property string, baseData domain.BasicProductData, attributeCode string) {
+	if attributeCode == "" || !baseData.HasAttribute(attributeCode) {
+		return
+	}
+	if value := baseData.Attributes[attributeCode].Value(); value != "" {
+		data[property] = value
+	}
+}
+
+// url returns the absolute product url - if the context belongs to a request
+func (b *DefaultStructuredDataBuilder) url(ctx context.Context, product domain.BasicProduct, variantCode string) string {
+	r := web.RequestFromContext(ctx)
+	if r == nil || b.urlService == nil {
+		return ""
+	}
+	url, err := b.urlService.GetAbsolute(r, product, variantCode)
+	if err != nil {
+		return ""
+	}
+	return url
+}
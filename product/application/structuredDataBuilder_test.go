@@ -0,0 +1,131 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type colorEnricher struct{}
+
+func (colorEnricher) Enrich(_ context.Context, product domain.BasicProduct, data application.JSONLD) {
+	if product.BaseData().HasAttribute("color") {
+		data["color"] = product.BaseData().Attributes["color"].Value()
+	}
+}
+
+func structuredDataBuilder() *application.DefaultStructuredDataBuilder {
+	builder := new(application.DefaultStructuredDataBuilder)
+	builder.Inject(
+		nil,
+		&struct {
+			MediaBaseURL   string `inject:"config:commerce.product.structuredData.mediaBaseUrl,optional"`
+			BrandAttribute string `inject:"config:commerce.product.structuredData.brandAttribute,optional"`
+			GTINAttribute  string `inject:"config:commerce.product.structuredData.gtinAttribute,optional"`
+			MPNAttribute   string `inject:"config:commerce.product.structuredData.mpnAttribute,optional"`
+		}{MediaBaseURL: "https://media.example.com/", BrandAttribute: "brand", GTINAttribute: "gtin"},
+		&struct {
+			Enrichers []application.StructuredDataEnricher `inject:",optional"`
+		}{Enrichers: []application.StructuredDataEnricher{colorEnricher{}}},
+	)
+	return builder
+}
+
+func structuredDataVariant(code string, price float64, stockLevel string, color string) domain.Variant {
+	return domain.Variant{
+		BasicProductData: domain.BasicProductData{
+			MarketPlaceCode: code,
+			Title:           "Shirt " + color,
+			StockLevel:      stockLevel,
+			Attributes: domain.Attributes{
+				"color": {Code: "color", RawValue: color},
+				"gtin":  {Code: "gtin", RawValue: "400" + code},
+			},
+		},
+		Saleable: domain.Saleable{IsSaleable: true, ActivePrice: domain.PriceInfo{Default: priceDomain.NewFromFloat(price, "EUR")}},
+	}
+}
+
+func TestDefaultStructuredDataBuilder_BuildProduct(t *testing.T) {
+	builder := structuredDataBuilder()
+
+	t.Run("simple product", func(t *testing.T) {
+		product := domain.SimpleProduct{
+			BasicProductData: domain.BasicProductData{
+				MarketPlaceCode:  "mug",
+				Title:            "Mug",
+				ShortDescription: "A mug",
+				StockLevel:       "low",
+				Media:            []domain.Media{{Usage: domain.MediaUsageDetail, Reference: "mug.jpg"}, {Usage: domain.MediaUsageThumbnail, Reference: "mug-thumb.jpg"}},
+				Attributes:       domain.Attributes{"brand": {Code: "brand", RawValue: "Flamingo"}, "color": {Code: "color", RawValue: "pink"}},
+			},
+			Saleable: domain.Saleable{
+				IsSaleable:  true,
+				SaleableTo:  time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC),
+				ActivePrice: domain.PriceInfo{Default: priceDomain.NewFromFloat(12.99, "EUR")},
+			},
+		}
+
+		data := builder.BuildProduct(context.Background(), product)
+		assert.Equal(t, "https://schema.org", data["@context"])
+		assert.Equal(t, "Product", data["@type"])
+		assert.Equal(t, "mug", data["sku"])
+		assert.Equal(t, "A mug", data["description"])
+		assert.Equal(t, []string{"https://media.example.com/mug.jpg"}, data["image"])
+		assert.Equal(t, application.JSONLD{"@type": "Brand", "name": "Flamingo"}, data["brand"])
+		assert.Equal(t, "pink", data["color"], "enrichers are applied")
+		assert.Equal(t, application.JSONLD{
+			"@type":           "Offer",
+			"price":           12.99,
+			"priceCurrency":   "EUR",
+			"availability":    "https://schema.org/LimitedAvailability",
+			"priceValidUntil": "2030-01-31",
+		}, data["offers"])
+	})
+
+	t.Run("configurable product", func(t *testing.T) {
+		configurable := domain.ConfigurableProduct{
+			BasicProductData:           domain.BasicProductData{MarketPlaceCode: "shirt", Title: "Shirt"},
+			VariantVariationAttributes: []string{"color"},
+			Variants: []domain.Variant{
+				structuredDataVariant("shirt-red", 19.99, "in", "red"),
+				structuredDataVariant("shirt-blue", 24.99, "out", "blue"),
+			},
+		}
+
+		data := builder.BuildProduct(context.Background(), configurable)
+		assert.Equal(t, "ProductGroup", data["@type"])
+		assert.Equal(t, "shirt", data["productGroupID"])
+		assert.Equal(t, []string{"color"}, data["variesBy"])
+
+		variants := data["hasVariant"].([]application.JSONLD)
+		assert.Len(t, variants, 2)
+		assert.Equal(t, "400shirt-red", variants[0]["gtin"])
+		assert.Equal(t, "https://schema.org/OutOfStock", variants[1]["offers"].(application.JSONLD)["availability"])
+		assert.Equal(t, application.JSONLD{
+			"@type":         "AggregateOffer",
+			"lowPrice":      19.99,
+			"highPrice":     24.99,
+			"priceCurrency": "EUR",
+			"offerCount":    2,
+		}, data["offers"])
+
+		withActiveVariant, err := configurable.GetConfigurableWithActiveVariant("shirt-blue")
+		assert.NoError(t, err)
+		data = builder.BuildProduct(context.Background(), withActiveVariant)
+		assert.Equal(t, "Product", data["@type"])
+		assert.Equal(t, "shirt-blue", data["sku"])
+		assert.Equal(t, "blue", data["color"])
+		assert.Equal(t, "shirt", data["isVariantOf"].(application.JSONLD)["productGroupID"])
+	})
+
+	t.Run("no product", func(t *testing.T) {
+		assert.Nil(t, builder.BuildProduct(context.Background(), nil))
+	})
+}
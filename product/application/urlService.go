@@ -39,7 +39,7 @@ func (s *URLService) GetURLParams(product domain.BasicProduct, variantCode strin
 		return params
 	}
 
-	if product.Type() == domain.TypeSimple {
+	if product.Type() == domain.TypeSimple || product.Type() == domain.TypeBundle {
 		params["marketplacecode"] = product.BaseData().MarketPlaceCode
 		params["name"] = web.URLTitle(product.BaseData().Title)
 	}
@@ -0,0 +1,49 @@
+package application
+
+import (
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// VariantSelectionService builds the domain.VariantSelection of configurables including the variant urls
+	VariantSelectionService struct {
+		urlService *URLService
+		clock      domain.Clock
+	}
+)
+
+// Inject dependencies
+func (s *VariantSelectionService) Inject(urlService *URLService, clock domain.Clock) {
+	s.urlService = urlService
+	s.clock = clock
+}
+
+// Get returns the variant selection of the configurable - the activeVariantCode is optional
+func (s *VariantSelectionService) Get(configurable domain.ConfigurableProduct, activeVariantCode string) domain.VariantSelection {
+	selection := domain.NewVariantSelection(configurable, activeVariantCode, s.clock.Now())
+	if s.urlService == nil {
+		return selection
+	}
+	for i, variant := range selection.Variants {
+		selection.Variants[i].URL, _ = s.urlService.Get(configurable, variant.Marketplacecode)
+	}
+	return selection
+}
+
+// GetForProduct returns the variant selection for configurables (with the active variant as selected variant) and false for all other product types
+func (s *VariantSelectionService) GetForProduct(product domain.BasicProduct) (domain.VariantSelection, bool) {
+	switch p := product.(type) {
+	case domain.ConfigurableProduct:
+		return s.Get(p, ""), true
+	case domain.ConfigurableProductWithActiveVariant:
+		configurable := domain.ConfigurableProduct{
+			Identifier:                 p.Identifier,
+			BasicProductData:           p.ConfigurableBaseData(),
+			Teaser:                     p.Teaser,
+			VariantVariationAttributes: p.VariantVariationAttributes,
+			Variants:                   p.Variants,
+		}
+		return s.Get(configurable, p.ActiveVariant.MarketPlaceCode), true
+	}
+	return domain.VariantSelection{}, false
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// VisibilityService checks the visibility window of products against the domain.Clock, if the visibility is enforced
+	VisibilityService struct {
+		clock             domain.Clock
+		enforceVisibility bool
+	}
+)
+
+// Inject dependencies
+func (s *VisibilityService) Inject(
+	clock domain.Clock,
+	config *struct {
+		EnforceVisibility bool `inject:"config:commerce.product.visibility.enforce,optional"`
+	},
+) {
+	s.clock = clock
+	if config != nil {
+		s.enforceVisibility = config.EnforceVisibility
+	}
+}
+
+// IsVisible checks the visibility window of the product (and of the active variant of configurables) - products are always visible if the visibility is not enforced
+func (s *VisibilityService) IsVisible(product domain.BasicProduct) bool {
+	if !s.enforceVisibility {
+		return true
+	}
+	return domain.IsProductVisibleAt(product, s.clock.Now())
+}
@@ -0,0 +1,27 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+func TestVisibilityService_IsVisible(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	expired := domain.SimpleProduct{BasicProductData: domain.BasicProductData{VisibleTo: now.Add(-time.Hour)}}
+
+	service := new(application.VisibilityService)
+	service.Inject(domain.FixedClock{Time: now}, &struct {
+		EnforceVisibility bool `inject:"config:commerce.product.visibility.enforce,optional"`
+	}{EnforceVisibility: true})
+	assert.False(t, service.IsVisible(expired), "products are hidden after their visibility window")
+	assert.True(t, service.IsVisible(domain.SimpleProduct{}), "unset windows are open")
+
+	service = new(application.VisibilityService)
+	service.Inject(domain.FixedClock{Time: now}, nil)
+	assert.True(t, service.IsVisible(expired), "visibility is not checked if not enforced")
+}
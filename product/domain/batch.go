@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// GetManyConcurrency is the maximum number of parallel ProductService.Get calls of the GetMany fallback
+	GetManyConcurrency = 10
+)
+
+// GetMany loads the products with the given marketplace codes. If the productService implements BatchProductService its GetMany is used,
+// otherwise the products are loaded with concurrent ProductService.Get calls.
+// Duplicate and empty marketplace codes are ignored. Products that are not found are missing in the result, other errors are returned as BatchGetError
+func GetMany(ctx context.Context, productService ProductService, marketplaceCodes ...string) (map[string]BasicProduct, error) {
+	codes := uniqueCodes(marketplaceCodes)
+	if len(codes) == 0 {
+		return make(map[string]BasicProduct), nil
+	}
+
+	if batchService, ok := productService.(BatchProductService); ok {
+		return batchService.GetMany(ctx, codes...)
+	}
+
+	return getConcurrent(ctx, productService, codes)
+}
+
+// getConcurrent is the fallback for ProductService implementations without batch support
+func getConcurrent(ctx context.Context, productService ProductService, codes []string) (map[string]BasicProduct, error) {
+	var (
+		mutex     sync.Mutex
+		waitGroup sync.WaitGroup
+		products  = make(map[string]BasicProduct, len(codes))
+		errs      = make(map[string]error)
+		semaphore = make(chan struct{}, GetManyConcurrency)
+	)
+
+	for _, code := range codes {
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(code string) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+
+			product, err := productService.Get(ctx, code)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			switch err.(type) {
+			case nil:
+				if product != nil {
+					products[code] = product
+				}
+			case ProductNotFound, *ProductNotFound:
+			default:
+				errs[code] = err
+			}
+		}(code)
+	}
+	waitGroup.Wait()
+
+	if len(errs) > 0 {
+		return products, &BatchGetError{Errors: errs}
+	}
+	return products, nil
+}
+
+func uniqueCodes(marketplaceCodes []string) []string {
+	seen := make(map[string]bool, len(marketplaceCodes))
+	codes := make([]string, 0, len(marketplaceCodes))
+	for _, code := range marketplaceCodes {
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	return codes
+}
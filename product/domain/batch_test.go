@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	productServiceStub struct {
+		mutex sync.Mutex
+		calls []string
+	}
+
+	batchProductServiceStub struct {
+		productServiceStub
+		batchCalls [][]string
+	}
+)
+
+func (s *productServiceStub) Get(ctx context.Context, marketplaceCode string) (BasicProduct, error) {
+	s.mutex.Lock()
+	s.calls = append(s.calls, marketplaceCode)
+	s.mutex.Unlock()
+
+	switch marketplaceCode {
+	case "unknown":
+		return nil, ProductNotFound{MarketplaceCode: marketplaceCode}
+	case "broken":
+		return nil, errors.New("backend error")
+	}
+	return SimpleProduct{BasicProductData: BasicProductData{MarketPlaceCode: marketplaceCode}}, nil
+}
+
+func (s *batchProductServiceStub) GetMany(ctx context.Context, marketplaceCodes ...string) (map[string]BasicProduct, error) {
+	s.batchCalls = append(s.batchCalls, marketplaceCodes)
+	result := make(map[string]BasicProduct)
+	for _, code := range marketplaceCodes {
+		result[code] = SimpleProduct{BasicProductData: BasicProductData{MarketPlaceCode: code}}
+	}
+	return result, nil
+}
+
+func TestGetMany(t *testing.T) {
+	t.Run("fallback to concurrent get", func(t *testing.T) {
+		service := new(productServiceStub)
+		products, err := GetMany(context.Background(), service, "a", "b", "a", "", "unknown")
+
+		assert.NoError(t, err)
+		assert.Len(t, products, 2)
+		assert.Equal(t, "a", products["a"].BaseData().MarketPlaceCode)
+		assert.Equal(t, "b", products["b"].BaseData().MarketPlaceCode)
+		assert.ElementsMatch(t, []string{"a", "b", "unknown"}, service.calls, "each code should be loaded once")
+	})
+
+	t.Run("errors are returned with the found products", func(t *testing.T) {
+		products, err := GetMany(context.Background(), new(productServiceStub), "a", "broken")
+
+		assert.Len(t, products, 1)
+		if assert.IsType(t, &BatchGetError{}, err) {
+			assert.Len(t, err.(*BatchGetError).Errors, 1)
+			assert.EqualError(t, err, `1 products could not be loaded: "broken": backend error`)
+		}
+	})
+
+	t.Run("many codes", func(t *testing.T) {
+		var codes []string
+		for i := 0; i < 3*GetManyConcurrency; i++ {
+			codes = append(codes, string(rune('a'+i)))
+		}
+		products, err := GetMany(context.Background(), new(productServiceStub), codes...)
+
+		assert.NoError(t, err)
+		assert.Len(t, products, len(codes))
+	})
+
+	t.Run("batch service", func(t *testing.T) {
+		service := new(batchProductServiceStub)
+		products, err := GetMany(context.Background(), service, "a", "b", "a")
+
+		assert.NoError(t, err)
+		assert.Len(t, products, 2)
+		assert.Equal(t, [][]string{{"a", "b"}}, service.batchCalls)
+		assert.Empty(t, service.calls)
+	})
+
+	t.Run("no codes", func(t *testing.T) {
+		service := new(batchProductServiceStub)
+		products, err := GetMany(context.Background(), service)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.Empty(t, service.batchCalls)
+	})
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// ProductViewedEvent is dispatched by the product detail view
+	ProductViewedEvent struct {
+		// MarketplaceCode of the viewed product (the configurable for variants)
+		MarketplaceCode string
+		// VariantMarketplaceCode of the active variant - empty if no variant is selected
+		VariantMarketplaceCode string
+		Product                BasicProduct
+	}
+)
+
+var _ flamingo.Event = (*ProductViewedEvent)(nil)
@@ -289,17 +289,17 @@ func (bpd BasicProductData) GetMedia(usage string) Media {
 
 // IsSaleableNow  checks flag and time
 func (p Saleable) IsSaleableNow() bool {
+	return p.IsSaleableAt(time.Now())
+}
+
+// IsSaleableAt checks flag and the saleable window for the given time
+func (p Saleable) IsSaleableAt(t time.Time) bool {
 	if p.IsSaleable == false {
 		return false
 	}
 
 	//For some reasons IsZero does not always work - thats why we check for 1970
-	if (p.SaleableFrom.IsZero() || p.SaleableFrom.Year() == 1970 || p.SaleableFrom.Before(time.Now())) &&
-		(p.SaleableTo.IsZero() || p.SaleableTo.Year() == 1970 || p.SaleableTo.After(time.Now())) {
-		return true
-	}
-
-	return false
+	return isInTimeWindow(t, p.SaleableFrom, p.SaleableTo)
 }
 
 // GetLoyaltyPriceByType - returns the loyaltyentry that matches the type
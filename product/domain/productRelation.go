@@ -0,0 +1,34 @@
+package domain
+
+import "context"
+
+const (
+	// RelationTypeCrossSell denotes products that are bought together with the product ("customers also bought")
+	RelationTypeCrossSell = "crossSell"
+	// RelationTypeUpSell denotes higher-value alternatives (upgrades) of the product
+	RelationTypeUpSell = "upSell"
+	// RelationTypeAccessory denotes accessories of the product
+	RelationTypeAccessory = "accessory"
+)
+
+type (
+	// ProductRelationService is an optional secondary port that returns the relations of a product to other products
+	ProductRelationService interface {
+		// GetRelations returns the relations of the product in the order they should be shown.
+		// If no relation types are given the relations of all types are returned
+		GetRelations(ctx context.Context, marketplaceCode string, relationTypes ...string) ([]ProductRelation, error)
+	}
+
+	// ProductRelation references a related product
+	ProductRelation struct {
+		// Type is one of the RelationType constants (or a custom type)
+		Type string
+		// MarketplaceCode of the related product
+		MarketplaceCode string
+	}
+)
+
+// RelationTypes returns the known relation types
+func RelationTypes() []string {
+	return []string{RelationTypeCrossSell, RelationTypeUpSell, RelationTypeAccessory}
+}
@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
 )
@@ -14,6 +16,14 @@ type (
 		Get(ctx context.Context, marketplaceCode string) (BasicProduct, error)
 	}
 
+	// BatchProductService is an optional secondary port for backends that can load several products with one request.
+	// Use GetMany to load products - it uses this port if the bound ProductService implements it
+	BatchProductService interface {
+		// GetMany returns the found products by marketplace code. Products that are not found are missing in the result.
+		// The returned error (e.g. a BatchGetError) does not prevent the usage of the returned products
+		GetMany(ctx context.Context, marketplaceCodes ...string) (map[string]BasicProduct, error)
+	}
+
 	// SearchResult returns product hits
 	SearchResult struct {
 		searchDomain.Result
@@ -33,9 +43,29 @@ type (
 	ProductNotFound struct {
 		MarketplaceCode string
 	}
+
+	// BatchGetError contains the errors of the products that could not be loaded - other than ProductNotFound
+	BatchGetError struct {
+		Errors map[string]error
+	}
 )
 
 // Error implements the error interface
 func (err ProductNotFound) Error() string {
 	return fmt.Sprintf("Product with Marketplace Code %q Not Found", err.MarketplaceCode)
 }
+
+// Error implements the error interface
+func (err *BatchGetError) Error() string {
+	codes := make([]string, 0, len(err.Errors))
+	for code := range err.Errors {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	messages := make([]string, len(codes))
+	for i, code := range codes {
+		messages[i] = fmt.Sprintf("%q: %v", code, err.Errors[code])
+	}
+	return fmt.Sprintf("%d products could not be loaded: %s", len(codes), strings.Join(messages, ", "))
+}
@@ -0,0 +1,425 @@
+package domain
+
+import (
+	"sort"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TypeBundle denotes bundle products
+	TypeBundle = "bundle"
+
+	// TypeBundleWithActiveChoices denotes bundle products that have their component choices selected
+	TypeBundleWithActiveChoices = "bundle_with_activechoices"
+
+	// BundleTypeFixed denotes bundles that consist of predefined components (e.g. a camera kit)
+	BundleTypeFixed = "fixed"
+
+	// BundleTypeConfigurable denotes bundles where the customer chooses the components
+	BundleTypeConfigurable = "configurable"
+)
+
+var (
+	// ErrBundleOptionNotFound is returned if a bundle configuration references an unknown option
+	ErrBundleOptionNotFound = errors.New("bundle option not found")
+	// ErrBundleChoiceNotFound is returned if a bundle configuration references an unknown choice
+	ErrBundleChoiceNotFound = errors.New("bundle choice not found")
+	// ErrBundleRequiredOptionMissing is returned if a required option has no choice
+	ErrBundleRequiredOptionMissing = errors.New("required bundle option has no choice")
+	// ErrBundleFixedChoice is returned if the components of a fixed bundle should be changed
+	ErrBundleFixedChoice = errors.New("choices of a fixed bundle can not be changed")
+	// ErrBundleInvalidQty is returned if the configured qty of a component is not allowed
+	ErrBundleInvalidQty = errors.New("invalid qty for bundle choice")
+)
+
+type (
+	// BundleProduct - A product that consists of several child products (components) which are sold together as one item
+	BundleProduct struct {
+		Identifier string
+		BasicProductData
+		Teaser TeaserData
+		// BundleType is either BundleTypeFixed or BundleTypeConfigurable
+		BundleType string
+		Options    []BundleOption
+	}
+
+	// BundleProductWithActiveChoices - A bundle product with selected choices for its options - this is the saleable bundle
+	BundleProductWithActiveChoices struct {
+		Identifier string
+		BasicProductData
+		Teaser        TeaserData
+		BundleType    string
+		Options       []BundleOption
+		ActiveChoices []BundleActiveChoice
+	}
+
+	// BundleOption is a slot of the bundle that is filled by one of its choices
+	BundleOption struct {
+		Code     string
+		Label    string
+		Required bool
+		Choices  []BundleChoice
+	}
+
+	// BundleChoice is a concrete product that can be chosen for a BundleOption
+	BundleChoice struct {
+		BasicProductData
+		Saleable
+		// Qty is the default qty of the component per bundle
+		Qty int
+		// CanChangeQty - if true the customer may choose a qty between MinQty and MaxQty
+		CanChangeQty bool
+		MinQty       int
+		MaxQty       int
+		// IsDefault marks the preselected choice of the option
+		IsDefault bool
+	}
+
+	// BundleActiveChoice is the chosen component for an option
+	BundleActiveChoice struct {
+		OptionCode  string
+		OptionLabel string
+		Choice      BundleChoice
+		// Qty is the qty of the component per bundle
+		Qty int
+	}
+
+	// BundleConfiguration describes the selected choices of a bundle, indexed by option code
+	BundleConfiguration map[string]BundleChoiceConfiguration
+
+	// BundleChoiceConfiguration is the selected choice for one option
+	BundleChoiceConfiguration struct {
+		MarketplaceCode string
+		Qty             int
+	}
+)
+
+var _ BasicProduct = BundleProduct{}
+var _ BasicProduct = BundleProductWithActiveChoices{}
+
+// Type interface implementation for BundleProduct
+func (p BundleProduct) Type() string {
+	return TypeBundle
+}
+
+// IsSaleable is true for fixed bundles with a valid default selection - configurable bundles need to get their choices selected
+func (p BundleProduct) IsSaleable() bool {
+	if p.BundleType != BundleTypeFixed {
+		return false
+	}
+	_, err := p.GetBundleWithActiveChoices(nil)
+	return err == nil
+}
+
+// SaleableData getter for BundleProduct - returns the aggregated saleable data of the default choices
+func (p BundleProduct) SaleableData() Saleable {
+	bundle, err := p.GetBundleWithActiveChoices(nil)
+	if err != nil {
+		return Saleable{}
+	}
+	return bundle.SaleableData()
+}
+
+// GetIdentifier interface implementation for BundleProduct
+func (p BundleProduct) GetIdentifier() string {
+	return p.Identifier
+}
+
+// BaseData interface implementation for BundleProduct
+func (p BundleProduct) BaseData() BasicProductData {
+	return p.BasicProductData
+}
+
+// TeaserData interface implementation for BundleProduct
+func (p BundleProduct) TeaserData() TeaserData {
+	return p.Teaser
+}
+
+// HasMedia for BundleProduct
+func (p BundleProduct) HasMedia(group string, usage string) bool {
+	media := findMediaInProduct(BasicProduct(p), group, usage)
+	if media == nil {
+		return false
+	}
+	return true
+}
+
+// GetMedia for BundleProduct
+func (p BundleProduct) GetMedia(group string, usage string) Media {
+	return *findMediaInProduct(BasicProduct(p), group, usage)
+}
+
+// Option getter - the option is retrieved by its code
+func (p BundleProduct) Option(code string) (*BundleOption, error) {
+	return findBundleOption(p.Options, code)
+}
+
+// DefaultConfiguration returns the configuration of the default choices
+func (p BundleProduct) DefaultConfiguration() BundleConfiguration {
+	configuration := make(BundleConfiguration)
+	for _, option := range p.Options {
+		if choice := option.DefaultChoice(); choice != nil {
+			configuration[option.Code] = BundleChoiceConfiguration{MarketplaceCode: choice.MarketPlaceCode, Qty: choice.Qty}
+		}
+	}
+	return configuration
+}
+
+// GetBundleWithActiveChoices returns the bundle with the given choices selected.
+// Options missing in the configuration get their default choice. Fixed bundles only accept their default choices.
+func (p BundleProduct) GetBundleWithActiveChoices(configuration BundleConfiguration) (BundleProductWithActiveChoices, error) {
+	for code := range configuration {
+		if _, err := p.Option(code); err != nil {
+			return BundleProductWithActiveChoices{}, err
+		}
+	}
+
+	activeChoices := make([]BundleActiveChoice, 0, len(p.Options))
+	for _, option := range p.Options {
+		choice := option.DefaultChoice()
+		selected, configured := configuration[option.Code]
+		if configured {
+			configuredChoice, err := option.Choice(selected.MarketplaceCode)
+			if err != nil {
+				return BundleProductWithActiveChoices{}, errors.Wrapf(err, "option %q", option.Code)
+			}
+			if p.BundleType == BundleTypeFixed && (choice == nil || choice.MarketPlaceCode != configuredChoice.MarketPlaceCode) {
+				return BundleProductWithActiveChoices{}, errors.Wrapf(ErrBundleFixedChoice, "option %q", option.Code)
+			}
+			choice = configuredChoice
+		}
+
+		if choice == nil {
+			if option.Required {
+				return BundleProductWithActiveChoices{}, errors.Wrapf(ErrBundleRequiredOptionMissing, "option %q", option.Code)
+			}
+			continue
+		}
+
+		qty := choice.Qty
+		if configured && selected.Qty > 0 && selected.Qty != qty {
+			if !choice.CanChangeQty || (choice.MinQty > 0 && selected.Qty < choice.MinQty) || (choice.MaxQty > 0 && selected.Qty > choice.MaxQty) {
+				return BundleProductWithActiveChoices{}, errors.Wrapf(ErrBundleInvalidQty, "option %q", option.Code)
+			}
+			qty = selected.Qty
+		}
+		if qty <= 0 {
+			qty = 1
+		}
+
+		activeChoices = append(activeChoices, BundleActiveChoice{
+			OptionCode:  option.Code,
+			OptionLabel: option.Label,
+			Choice:      *choice,
+			Qty:         qty,
+		})
+	}
+
+	return BundleProductWithActiveChoices{
+		Identifier:       p.Identifier,
+		BasicProductData: p.BasicProductData,
+		Teaser:           p.Teaser,
+		BundleType:       p.BundleType,
+		Options:          p.Options,
+		ActiveChoices:    activeChoices,
+	}, nil
+}
+
+// IsInStock is true if all default components are in stock
+func (p BundleProduct) IsInStock() bool {
+	bundle, err := p.GetBundleWithActiveChoices(nil)
+	if err != nil {
+		return false
+	}
+	return bundle.IsInStock()
+}
+
+// DefaultChoice returns the choice marked as default - for options with exactly one choice this choice is the default
+func (o BundleOption) DefaultChoice() *BundleChoice {
+	for _, choice := range o.Choices {
+		if choice.IsDefault {
+			return &choice
+		}
+	}
+	if len(o.Choices) == 1 {
+		return &o.Choices[0]
+	}
+	return nil
+}
+
+// Choice getter - the choice is retrieved by the marketplace code of its product
+func (o BundleOption) Choice(marketplaceCode string) (*BundleChoice, error) {
+	for _, choice := range o.Choices {
+		if choice.MarketPlaceCode == marketplaceCode {
+			return &choice, nil
+		}
+	}
+	return nil, ErrBundleChoiceNotFound
+}
+
+// SaleableData getter for BundleChoice
+func (c BundleChoice) SaleableData() Saleable {
+	return c.Saleable
+}
+
+//********BUNDLE WITH ACTIVE CHOICES
+
+// Type getter
+func (p BundleProductWithActiveChoices) Type() string {
+	return TypeBundleWithActiveChoices
+}
+
+// IsSaleable is true if all chosen components are saleable
+func (p BundleProductWithActiveChoices) IsSaleable() bool {
+	return p.SaleableData().IsSaleable
+}
+
+// GetIdentifier getter
+func (p BundleProductWithActiveChoices) GetIdentifier() string {
+	return p.Identifier
+}
+
+// BaseData getter - returns the data of the bundle itself, the data of the components is part of the ActiveChoices
+func (p BundleProductWithActiveChoices) BaseData() BasicProductData {
+	return p.BasicProductData
+}
+
+// TeaserData getter
+func (p BundleProductWithActiveChoices) TeaserData() TeaserData {
+	return p.Teaser
+}
+
+// HasMedia for BundleProductWithActiveChoices
+func (p BundleProductWithActiveChoices) HasMedia(group string, usage string) bool {
+	media := findMediaInProduct(BasicProduct(p), group, usage)
+	if media == nil {
+		return false
+	}
+	return true
+}
+
+// GetMedia for BundleProductWithActiveChoices
+func (p BundleProductWithActiveChoices) GetMedia(group string, usage string) Media {
+	return *findMediaInProduct(BasicProduct(p), group, usage)
+}
+
+// Option getter - the option is retrieved by its code
+func (p BundleProductWithActiveChoices) Option(code string) (*BundleOption, error) {
+	return findBundleOption(p.Options, code)
+}
+
+// ActiveChoice returns the chosen component for the given option
+func (p BundleProductWithActiveChoices) ActiveChoice(optionCode string) (*BundleActiveChoice, bool) {
+	for _, activeChoice := range p.ActiveChoices {
+		if activeChoice.OptionCode == optionCode {
+			return &activeChoice, true
+		}
+	}
+	return nil, false
+}
+
+// Configuration returns the BundleConfiguration that describes the active choices
+func (p BundleProductWithActiveChoices) Configuration() BundleConfiguration {
+	configuration := make(BundleConfiguration, len(p.ActiveChoices))
+	for _, activeChoice := range p.ActiveChoices {
+		configuration[activeChoice.OptionCode] = BundleChoiceConfiguration{MarketplaceCode: activeChoice.Choice.MarketPlaceCode, Qty: activeChoice.Qty}
+	}
+	return configuration
+}
+
+// IsInStock is true if all chosen components are in stock
+func (p BundleProductWithActiveChoices) IsInStock() bool {
+	if len(p.ActiveChoices) == 0 {
+		return false
+	}
+	for _, activeChoice := range p.ActiveChoices {
+		if !activeChoice.Choice.IsInStock() {
+			return false
+		}
+	}
+	return true
+}
+
+// SaleableData returns the saleable data aggregated over the chosen components:
+// the price is the sum of the component prices multiplied with their qty and the saleable window is the intersection of the component windows
+func (p BundleProductWithActiveChoices) SaleableData() Saleable {
+	if len(p.ActiveChoices) == 0 {
+		return Saleable{}
+	}
+
+	saleable := Saleable{IsSaleable: true}
+	var defaultPrices, finalPrices []priceDomain.Price
+	taxClass := p.ActiveChoices[0].Choice.ActivePrice.TaxClass
+	for _, activeChoice := range p.ActiveChoices {
+		choice := activeChoice.Choice
+		if !choice.IsSaleable {
+			saleable.IsSaleable = false
+		}
+		if !choice.SaleableFrom.IsZero() && choice.SaleableFrom.After(saleable.SaleableFrom) {
+			saleable.SaleableFrom = choice.SaleableFrom
+		}
+		if !choice.SaleableTo.IsZero() && (saleable.SaleableTo.IsZero() || choice.SaleableTo.Before(saleable.SaleableTo)) {
+			saleable.SaleableTo = choice.SaleableTo
+		}
+		if choice.ActivePrice.IsDiscounted {
+			saleable.ActivePrice.IsDiscounted = true
+		}
+		if choice.ActivePrice.TaxClass != taxClass {
+			taxClass = ""
+		}
+		defaultPrices = append(defaultPrices, choice.ActivePrice.Default.Multiply(activeChoice.Qty))
+		finalPrices = append(finalPrices, choice.ActivePrice.GetFinalPrice().Multiply(activeChoice.Qty))
+	}
+
+	defaultPrice, err := priceDomain.SumAll(defaultPrices...)
+	if err != nil {
+		return Saleable{}
+	}
+	finalPrice, err := priceDomain.SumAll(finalPrices...)
+	if err != nil {
+		return Saleable{}
+	}
+
+	saleable.ActivePrice.Default = defaultPrice
+	saleable.ActivePrice.TaxClass = taxClass
+	if saleable.ActivePrice.IsDiscounted {
+		saleable.ActivePrice.Discounted = finalPrice
+	}
+	saleable.AvailablePrices = []PriceInfo{saleable.ActivePrice}
+
+	return saleable
+}
+
+// Equal checks if both configurations select the same choices with the same qty
+func (c BundleConfiguration) Equal(other BundleConfiguration) bool {
+	if len(c) != len(other) {
+		return false
+	}
+	for code, choice := range c {
+		if otherChoice, ok := other[code]; !ok || otherChoice != choice {
+			return false
+		}
+	}
+	return true
+}
+
+// OptionCodes returns the sorted option codes of the configuration
+func (c BundleConfiguration) OptionCodes() []string {
+	codes := make([]string, 0, len(c))
+	for code := range c {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func findBundleOption(options []BundleOption, code string) (*BundleOption, error) {
+	for _, option := range options {
+		if option.Code == code {
+			return &option, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrBundleOptionNotFound, "option %q", code)
+}
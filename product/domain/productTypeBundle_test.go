@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+)
+
+func bundleChoice(marketplaceCode string, price float64, stockLevel string) BundleChoice {
+	return BundleChoice{
+		BasicProductData: BasicProductData{MarketPlaceCode: marketplaceCode, Title: marketplaceCode, StockLevel: stockLevel},
+		Saleable: Saleable{
+			IsSaleable:  true,
+			ActivePrice: PriceInfo{Default: priceDomain.NewFromFloat(price, "EUR")},
+		},
+		Qty: 1,
+	}
+}
+
+func cameraKit(bundleType string) BundleProduct {
+	lens := bundleChoice("lens-50mm", 200, "in")
+	lens.IsDefault = true
+	battery := bundleChoice("battery", 20, "in")
+	battery.CanChangeQty = true
+	battery.MinQty = 1
+	battery.MaxQty = 3
+	return BundleProduct{
+		BasicProductData: BasicProductData{MarketPlaceCode: "camera-kit", Title: "Camera Kit"},
+		BundleType:       bundleType,
+		Options: []BundleOption{
+			{Code: "body", Required: true, Choices: []BundleChoice{bundleChoice("body", 500, "in")}},
+			{Code: "lens", Required: true, Choices: []BundleChoice{lens, bundleChoice("lens-85mm", 300, "out")}},
+			{Code: "battery", Choices: []BundleChoice{battery}},
+		},
+	}
+}
+
+func TestBundleProduct_FixedBundle(t *testing.T) {
+	bundle := cameraKit(BundleTypeFixed)
+	assert.Equal(t, TypeBundle, bundle.Type())
+	assert.True(t, bundle.IsSaleable())
+	assert.True(t, bundle.IsInStock())
+	assert.Equal(t, 720.0, bundle.SaleableData().ActivePrice.GetFinalPrice().FloatAmount())
+
+	_, err := bundle.GetBundleWithActiveChoices(BundleConfiguration{"lens": {MarketplaceCode: "lens-85mm"}})
+	assert.Equal(t, ErrBundleFixedChoice, errors.Cause(err))
+}
+
+func TestBundleProduct_GetBundleWithActiveChoices(t *testing.T) {
+	bundle := cameraKit(BundleTypeConfigurable)
+	assert.False(t, bundle.IsSaleable())
+
+	t.Run("choices and qty are applied", func(t *testing.T) {
+		active, err := bundle.GetBundleWithActiveChoices(BundleConfiguration{
+			"lens":    {MarketplaceCode: "lens-85mm"},
+			"battery": {MarketplaceCode: "battery", Qty: 2},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, TypeBundleWithActiveChoices, active.Type())
+		assert.Len(t, active.ActiveChoices, 3)
+		assert.True(t, active.IsSaleable())
+		assert.False(t, active.IsInStock(), "the 85mm lens is out of stock")
+		assert.Equal(t, 840.0, active.SaleableData().ActivePrice.GetFinalPrice().FloatAmount())
+
+		battery, found := active.ActiveChoice("battery")
+		assert.True(t, found)
+		assert.Equal(t, 2, battery.Qty)
+		assert.True(t, active.Configuration().Equal(BundleConfiguration{
+			"body":    {MarketplaceCode: "body", Qty: 1},
+			"lens":    {MarketplaceCode: "lens-85mm", Qty: 1},
+			"battery": {MarketplaceCode: "battery", Qty: 2},
+		}))
+	})
+
+	t.Run("invalid configurations", func(t *testing.T) {
+		_, err := bundle.GetBundleWithActiveChoices(BundleConfiguration{"tripod": {MarketplaceCode: "tripod"}})
+		assert.Equal(t, ErrBundleOptionNotFound, errors.Cause(err))
+
+		_, err = bundle.GetBundleWithActiveChoices(BundleConfiguration{"lens": {MarketplaceCode: "lens-35mm"}})
+		assert.Equal(t, ErrBundleChoiceNotFound, errors.Cause(err))
+
+		_, err = bundle.GetBundleWithActiveChoices(BundleConfiguration{"battery": {MarketplaceCode: "battery", Qty: 4}})
+		assert.Equal(t, ErrBundleInvalidQty, errors.Cause(err))
+
+		_, err = bundle.GetBundleWithActiveChoices(BundleConfiguration{"body": {MarketplaceCode: "body", Qty: 2}})
+		assert.Equal(t, ErrBundleInvalidQty, errors.Cause(err))
+	})
+
+	t.Run("required option without default", func(t *testing.T) {
+		bundle := cameraKit(BundleTypeConfigurable)
+		bundle.Options[1].Choices[0].IsDefault = false
+		_, err := bundle.GetBundleWithActiveChoices(nil)
+		assert.Equal(t, ErrBundleRequiredOptionMissing, errors.Cause(err))
+	})
+}
+
+func TestBundleProductWithActiveChoices_SaleableData(t *testing.T) {
+	bundle := cameraKit(BundleTypeConfigurable)
+	bundle.Options[0].Choices[0].ActivePrice.IsDiscounted = true
+	bundle.Options[0].Choices[0].ActivePrice.Discounted = priceDomain.NewFromFloat(450, "EUR")
+	bundle.Options[1].Choices[0].IsSaleable = false
+
+	active, err := bundle.GetBundleWithActiveChoices(nil)
+	assert.NoError(t, err)
+
+	saleable := active.SaleableData()
+	assert.False(t, saleable.IsSaleable)
+	assert.True(t, saleable.ActivePrice.IsDiscounted)
+	assert.Equal(t, 720.0, saleable.ActivePrice.Default.FloatAmount())
+	assert.Equal(t, 670.0, saleable.ActivePrice.GetFinalPrice().FloatAmount())
+
+	bundle.Options[2].Choices[0].ActivePrice.Default = priceDomain.NewFromFloat(20, "USD")
+	active, _ = bundle.GetBundleWithActiveChoices(nil)
+	assert.Equal(t, Saleable{}, active.SaleableData(), "mixed currencies can not be aggregated")
+}
@@ -0,0 +1,415 @@
+package domain
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+)
+
+// Unit families - units can only be converted within the same family
+const (
+	UnitFamilyArea           = "area"
+	UnitFamilyBinary         = "binary"
+	UnitFamilyLoudness       = "loudness"
+	UnitFamilyFrequency      = "frequency"
+	UnitFamilyLength         = "length"
+	UnitFamilyPower          = "power"
+	UnitFamilyVoltage        = "voltage"
+	UnitFamilyIntensity      = "intensity"
+	UnitFamilyResistance     = "resistance"
+	UnitFamilySpeed          = "speed"
+	UnitFamilyElectricCharge = "electricCharge"
+	UnitFamilyDuration       = "duration"
+	UnitFamilyTemperature    = "temperature"
+	UnitFamilyVolume         = "volume"
+	UnitFamilyWeight         = "weight"
+	UnitFamilyPressure       = "pressure"
+	UnitFamilyEnergy         = "energy"
+	UnitFamilyPiece          = "piece"
+)
+
+var (
+	// ErrUnknownUnit is returned for unit codes that are not part of the Units
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrIncompatibleUnits is returned if units of different families should be converted
+	ErrIncompatibleUnits = errors.New("units are not of the same family")
+	// ErrNoBasePrice is returned if no base price can be calculated
+	ErrNoBasePrice = errors.New("no base price available")
+)
+
+type (
+	// unitConversion converts a unit into the standard unit of its family: standard = value * factor + offset
+	unitConversion struct {
+		family string
+		factor float64
+		offset float64
+	}
+
+	// BasePrice is the price of a reference quantity of a product - e.g. "1.99 € / 100 g"
+	BasePrice struct {
+		Price priceDomain.Price
+		// Amount of the reference quantity (e.g. 100)
+		Amount float64
+		// Unit of the reference quantity (e.g. GRAM)
+		Unit Unit
+	}
+)
+
+// unitConversions defines the conversion of each unit into the standard unit of its family
+// (square meter, byte, hertz, meter, watt, volt, ampere, ohm, meter per second, coulomb, second, kelvin, liter, gram, pascal, joule and piece)
+var unitConversions = map[string]unitConversion{
+	SQUARE_MILLIMETER: {family: UnitFamilyArea, factor: 0.000001},
+	SQUARE_CENTIMETER: {family: UnitFamilyArea, factor: 0.0001},
+	SQUARE_DECIMETER:  {family: UnitFamilyArea, factor: 0.01},
+	SQUARE_METER:      {family: UnitFamilyArea, factor: 1},
+	CENTIARE:          {family: UnitFamilyArea, factor: 1},
+	SQUARE_DEKAMETER:  {family: UnitFamilyArea, factor: 100},
+	ARE:               {family: UnitFamilyArea, factor: 100},
+	SQUARE_HECTOMETER: {family: UnitFamilyArea, factor: 10000},
+	HECTARE:           {family: UnitFamilyArea, factor: 10000},
+	SQUARE_KILOMETER:  {family: UnitFamilyArea, factor: 1000000},
+	SQUARE_MIL:        {family: UnitFamilyArea, factor: 0.00000000064516},
+	SQUARE_INCH:       {family: UnitFamilyArea, factor: 0.00064516},
+	SQUARE_FOOT:       {family: UnitFamilyArea, factor: 0.09290304},
+	SQUARE_YARD:       {family: UnitFamilyArea, factor: 0.83612736},
+	ARPENT:            {family: UnitFamilyArea, factor: 3418.89},
+	ACRE:              {family: UnitFamilyArea, factor: 4046.8564224},
+	SQUARE_FURLONG:    {family: UnitFamilyArea, factor: 40468.564224},
+	SQUARE_MILE:       {family: UnitFamilyArea, factor: 2589988.110336},
+
+	BIT:      {family: UnitFamilyBinary, factor: 0.125},
+	BYTE:     {family: UnitFamilyBinary, factor: 1},
+	KILOBYTE: {family: UnitFamilyBinary, factor: 1024},
+	MEGABYTE: {family: UnitFamilyBinary, factor: 1048576},
+	GIGABYTE: {family: UnitFamilyBinary, factor: 1073741824},
+	TERABYTE: {family: UnitFamilyBinary, factor: 1099511627776},
+
+	DECIBEL: {family: UnitFamilyLoudness, factor: 1},
+
+	HERTZ:     {family: UnitFamilyFrequency, factor: 1},
+	KILOHERTZ: {family: UnitFamilyFrequency, factor: 1000},
+	MEGAHERTZ: {family: UnitFamilyFrequency, factor: 1000000},
+	GIGAHERTZ: {family: UnitFamilyFrequency, factor: 1000000000},
+	TERAHERTZ: {family: UnitFamilyFrequency, factor: 1000000000000},
+
+	MILLIMETER: {family: UnitFamilyLength, factor: 0.001},
+	CENTIMETER: {family: UnitFamilyLength, factor: 0.01},
+	DECIMETER:  {family: UnitFamilyLength, factor: 0.1},
+	METER:      {family: UnitFamilyLength, factor: 1},
+	DEKAMETER:  {family: UnitFamilyLength, factor: 10},
+	HECTOMETER: {family: UnitFamilyLength, factor: 100},
+	KILOMETER:  {family: UnitFamilyLength, factor: 1000},
+	MIL:        {family: UnitFamilyLength, factor: 0.0000254},
+	INCH:       {family: UnitFamilyLength, factor: 0.0254},
+	FEET:       {family: UnitFamilyLength, factor: 0.3048},
+	YARD:       {family: UnitFamilyLength, factor: 0.9144},
+	CHAIN:      {family: UnitFamilyLength, factor: 20.1168},
+	FURLONG:    {family: UnitFamilyLength, factor: 201.168},
+	MILE:       {family: UnitFamilyLength, factor: 1609.344},
+
+	WATT:     {family: UnitFamilyPower, factor: 1},
+	KILOWATT: {family: UnitFamilyPower, factor: 1000},
+	MEGAWATT: {family: UnitFamilyPower, factor: 1000000},
+	GIGAWATT: {family: UnitFamilyPower, factor: 1000000000},
+	TERAWATT: {family: UnitFamilyPower, factor: 1000000000000},
+
+	MILLIVOLT: {family: UnitFamilyVoltage, factor: 0.001},
+	CENTIVOLT: {family: UnitFamilyVoltage, factor: 0.01},
+	DECIVOLT:  {family: UnitFamilyVoltage, factor: 0.1},
+	VOLT:      {family: UnitFamilyVoltage, factor: 1},
+	DEKAVOLT:  {family: UnitFamilyVoltage, factor: 10},
+	HECTOVOLT: {family: UnitFamilyVoltage, factor: 100},
+	KILOVOLT:  {family: UnitFamilyVoltage, factor: 1000},
+
+	MILLIAMPERE: {family: UnitFamilyIntensity, factor: 0.001},
+	CENTIAMPERE: {family: UnitFamilyIntensity, factor: 0.01},
+	DECIAMPERE:  {family: UnitFamilyIntensity, factor: 0.1},
+	AMPERE:      {family: UnitFamilyIntensity, factor: 1},
+	DEKAMPERE:   {family: UnitFamilyIntensity, factor: 10},
+	HECTOAMPERE: {family: UnitFamilyIntensity, factor: 100},
+	KILOAMPERE:  {family: UnitFamilyIntensity, factor: 1000},
+
+	MILLIOHM: {family: UnitFamilyResistance, factor: 0.001},
+	CENTIOHM: {family: UnitFamilyResistance, factor: 0.01},
+	DECIOHM:  {family: UnitFamilyResistance, factor: 0.1},
+	OHM:      {family: UnitFamilyResistance, factor: 1},
+	DEKAOHM:  {family: UnitFamilyResistance, factor: 10},
+	HECTOHM:  {family: UnitFamilyResistance, factor: 100},
+	KILOHM:   {family: UnitFamilyResistance, factor: 1000},
+	MEGOHM:   {family: UnitFamilyResistance, factor: 1000000},
+
+	METER_PER_SECOND:   {family: UnitFamilySpeed, factor: 1},
+	METER_PER_MINUTE:   {family: UnitFamilySpeed, factor: 1.0 / 60},
+	METER_PER_HOUR:     {family: UnitFamilySpeed, factor: 1.0 / 3600},
+	KILOMETER_PER_HOUR: {family: UnitFamilySpeed, factor: 1.0 / 3.6},
+	FOOT_PER_SECOND:    {family: UnitFamilySpeed, factor: 0.3048},
+	FOOT_PER_HOUR:      {family: UnitFamilySpeed, factor: 0.3048 / 3600},
+	YARD_PER_HOUR:      {family: UnitFamilySpeed, factor: 0.9144 / 3600},
+	MILE_PER_HOUR:      {family: UnitFamilySpeed, factor: 0.44704},
+
+	MILLIAMPEREHOUR: {family: UnitFamilyElectricCharge, factor: 3.6},
+	AMPEREHOUR:      {family: UnitFamilyElectricCharge, factor: 3600},
+	MILLICOULOMB:    {family: UnitFamilyElectricCharge, factor: 0.001},
+	CENTICOULOMB:    {family: UnitFamilyElectricCharge, factor: 0.01},
+	DECICOULOMB:     {family: UnitFamilyElectricCharge, factor: 0.1},
+	COULOMB:         {family: UnitFamilyElectricCharge, factor: 1},
+	DEKACOULOMB:     {family: UnitFamilyElectricCharge, factor: 10},
+	HECTOCOULOMB:    {family: UnitFamilyElectricCharge, factor: 100},
+	KILOCOULOMB:     {family: UnitFamilyElectricCharge, factor: 1000},
+
+	MILLISECOND: {family: UnitFamilyDuration, factor: 0.001},
+	SECOND:      {family: UnitFamilyDuration, factor: 1},
+	MINUTE:      {family: UnitFamilyDuration, factor: 60},
+	HOUR:        {family: UnitFamilyDuration, factor: 3600},
+	DAY:         {family: UnitFamilyDuration, factor: 86400},
+	WEEK:        {family: UnitFamilyDuration, factor: 604800},
+	MONTH:       {family: UnitFamilyDuration, factor: 2629800},
+	YEAR:        {family: UnitFamilyDuration, factor: 31557600},
+
+	CELSIUS:    {family: UnitFamilyTemperature, factor: 1, offset: 273.15},
+	FAHRENHEIT: {family: UnitFamilyTemperature, factor: 5.0 / 9, offset: 459.67 * 5 / 9},
+	KELVIN:     {family: UnitFamilyTemperature, factor: 1},
+	RANKINE:    {family: UnitFamilyTemperature, factor: 5.0 / 9},
+	REAUMUR:    {family: UnitFamilyTemperature, factor: 1.25, offset: 273.15},
+
+	CUBIC_MILLIMETER: {family: UnitFamilyVolume, factor: 0.000001},
+	CUBIC_CENTIMETER: {family: UnitFamilyVolume, factor: 0.001},
+	MILLILITER:       {family: UnitFamilyVolume, factor: 0.001},
+	CENTILITER:       {family: UnitFamilyVolume, factor: 0.01},
+	DECILITER:        {family: UnitFamilyVolume, factor: 0.1},
+	CUBIC_DECIMETER:  {family: UnitFamilyVolume, factor: 1},
+	LITER:            {family: UnitFamilyVolume, factor: 1},
+	CUBIC_METER:      {family: UnitFamilyVolume, factor: 1000},
+	OUNCE:            {family: UnitFamilyVolume, factor: 0.0295735296},
+	PINT:             {family: UnitFamilyVolume, factor: 0.473176473},
+	BARREL:           {family: UnitFamilyVolume, factor: 158.987294928},
+	GALLON:           {family: UnitFamilyVolume, factor: 3.785411784},
+	CUBIC_FOOT:       {family: UnitFamilyVolume, factor: 28.316846592},
+	CUBIC_INCH:       {family: UnitFamilyVolume, factor: 0.016387064},
+	CUBIC_YARD:       {family: UnitFamilyVolume, factor: 764.554857984},
+
+	MILLIGRAM: {family: UnitFamilyWeight, factor: 0.001},
+	GRAM:      {family: UnitFamilyWeight, factor: 1},
+	KILOGRAM:  {family: UnitFamilyWeight, factor: 1000},
+	TON:       {family: UnitFamilyWeight, factor: 1000000},
+	GRAIN:     {family: UnitFamilyWeight, factor: 0.06479891},
+	DENIER:    {family: UnitFamilyWeight, factor: 0.001275},
+	POUND:     {family: UnitFamilyWeight, factor: 453.59237},
+	MARC:      {family: UnitFamilyWeight, factor: 244.7529},
+	LIVRE:     {family: UnitFamilyWeight, factor: 489.5058},
+
+	BAR:         {family: UnitFamilyPressure, factor: 100000},
+	PASCAL:      {family: UnitFamilyPressure, factor: 1},
+	HECTOPASCAL: {family: UnitFamilyPressure, factor: 100},
+	MILLIBAR:    {family: UnitFamilyPressure, factor: 100},
+	ATM:         {family: UnitFamilyPressure, factor: 101325},
+	PSI:         {family: UnitFamilyPressure, factor: 6894.757293168},
+	TORR:        {family: UnitFamilyPressure, factor: 133.322368421},
+	MMHG:        {family: UnitFamilyPressure, factor: 133.322387415},
+
+	JOULE:       {family: UnitFamilyEnergy, factor: 1},
+	CALORIE:     {family: UnitFamilyEnergy, factor: 4.184},
+	KILOCALORIE: {family: UnitFamilyEnergy, factor: 4184},
+	KILOJOULE:   {family: UnitFamilyEnergy, factor: 1000},
+
+	PCS:   {family: UnitFamilyPiece, factor: 1},
+	PIECE: {family: UnitFamilyPiece, factor: 1},
+	DOZEN: {family: UnitFamilyPiece, factor: 12},
+}
+
+// normalizedUnits are the units (ascending) that are used to display values of a family in a human readable way
+var normalizedUnits = map[string][]string{
+	UnitFamilyArea:           {SQUARE_MILLIMETER, SQUARE_CENTIMETER, SQUARE_METER, SQUARE_KILOMETER},
+	UnitFamilyBinary:         {BYTE, KILOBYTE, MEGABYTE, GIGABYTE, TERABYTE},
+	UnitFamilyFrequency:      {HERTZ, KILOHERTZ, MEGAHERTZ, GIGAHERTZ, TERAHERTZ},
+	UnitFamilyLength:         {MILLIMETER, CENTIMETER, METER, KILOMETER},
+	UnitFamilyPower:          {WATT, KILOWATT, MEGAWATT, GIGAWATT, TERAWATT},
+	UnitFamilyVoltage:        {MILLIVOLT, VOLT, KILOVOLT},
+	UnitFamilyIntensity:      {MILLIAMPERE, AMPERE, KILOAMPERE},
+	UnitFamilyResistance:     {MILLIOHM, OHM, KILOHM, MEGOHM},
+	UnitFamilyElectricCharge: {MILLIAMPEREHOUR, AMPEREHOUR},
+	UnitFamilyDuration:       {MILLISECOND, SECOND, MINUTE, HOUR, DAY},
+	UnitFamilyVolume:         {MILLILITER, LITER},
+	UnitFamilyWeight:         {MILLIGRAM, GRAM, KILOGRAM, TON},
+	UnitFamilyEnergy:         {JOULE, KILOJOULE},
+}
+
+// GetUnitFamily returns the family of the unit
+func GetUnitFamily(unitCode string) (string, bool) {
+	conversion, ok := unitConversions[unitCode]
+	return conversion.family, ok
+}
+
+// ConvertUnit converts the value from one unit into another unit of the same family
+func ConvertUnit(value float64, fromUnitCode string, toUnitCode string) (float64, error) {
+	from, ok := unitConversions[fromUnitCode]
+	if !ok {
+		return 0, errors.Wrapf(ErrUnknownUnit, "unit %q", fromUnitCode)
+	}
+	to, ok := unitConversions[toUnitCode]
+	if !ok {
+		return 0, errors.Wrapf(ErrUnknownUnit, "unit %q", toUnitCode)
+	}
+	if from.family != to.family {
+		return 0, errors.Wrapf(ErrIncompatibleUnits, "%s (%s) to %s (%s)", fromUnitCode, from.family, toUnitCode, to.family)
+	}
+	if fromUnitCode == toUnitCode {
+		return value, nil
+	}
+
+	standard := value*from.factor + from.offset
+	return (standard - to.offset) / to.factor, nil
+}
+
+// NormalizeUnit converts the value into the largest metric unit of its family in which the value is at least 1 (e.g. 1500 GRAM into 1.5 KILOGRAM).
+// Values of units that have no normalized units (e.g. imperial units or temperatures) are returned unchanged
+func NormalizeUnit(value float64, unitCode string) (float64, string) {
+	family, ok := GetUnitFamily(unitCode)
+	if !ok || !isNormalizedUnit(family, unitCode) {
+		return value, unitCode
+	}
+
+	units := normalizedUnits[family]
+	normalizedValue, normalizedUnit := value, unitCode
+	for _, candidate := range units {
+		converted, err := ConvertUnit(value, unitCode, candidate)
+		if err != nil {
+			continue
+		}
+		if math.Abs(converted) >= 1 || candidate == units[0] {
+			normalizedValue, normalizedUnit = converted, candidate
+		}
+	}
+	return normalizedValue, normalizedUnit
+}
+
+func isNormalizedUnit(family string, unitCode string) bool {
+	for _, candidate := range normalizedUnits[family] {
+		if candidate == unitCode {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultBaseUnit returns the reference quantity that is used for base prices of products with the given content:
+// 1 kg / 1 l (100 g / 100 ml for contents up to 250 g / 250 ml), 1 m, 1 m² and 1 piece - other families use 1 unit of the content unit
+func DefaultBaseUnit(contentAmount float64, contentUnitCode string) (float64, string) {
+	family, _ := GetUnitFamily(contentUnitCode)
+	switch family {
+	case UnitFamilyWeight:
+		if grams, _ := ConvertUnit(contentAmount, contentUnitCode, GRAM); grams <= 250 {
+			return 100, GRAM
+		}
+		return 1, KILOGRAM
+	case UnitFamilyVolume:
+		if milliliters, _ := ConvertUnit(contentAmount, contentUnitCode, MILLILITER); milliliters <= 250 {
+			return 100, MILLILITER
+		}
+		return 1, LITER
+	case UnitFamilyLength:
+		return 1, METER
+	case UnitFamilyArea:
+		return 1, SQUARE_METER
+	case UnitFamilyPiece:
+		return 1, PIECE
+	}
+	return 1, contentUnitCode
+}
+
+// FloatValue returns the attribute value as number
+func (at Attribute) FloatValue() (float64, error) {
+	switch value := at.RawValue.(type) {
+	case float64:
+		return value, nil
+	case int:
+		return float64(value), nil
+	case int64:
+		return float64(value), nil
+	}
+	return strconv.ParseFloat(strings.TrimSpace(at.Value()), 64)
+}
+
+// ConvertTo returns the value of the attribute in the given unit
+func (at Attribute) ConvertTo(unitCode string) (float64, error) {
+	value, err := at.FloatValue()
+	if err != nil {
+		return 0, err
+	}
+	return ConvertUnit(value, at.UnitCode, unitCode)
+}
+
+// FormatWithUnit returns the value together with the symbol of its unit (e.g. "1.5 kg").
+// Without a unit code the value is normalized into a human readable unit of its family (e.g. 1500 GRAM to 1.5 kg),
+// otherwise it is converted into the given unit. Attributes without a numeric value or unit are returned as they are
+func (at Attribute) FormatWithUnit(unitCode string) string {
+	value, err := at.FloatValue()
+	if err != nil || !at.HasUnitCode() {
+		return strings.TrimSpace(at.Value() + " " + at.GetUnit().Symbol)
+	}
+
+	targetUnit := at.UnitCode
+	if unitCode != "" {
+		converted, err := ConvertUnit(value, at.UnitCode, unitCode)
+		if err == nil {
+			value, targetUnit = converted, unitCode
+		}
+	} else {
+		value, targetUnit = NormalizeUnit(value, at.UnitCode)
+	}
+
+	formatted := strconv.FormatFloat(math.Round(value*1000)/1000, 'f', -1, 64)
+	return strings.TrimSpace(formatted + " " + Attribute{UnitCode: targetUnit}.GetUnit().Symbol)
+}
+
+// BasePrice returns the price per reference quantity (e.g. per 100 g) of the final price.
+// If the backend delivers the base price in ActiveBase (with ActiveBaseAmount and ActiveBaseUnit as reference quantity) it is returned directly.
+// Otherwise it is calculated with the given content attribute (e.g. 250 GRAM) - ActiveBaseAmount and ActiveBaseUnit are used as reference quantity if set,
+// else the DefaultBaseUnit of the content is used
+func (p PriceInfo) BasePrice(content Attribute) (*BasePrice, error) {
+	finalPrice := p.GetFinalPrice()
+	referenceAmount, _ := p.ActiveBaseAmount.Float64()
+	referenceUnit := p.ActiveBaseUnit
+
+	if p.ActiveBase.Sign() != 0 {
+		if referenceAmount == 0 || referenceUnit == "" {
+			return nil, errors.Wrap(ErrNoBasePrice, "ActiveBase without reference quantity")
+		}
+		return &BasePrice{
+			Price:  priceDomain.NewFromBigFloat(p.ActiveBase, finalPrice.Currency()),
+			Amount: referenceAmount,
+			Unit:   Attribute{UnitCode: referenceUnit}.GetUnit(),
+		}, nil
+	}
+
+	contentAmount, err := content.FloatValue()
+	if err != nil || contentAmount <= 0 || !content.HasUnitCode() {
+		return nil, errors.Wrap(ErrNoBasePrice, "invalid content")
+	}
+	if referenceAmount == 0 || referenceUnit == "" {
+		referenceAmount, referenceUnit = DefaultBaseUnit(contentAmount, content.UnitCode)
+	}
+
+	contentInReferenceUnit, err := ConvertUnit(contentAmount, content.UnitCode, referenceUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	factor := new(big.Float).Quo(big.NewFloat(referenceAmount), big.NewFloat(contentInReferenceUnit))
+	amount := new(big.Float).Mul(finalPrice.Amount(), factor)
+	return &BasePrice{
+		Price:  priceDomain.NewFromBigFloat(*amount, finalPrice.Currency()),
+		Amount: referenceAmount,
+		Unit:   Attribute{UnitCode: referenceUnit}.GetUnit(),
+	}, nil
+}
+
+// IsNeeded returns false if the base price equals the price of the product - e.g. for a product with a content of exactly 1 kg
+func (b BasePrice) IsNeeded(finalPrice priceDomain.Price) bool {
+	return !b.Price.LikelyEqual(finalPrice)
+}
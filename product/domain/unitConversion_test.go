@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+)
+
+func TestConvertUnit(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		expected float64
+	}{
+		{value: 1500, from: GRAM, to: KILOGRAM, expected: 1.5},
+		{value: 1, from: POUND, to: GRAM, expected: 453.59237},
+		{value: 33, from: CENTILITER, to: MILLILITER, expected: 330},
+		{value: 1, from: GALLON, to: LITER, expected: 3.785411784},
+		{value: 12, from: INCH, to: CENTIMETER, expected: 30.48},
+		{value: 2, from: GIGABYTE, to: MEGABYTE, expected: 2048},
+		{value: 100, from: CELSIUS, to: FAHRENHEIT, expected: 212},
+		{value: 0, from: CELSIUS, to: KELVIN, expected: 273.15},
+		{value: 2, from: DOZEN, to: PIECE, expected: 24},
+	}
+	for _, tt := range tests {
+		result, err := ConvertUnit(tt.value, tt.from, tt.to)
+		assert.NoError(t, err)
+		assert.InDelta(t, tt.expected, result, 0.000001, "%v %s to %s", tt.value, tt.from, tt.to)
+	}
+
+	_, err := ConvertUnit(1, GRAM, LITER)
+	assert.Equal(t, ErrIncompatibleUnits, errors.Cause(err))
+
+	_, err = ConvertUnit(1, "PARSEC", METER)
+	assert.Equal(t, ErrUnknownUnit, errors.Cause(err))
+}
+
+func TestNormalizeUnit(t *testing.T) {
+	value, unit := NormalizeUnit(1500, GRAM)
+	assert.Equal(t, 1.5, value)
+	assert.Equal(t, KILOGRAM, unit)
+
+	value, unit = NormalizeUnit(0.25, LITER)
+	assert.Equal(t, 250.0, value)
+	assert.Equal(t, MILLILITER, unit)
+
+	value, unit = NormalizeUnit(0.5, MILLIGRAM)
+	assert.Equal(t, 0.5, value)
+	assert.Equal(t, MILLIGRAM, unit, "the smallest unit is kept")
+
+	value, unit = NormalizeUnit(12, POUND)
+	assert.Equal(t, 12.0, value)
+	assert.Equal(t, POUND, unit, "units without normalized units are kept")
+}
+
+func TestAttribute_FloatValue(t *testing.T) {
+	value, err := Attribute{RawValue: "2.5"}.FloatValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, value)
+
+	value, err = Attribute{RawValue: 3}.FloatValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, value)
+
+	_, err = Attribute{RawValue: "red"}.FloatValue()
+	assert.Error(t, err)
+
+	value, err = Attribute{RawValue: 250.0, UnitCode: GRAM}.ConvertTo(KILOGRAM)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, value)
+}
+
+func TestPriceInfo_BasePrice(t *testing.T) {
+	priceInfo := PriceInfo{Default: priceDomain.NewFromFloat(4.98, "EUR")}
+
+	t.Run("small contents are based on 100 g", func(t *testing.T) {
+		basePrice, err := priceInfo.BasePrice(Attribute{RawValue: 250.0, UnitCode: GRAM})
+		assert.NoError(t, err)
+		assert.Equal(t, 1.99, basePrice.Price.GetPayable().FloatAmount())
+		assert.Equal(t, 100.0, basePrice.Amount)
+		assert.Equal(t, "g", basePrice.Unit.Symbol)
+	})
+
+	t.Run("large contents are based on 1 l", func(t *testing.T) {
+		basePrice, err := priceInfo.BasePrice(Attribute{RawValue: "1500", UnitCode: MILLILITER})
+		assert.NoError(t, err)
+		assert.Equal(t, 3.32, basePrice.Price.GetPayable().FloatAmount())
+		assert.Equal(t, 1.0, basePrice.Amount)
+		assert.Equal(t, LITER, basePrice.Unit.Code)
+		assert.True(t, basePrice.IsNeeded(priceInfo.GetFinalPrice()))
+	})
+
+	t.Run("discounted price and configured reference quantity", func(t *testing.T) {
+		priceInfo := priceInfo
+		priceInfo.IsDiscounted = true
+		priceInfo.Discounted = priceDomain.NewFromFloat(3, "EUR")
+		priceInfo.ActiveBaseAmount = *big.NewFloat(1)
+		priceInfo.ActiveBaseUnit = KILOGRAM
+
+		basePrice, err := priceInfo.BasePrice(Attribute{RawValue: 500.0, UnitCode: GRAM})
+		assert.NoError(t, err)
+		assert.Equal(t, 6.0, basePrice.Price.GetPayable().FloatAmount())
+		assert.Equal(t, KILOGRAM, basePrice.Unit.Code)
+	})
+
+	t.Run("base price of the backend", func(t *testing.T) {
+		priceInfo := priceInfo
+		priceInfo.ActiveBase = *big.NewFloat(0.99)
+		priceInfo.ActiveBaseAmount = *big.NewFloat(100)
+		priceInfo.ActiveBaseUnit = MILLILITER
+
+		basePrice, err := priceInfo.BasePrice(Attribute{})
+		assert.NoError(t, err)
+		assert.Equal(t, 0.99, basePrice.Price.FloatAmount())
+		assert.Equal(t, "EUR", basePrice.Price.Currency())
+		assert.Equal(t, 100.0, basePrice.Amount)
+	})
+
+	t.Run("no base price", func(t *testing.T) {
+		_, err := priceInfo.BasePrice(Attribute{RawValue: "250"})
+		assert.Equal(t, ErrNoBasePrice, errors.Cause(err))
+
+		_, err = priceInfo.BasePrice(Attribute{RawValue: "0", UnitCode: GRAM})
+		assert.Equal(t, ErrNoBasePrice, errors.Cause(err))
+	})
+}
@@ -400,7 +400,7 @@ var Units = map[string]Unit{
 	},
 	KILOWATT: {
 		Code:   KILOWATT,
-		Symbol: "mW",
+		Symbol: "kW",
 	},
 	MEGAWATT: {
 		Code:   MEGAWATT,
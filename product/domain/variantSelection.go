@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+)
+
+type (
+	// VariantSelection is the matrix of the variation attributes, their options and the variants of a configurable
+	VariantSelection struct {
+		Attributes []VariantSelectionAttribute
+		Variants   []VariantSelectionVariant
+		// PreselectedVariant is the marketplace code of the active variant. If no variant is active it is the available variant
+		// preselected by the teaser or the cheapest available variant - empty if no variant is available
+		PreselectedVariant string
+		// PriceRange of the saleable variants
+		PriceRange PriceRange
+	}
+
+	// VariantSelectionAttribute is a variation attribute (e.g. color) with its options
+	VariantSelectionAttribute struct {
+		Key     string
+		Title   string
+		Options []VariantSelectionOption
+	}
+
+	// VariantSelectionOption is a value of a variation attribute (e.g. red)
+	VariantSelectionOption struct {
+		Key   string
+		Title string
+		// Combinations contains the values of the other variation attributes that exist in combination with this option
+		Combinations map[string][]string
+		// AvailableCombinations is like Combinations but only for variants that are in stock and saleable
+		AvailableCombinations map[string][]string
+		// Selected is set if the option belongs to the active variant
+		Selected bool
+		// Preselected is set if the option belongs to the preselected variant
+		Preselected bool
+		// InStock is set if at least one variant with this option is in stock
+		InStock bool
+		// Saleable is set if at least one variant with this option is saleable
+		Saleable bool
+		// PriceRange of the saleable variants with this option
+		PriceRange PriceRange
+	}
+
+	// VariantSelectionVariant is a variant with the values of its variation attributes
+	VariantSelectionVariant struct {
+		Attributes      map[string]string
+		Marketplacecode string
+		Title           string
+		// URL is set by the application.VariantSelectionService
+		URL      string
+		InStock  bool
+		Saleable bool
+		Price    priceDomain.Price
+	}
+
+	// PriceRange is the minimum and maximum of a set of prices - both are zero if there are no prices
+	PriceRange struct {
+		Min priceDomain.Price
+		Max priceDomain.Price
+	}
+)
+
+// IsRange returns true if the minimum and the maximum differ (e.g. to render "from" prices)
+func (r PriceRange) IsRange() bool {
+	return !r.Min.Equal(r.Max)
+}
+
+// IsEmpty returns true if the range does not contain a price
+func (r PriceRange) IsEmpty() bool {
+	return r.Min.Currency() == "" && r.Min.IsZero() && r.Max.IsZero()
+}
+
+// extend the range by the price
+func (r PriceRange) extend(price priceDomain.Price) PriceRange {
+	if r.IsEmpty() {
+		return PriceRange{Min: price, Max: price}
+	}
+	if price.IsLessThen(r.Min) {
+		r.Min = price
+	}
+	if price.IsGreaterThen(r.Max) {
+		r.Max = price
+	}
+	return r
+}
+
+// IsAvailable checks if the variant is in stock and saleable
+func (v VariantSelectionVariant) IsAvailable() bool {
+	return v.InStock && v.Saleable
+}
+
+// Variant returns the variant with the marketplace code
+func (s VariantSelection) Variant(marketplaceCode string) (*VariantSelectionVariant, bool) {
+	for _, variant := range s.Variants {
+		if variant.Marketplacecode == marketplaceCode {
+			return &variant, true
+		}
+	}
+	return nil, false
+}
+
+// NewVariantSelection builds the variant selection of the configurable, the visibility and saleable windows are checked for the given time.
+// Variants that are not visible are left out. The activeVariantCode is optional - see VariantSelection.PreselectedVariant for the preselection without active variant
+func NewVariantSelection(configurable ConfigurableProduct, activeVariantCode string, at time.Time) VariantSelection {
+	selection := VariantSelection{}
+	configurable.Variants = visibleVariants(configurable.Variants, at)
+
+	for _, variant := range configurable.Variants {
+		attributes := make(map[string]string)
+		for _, code := range configurable.VariantVariationAttributes {
+			if variant.HasAttribute(code) {
+				attributes[code] = variant.Attributes[code].Value()
+			}
+		}
+
+		selectionVariant := VariantSelectionVariant{
+			Attributes:      attributes,
+			Marketplacecode: variant.MarketPlaceCode,
+			Title:           variant.Title,
+			InStock:         variant.IsInStock(),
+			Saleable:        variant.Saleable.IsSaleableAt(at),
+			Price:           variant.ActivePrice.GetFinalPrice(),
+		}
+		if selectionVariant.Saleable {
+			selection.PriceRange = selection.PriceRange.extend(selectionVariant.Price)
+		}
+		selection.Variants = append(selection.Variants, selectionVariant)
+	}
+
+	selection.PreselectedVariant = preselectVariant(selection, activeVariantCode, configurable.Teaser.PreSelectedVariantSku)
+	activeAttributes := variantAttributes(selection, activeVariantCode)
+	preselectedAttributes := variantAttributes(selection, selection.PreselectedVariant)
+
+	for _, code := range configurable.VariantVariationAttributes {
+		attribute := VariantSelectionAttribute{
+			Key:   code,
+			Title: strings.Title(code),
+		}
+
+		for _, optionCode := range optionOrder(configurable, code) {
+			option := VariantSelectionOption{
+				Key:                   optionCode,
+				Title:                 optionTitle(configurable, code, optionCode),
+				Combinations:          make(map[string][]string),
+				AvailableCombinations: make(map[string][]string),
+				Selected:              activeAttributes != nil && activeAttributes[code] == optionCode,
+				Preselected:           preselectedAttributes != nil && preselectedAttributes[code] == optionCode,
+			}
+
+			for _, variant := range selection.Variants {
+				if value, ok := variant.Attributes[code]; !ok || value != optionCode {
+					continue
+				}
+				option.InStock = option.InStock || variant.InStock
+				option.Saleable = option.Saleable || variant.Saleable
+				if variant.Saleable {
+					option.PriceRange = option.PriceRange.extend(variant.Price)
+				}
+
+				for _, otherCode := range configurable.VariantVariationAttributes {
+					otherValue, ok := variant.Attributes[otherCode]
+					if otherCode == code || !ok {
+						continue
+					}
+					option.Combinations[otherCode] = appendUnique(option.Combinations[otherCode], otherValue)
+					if variant.IsAvailable() {
+						option.AvailableCombinations[otherCode] = appendUnique(option.AvailableCombinations[otherCode], otherValue)
+					}
+				}
+			}
+
+			attribute.Options = append(attribute.Options, option)
+		}
+
+		selection.Attributes = append(selection.Attributes, attribute)
+	}
+
+	return selection
+}
+
+// visibleVariants returns the variants within their visibility window
+func visibleVariants(variants []Variant, at time.Time) []Variant {
+	visible := make([]Variant, 0, len(variants))
+	for _, variant := range variants {
+		if variant.IsVisibleAt(at) {
+			visible = append(visible, variant)
+		}
+	}
+	return visible
+}
+
+// preselectVariant returns the active variant if it exists - otherwise the teasered or the cheapest available variant
+func preselectVariant(selection VariantSelection, activeVariantCode string, teaserVariantCode string) string {
+	if _, ok := selection.Variant(activeVariantCode); ok && activeVariantCode != "" {
+		return activeVariantCode
+	}
+	if variant, ok := selection.Variant(teaserVariantCode); ok && teaserVariantCode != "" && variant.IsAvailable() {
+		return teaserVariantCode
+	}
+
+	var cheapest *VariantSelectionVariant
+	for i, variant := range selection.Variants {
+		if !variant.IsAvailable() {
+			continue
+		}
+		if cheapest == nil || variant.Price.IsLessThen(cheapest.Price) {
+			cheapest = &selection.Variants[i]
+		}
+	}
+	if cheapest == nil {
+		return ""
+	}
+	return cheapest.Marketplacecode
+}
+
+// variantAttributes returns the variation attribute values of the variant or nil if the variant does not exist
+func variantAttributes(selection VariantSelection, marketplaceCode string) map[string]string {
+	if marketplaceCode == "" {
+		return nil
+	}
+	variant, ok := selection.Variant(marketplaceCode)
+	if !ok {
+		return nil
+	}
+	return variant.Attributes
+}
+
+// optionOrder returns the values of the attribute - first in the configured sorting, then in the order of the variants
+func optionOrder(configurable ConfigurableProduct, code string) []string {
+	existing := make(map[string]bool)
+	var variantOrder []string
+	for _, variant := range configurable.Variants {
+		if !variant.HasAttribute(code) {
+			continue
+		}
+		value := variant.Attributes[code].Value()
+		if !existing[value] {
+			existing[value] = true
+			variantOrder = append(variantOrder, value)
+		}
+	}
+
+	var result []string
+	for _, value := range append(append([]string{}, configurable.VariantVariationAttributesSorting[code]...), variantOrder...) {
+		if existing[value] {
+			result = appendUnique(result, value)
+		}
+	}
+	return result
+}
+
+// optionTitle returns the label of the attribute value - the value is used if no variant has a label
+func optionTitle(configurable ConfigurableProduct, code string, value string) string {
+	for _, variant := range configurable.Variants {
+		if attribute, ok := variant.Attributes[code]; ok && attribute.Value() == value && attribute.Label != "" {
+			return attribute.Label
+		}
+	}
+	return strings.Title(value)
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+)
+
+func selectionVariant(code string, color string, size string, price float64, inStock bool, saleable bool) Variant {
+	return Variant{
+		BasicProductData: BasicProductData{
+			MarketPlaceCode: code,
+			Title:           code,
+			StockLevel:      map[bool]string{true: "high", false: "out"}[inStock],
+			Attributes: Attributes{
+				"color": Attribute{Code: "color", RawValue: color, Label: "Color " + color},
+				"size":  Attribute{Code: "size", RawValue: size},
+			},
+		},
+		Saleable: Saleable{
+			IsSaleable:  saleable,
+			ActivePrice: PriceInfo{Default: priceDomain.NewFromFloat(price, "EUR")},
+		},
+	}
+}
+
+func TestNewVariantSelection(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	configurable := ConfigurableProduct{
+		BasicProductData:           BasicProductData{MarketPlaceCode: "shirt"},
+		VariantVariationAttributes: []string{"color", "size"},
+		VariantVariationAttributesSorting: map[string][]string{
+			"size": {"S", "M", "L"},
+		},
+		Variants: []Variant{
+			selectionVariant("red-l", "red", "L", 30, true, true),
+			selectionVariant("red-m", "red", "M", 20, false, true),
+			selectionVariant("blue-m", "blue", "M", 25, true, true),
+			selectionVariant("blue-s", "blue", "S", 10, true, false),
+		},
+	}
+
+	selection := NewVariantSelection(configurable, "", now)
+
+	assert.Equal(t, "blue-m", selection.PreselectedVariant, "the cheapest variant that is in stock and saleable is preselected")
+	assert.True(t, selection.PriceRange.Min.Equal(priceDomain.NewFromFloat(20, "EUR")), "only saleable variants are part of the price range")
+	assert.True(t, selection.PriceRange.Max.Equal(priceDomain.NewFromFloat(30, "EUR")))
+	assert.True(t, selection.PriceRange.IsRange())
+
+	if !assert.Len(t, selection.Attributes, 2) {
+		return
+	}
+	color := selection.Attributes[0]
+	assert.Equal(t, "Color", color.Title)
+	if assert.Len(t, color.Options, 2) {
+		red := color.Options[0]
+		assert.Equal(t, "red", red.Key)
+		assert.Equal(t, "Color red", red.Title)
+		assert.Equal(t, []string{"L", "M"}, red.Combinations["size"])
+		assert.Equal(t, []string{"L"}, red.AvailableCombinations["size"], "red-m is out of stock")
+		assert.True(t, red.InStock)
+		assert.False(t, red.Selected)
+		assert.False(t, red.Preselected)
+		assert.True(t, color.Options[1].Preselected)
+	}
+
+	size := selection.Attributes[1]
+	if assert.Len(t, size.Options, 3) {
+		assert.Equal(t, []string{"S", "M", "L"}, []string{size.Options[0].Key, size.Options[1].Key, size.Options[2].Key}, "the configured sorting is used")
+		small := size.Options[0]
+		assert.True(t, small.InStock)
+		assert.False(t, small.Saleable)
+		assert.True(t, small.PriceRange.IsEmpty())
+		assert.Empty(t, small.AvailableCombinations)
+	}
+
+	selection = NewVariantSelection(configurable, "red-l", now)
+	assert.Equal(t, "red-l", selection.PreselectedVariant)
+	assert.True(t, selection.Attributes[0].Options[0].Selected)
+	assert.True(t, selection.Attributes[1].Options[2].Selected)
+
+	configurable.Teaser.PreSelectedVariantSku = "red-l"
+	assert.Equal(t, "red-l", NewVariantSelection(configurable, "", now).PreselectedVariant, "the teasered variant is preferred")
+
+	configurable.Teaser.PreSelectedVariantSku = ""
+	configurable.Variants[0].SaleableTo = now.Add(-time.Hour)
+	configurable.Variants[2].SaleableTo = now.Add(-time.Hour)
+	selection = NewVariantSelection(configurable, "", now)
+	assert.Equal(t, "", selection.PreselectedVariant, "no variant is available anymore")
+	assert.False(t, selection.PriceRange.IsRange())
+}
+
+func TestNewVariantSelection_InvisibleVariant(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	expired := selectionVariant("green-s", "green", "S", 5, true, true)
+	expired.VisibleTo = now.Add(-time.Hour)
+	configurable := ConfigurableProduct{
+		BasicProductData:           BasicProductData{MarketPlaceCode: "shirt"},
+		VariantVariationAttributes: []string{"color", "size"},
+		Variants: []Variant{
+			selectionVariant("red-m", "red", "M", 20, true, true),
+			selectionVariant("blue-m", "blue", "M", 25, true, true),
+			expired,
+		},
+	}
+
+	selection := NewVariantSelection(configurable, "", now)
+
+	assert.Len(t, selection.Variants, 2)
+	_, ok := selection.Variant("green-s")
+	assert.False(t, ok, "the expired variant is not part of the selection")
+	assert.Equal(t, "red-m", selection.PreselectedVariant, "the expired variant is not preselected although it is the cheapest")
+	assert.True(t, selection.PriceRange.Min.Equal(priceDomain.NewFromFloat(20, "EUR")), "the expired variant is not part of the price range")
+	if assert.Len(t, selection.Attributes, 2) {
+		for _, option := range selection.Attributes[0].Options {
+			assert.NotEqual(t, "green", option.Key)
+		}
+		assert.Len(t, selection.Attributes[1].Options, 1, "only the size of the visible variants is an option")
+	}
+
+	assert.Equal(t, "red-m", NewVariantSelection(configurable, "green-s", now).PreselectedVariant, "an invisible active variant is not preselected")
+}
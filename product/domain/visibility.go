@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"time"
+)
+
+type (
+	// Clock provides the current time for visibility and saleable checks - bind an own implementation to preview scheduled launches
+	Clock interface {
+		Now() time.Time
+	}
+
+	// SystemClock returns the current system time
+	SystemClock struct{}
+
+	// FixedClock always returns the same time (useful for tests and previews)
+	FixedClock struct {
+		Time time.Time
+	}
+)
+
+var (
+	_ Clock = SystemClock{}
+	_ Clock = FixedClock{}
+)
+
+// Now returns time.Now()
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Now returns the fixed time
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
+
+// isUnsetTime checks for zero times - some adapters deliver the unix epoch instead of the zero time
+func isUnsetTime(t time.Time) bool {
+	return t.IsZero() || t.Year() == 1970
+}
+
+// isInTimeWindow checks if t is within the (optional) window from - to
+func isInTimeWindow(t, from, to time.Time) bool {
+	return (isUnsetTime(from) || from.Before(t)) && (isUnsetTime(to) || to.After(t))
+}
+
+// IsVisibleAt checks the VisibleFrom and VisibleTo window - unset bounds are open
+func (bpd BasicProductData) IsVisibleAt(t time.Time) bool {
+	return isInTimeWindow(t, bpd.VisibleFrom, bpd.VisibleTo)
+}
+
+// IsVisibleNow checks the visibility window against the current time
+func (bpd BasicProductData) IsVisibleNow() bool {
+	return bpd.IsVisibleAt(time.Now())
+}
+
+// IsProductVisibleAt checks the visibility window of the product - for configurables with an active variant
+// the configurable and the variant need to be visible
+func IsProductVisibleAt(product BasicProduct, t time.Time) bool {
+	if product == nil {
+		return false
+	}
+	if withActiveVariant, ok := product.(ConfigurableProductWithActiveVariant); ok && !withActiveVariant.ConfigurableBaseData().IsVisibleAt(t) {
+		return false
+	}
+	return product.BaseData().IsVisibleAt(t)
+}
+
+// IsProductSaleableAt checks the saleable flag and window of the product - for configurables the active variant is checked
+func IsProductSaleableAt(product BasicProduct, t time.Time) bool {
+	if product == nil || !product.IsSaleable() {
+		return false
+	}
+	return product.SaleableData().IsSaleableAt(t)
+}
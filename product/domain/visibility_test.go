@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicProductData_IsVisibleAt(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, BasicProductData{}.IsVisibleAt(now), "visible if no window is set")
+	assert.True(t, BasicProductData{VisibleFrom: time.Unix(0, 0)}.IsVisibleAt(now), "the unix epoch counts as unset")
+	assert.False(t, BasicProductData{VisibleFrom: now.Add(time.Hour)}.IsVisibleAt(now), "not visible before the launch")
+	assert.True(t, BasicProductData{VisibleFrom: now.Add(-time.Hour)}.IsVisibleAt(now))
+	assert.False(t, BasicProductData{VisibleTo: now.Add(-time.Hour)}.IsVisibleAt(now), "not visible after the window")
+	assert.True(t, BasicProductData{VisibleFrom: now.Add(-time.Hour), VisibleTo: now.Add(time.Hour)}.IsVisibleAt(now))
+}
+
+func TestIsProductVisibleAt(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	configurable := ConfigurableProduct{
+		BasicProductData: BasicProductData{MarketPlaceCode: "shirt"},
+		Variants: []Variant{
+			{BasicProductData: BasicProductData{MarketPlaceCode: "shirt-red"}},
+			{BasicProductData: BasicProductData{MarketPlaceCode: "shirt-blue", VisibleFrom: now.Add(time.Hour)}},
+		},
+	}
+
+	assert.False(t, IsProductVisibleAt(nil, now))
+	assert.True(t, IsProductVisibleAt(configurable, now))
+
+	red, _ := configurable.GetConfigurableWithActiveVariant("shirt-red")
+	assert.True(t, IsProductVisibleAt(red, now))
+	blue, _ := configurable.GetConfigurableWithActiveVariant("shirt-blue")
+	assert.False(t, IsProductVisibleAt(blue, now), "the active variant is not visible yet")
+
+	configurable.VisibleTo = now.Add(-time.Hour)
+	red, _ = configurable.GetConfigurableWithActiveVariant("shirt-red")
+	assert.False(t, IsProductVisibleAt(red, now), "the configurable is not visible anymore")
+}
+
+func TestIsProductSaleableAt(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	simple := SimpleProduct{Saleable: Saleable{IsSaleable: true, SaleableTo: now.Add(time.Hour)}}
+
+	assert.True(t, IsProductSaleableAt(simple, now))
+	assert.False(t, IsProductSaleableAt(simple, now.Add(2*time.Hour)), "the saleable window is closed")
+	assert.False(t, IsProductSaleableAt(SimpleProduct{}, now), "the saleable flag is not set")
+	assert.False(t, IsProductSaleableAt(ConfigurableProduct{}, now), "configurables without active variant are not saleable")
+	assert.True(t, FixedClock{Time: now}.Now().Equal(now))
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// CachingProductService is a dingo interceptor that caches the results of the bound ProductService in the ProductCache.
+	// Concurrent requests for the same uncached products result in one call of the intercepted ProductService
+	CachingProductService struct {
+		domain.ProductService
+		cache *ProductCache
+	}
+)
+
+var (
+	_ domain.ProductService      = (*CachingProductService)(nil)
+	_ domain.BatchProductService = (*CachingProductService)(nil)
+)
+
+// Inject dependencies
+func (s *CachingProductService) Inject(cache *ProductCache) {
+	s.cache = cache
+}
+
+// Get returns the cached product or loads it from the intercepted ProductService
+func (s *CachingProductService) Get(ctx context.Context, marketplaceCode string) (domain.BasicProduct, error) {
+	if product, ok := s.cache.get(marketplaceCode); ok {
+		if product == nil {
+			return nil, domain.ProductNotFound{MarketplaceCode: marketplaceCode}
+		}
+		return product, nil
+	}
+
+	result, err, _ := s.cache.loader.Do(marketplaceCode, func() (interface{}, error) {
+		product, err := s.ProductService.Get(ctx, marketplaceCode)
+		switch err.(type) {
+		case nil:
+			s.cache.set(marketplaceCode, product)
+		case domain.ProductNotFound, *domain.ProductNotFound:
+			s.cache.set(marketplaceCode, nil)
+		}
+		return product, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	product, _ := result.(domain.BasicProduct)
+	return product, nil
+}
+
+// GetMany returns the cached products and loads the missing ones with one domain.GetMany call on the intercepted ProductService
+func (s *CachingProductService) GetMany(ctx context.Context, marketplaceCodes ...string) (map[string]domain.BasicProduct, error) {
+	products := make(map[string]domain.BasicProduct, len(marketplaceCodes))
+	var missing []string
+	for _, marketplaceCode := range marketplaceCodes {
+		product, ok := s.cache.get(marketplaceCode)
+		if !ok {
+			missing = append(missing, marketplaceCode)
+			continue
+		}
+		if product != nil {
+			products[marketplaceCode] = product
+		}
+	}
+	if len(missing) == 0 {
+		return products, nil
+	}
+
+	sort.Strings(missing)
+	type loadResult struct {
+		products map[string]domain.BasicProduct
+		err      error
+	}
+	result, _, _ := s.cache.loader.Do("batch:"+strings.Join(missing, ","), func() (interface{}, error) {
+		loaded, err := domain.GetMany(ctx, s.ProductService, missing...)
+		var failed map[string]error
+		if batchErr, ok := err.(*domain.BatchGetError); ok {
+			failed = batchErr.Errors
+		} else if err != nil {
+			return loadResult{products: loaded, err: err}, nil
+		}
+
+		for _, marketplaceCode := range missing {
+			if product, found := loaded[marketplaceCode]; found {
+				s.cache.set(marketplaceCode, product)
+			} else if _, hasError := failed[marketplaceCode]; !hasError {
+				s.cache.set(marketplaceCode, nil)
+			}
+		}
+		return loadResult{products: loaded, err: err}, nil
+	})
+
+	loaded := result.(loadResult)
+	for marketplaceCode, product := range loaded.products {
+		products[marketplaceCode] = product
+	}
+	return products, loaded.err
+}
@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type productServiceStub struct {
+	mutex   sync.Mutex
+	calls   map[string]int
+	release chan struct{}
+}
+
+func (s *productServiceStub) Get(ctx context.Context, marketplaceCode string) (domain.BasicProduct, error) {
+	s.mutex.Lock()
+	if s.calls == nil {
+		s.calls = make(map[string]int)
+	}
+	s.calls[marketplaceCode]++
+	s.mutex.Unlock()
+
+	if s.release != nil {
+		<-s.release
+	}
+
+	switch marketplaceCode {
+	case "unknown":
+		return nil, domain.ProductNotFound{MarketplaceCode: marketplaceCode}
+	case "broken":
+		return nil, errors.New("backend error")
+	}
+	return domain.SimpleProduct{BasicProductData: domain.BasicProductData{MarketPlaceCode: marketplaceCode}}, nil
+}
+
+func (s *productServiceStub) callCount(marketplaceCode string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls[marketplaceCode]
+}
+
+func newCachingProductService(size float64, ttl, notFoundTTL string) (*CachingProductService, *productServiceStub, *time.Time) {
+	cache := new(ProductCache)
+	cache.Inject(flamingo.NullLogger{}, &struct {
+		Size        float64 `inject:"config:commerce.product.cache.size,optional"`
+		TTL         string  `inject:"config:commerce.product.cache.ttl,optional"`
+		NotFoundTTL string  `inject:"config:commerce.product.cache.notFoundTTL,optional"`
+	}{Size: size, TTL: ttl, NotFoundTTL: notFoundTTL})
+
+	now := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time {
+		return now
+	}
+
+	stub := new(productServiceStub)
+	service := &CachingProductService{ProductService: stub}
+	service.Inject(cache)
+	return service, stub, &now
+}
+
+func TestCachingProductService_Get(t *testing.T) {
+	t.Run("products are cached until the ttl expires", func(t *testing.T) {
+		service, stub, now := newCachingProductService(10, "1m", "")
+
+		for i := 0; i < 3; i++ {
+			product, err := service.Get(context.Background(), "a")
+			assert.NoError(t, err)
+			assert.Equal(t, "a", product.BaseData().MarketPlaceCode)
+		}
+		assert.Equal(t, 1, stub.callCount("a"))
+
+		*now = now.Add(time.Minute)
+		_, err := service.Get(context.Background(), "a")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, stub.callCount("a"))
+	})
+
+	t.Run("not found is only cached with notFoundTTL", func(t *testing.T) {
+		service, stub, _ := newCachingProductService(10, "1m", "")
+		_, err := service.Get(context.Background(), "unknown")
+		assert.Equal(t, domain.ProductNotFound{MarketplaceCode: "unknown"}, err)
+		_, err = service.Get(context.Background(), "unknown")
+		assert.Equal(t, domain.ProductNotFound{MarketplaceCode: "unknown"}, err)
+		assert.Equal(t, 2, stub.callCount("unknown"))
+
+		service, stub, _ = newCachingProductService(10, "1m", "10s")
+		_, _ = service.Get(context.Background(), "unknown")
+		_, err = service.Get(context.Background(), "unknown")
+		assert.Equal(t, domain.ProductNotFound{MarketplaceCode: "unknown"}, err)
+		assert.Equal(t, 1, stub.callCount("unknown"))
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		service, stub, _ := newCachingProductService(10, "1m", "1m")
+		_, err := service.Get(context.Background(), "broken")
+		assert.Error(t, err)
+		_, err = service.Get(context.Background(), "broken")
+		assert.Error(t, err)
+		assert.Equal(t, 2, stub.callCount("broken"))
+	})
+
+	t.Run("least recently used products are evicted", func(t *testing.T) {
+		service, stub, _ := newCachingProductService(2, "1m", "")
+		_, _ = service.Get(context.Background(), "a")
+		_, _ = service.Get(context.Background(), "b")
+		_, _ = service.Get(context.Background(), "a")
+		_, _ = service.Get(context.Background(), "c")
+		assert.Equal(t, 2, service.cache.Len())
+
+		_, _ = service.Get(context.Background(), "a")
+		_, _ = service.Get(context.Background(), "b")
+		assert.Equal(t, 1, stub.callCount("a"))
+		assert.Equal(t, 2, stub.callCount("b"))
+	})
+
+	t.Run("concurrent requests are loaded once", func(t *testing.T) {
+		service, stub, _ := newCachingProductService(10, "1m", "")
+		stub.release = make(chan struct{})
+
+		var waitGroup sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				product, err := service.Get(context.Background(), "a")
+				assert.NoError(t, err)
+				assert.NotNil(t, product)
+			}()
+		}
+		for stub.callCount("a") == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(stub.release)
+		waitGroup.Wait()
+
+		assert.Equal(t, 1, stub.callCount("a"))
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		service, stub, _ := newCachingProductService(10, "1m", "")
+		_, _ = service.Get(context.Background(), "a")
+		service.cache.Invalidate("a")
+		_, _ = service.Get(context.Background(), "a")
+		service.cache.Flush()
+		_, _ = service.Get(context.Background(), "a")
+		assert.Equal(t, 3, stub.callCount("a"))
+	})
+}
+
+func TestCachingProductService_GetMany(t *testing.T) {
+	service, stub, _ := newCachingProductService(10, "1m", "1m")
+	_, _ = service.Get(context.Background(), "a")
+
+	products, err := service.GetMany(context.Background(), "a", "b", "unknown", "broken")
+	assert.IsType(t, &domain.BatchGetError{}, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, 1, stub.callCount("a"))
+
+	products, err = service.GetMany(context.Background(), "a", "b", "unknown")
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, 1, stub.callCount("b"))
+	assert.Equal(t, 1, stub.callCount("unknown"))
+
+	products, err = domain.GetMany(context.Background(), service, "b", "c")
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, 1, stub.callCount("b"), "domain.GetMany should use the cache")
+}
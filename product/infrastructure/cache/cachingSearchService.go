@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"context"
+	"strings"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	searchCache "flamingo.me/flamingo-commerce/v3/search/infrastructure/cache"
+)
+
+type (
+	// CachingSearchService is a dingo interceptor that caches the results of the bound product SearchService in the SearchCache of the search module.
+	// The results are tagged with their products and categories and the categories of the filters
+	CachingSearchService struct {
+		domain.SearchService
+		cache *searchCache.SearchCache
+	}
+
+	// ProductDocumentTagger returns the product and category tags of product documents for the SearchCache
+	ProductDocumentTagger struct{}
+)
+
+// CacheNameProductSearch is the cache name of the product search results in the metrics
+const CacheNameProductSearch = "productsearch"
+
+var (
+	_ domain.SearchService        = (*CachingSearchService)(nil)
+	_ searchDomain.DocumentTagger = (*ProductDocumentTagger)(nil)
+)
+
+// Inject dependencies
+func (s *CachingSearchService) Inject(cache *searchCache.SearchCache) {
+	s.cache = cache
+}
+
+// Search returns the cached result or searches it with the intercepted SearchService
+func (s *CachingSearchService) Search(ctx context.Context, filter ...searchDomain.Filter) (*domain.SearchResult, error) {
+	return s.load(ctx, s.cache.Key("search", filter), filter, func(ctx context.Context) (*domain.SearchResult, error) {
+		return s.SearchService.Search(ctx, filter...)
+	})
+}
+
+// SearchBy returns the cached result or searches it with the intercepted SearchService
+func (s *CachingSearchService) SearchBy(ctx context.Context, attribute string, values []string, filter ...searchDomain.Filter) (*domain.SearchResult, error) {
+	filters := append([]searchDomain.Filter{searchDomain.NewKeyValueFilter(attribute, values)}, filter...)
+	return s.load(ctx, s.cache.Key("searchBy:"+attribute, filters), filters, func(ctx context.Context) (*domain.SearchResult, error) {
+		return s.SearchService.SearchBy(ctx, attribute, values, filter...)
+	})
+}
+
+func (s *CachingSearchService) load(ctx context.Context, key string, filters []searchDomain.Filter, search func(ctx context.Context) (*domain.SearchResult, error)) (*domain.SearchResult, error) {
+	value, err := s.cache.Load(ctx, CacheNameProductSearch, key, func(ctx context.Context) (interface{}, []string, error) {
+		result, err := search(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tags := searchCache.FilterTags(filters)
+		for _, product := range result.Hits {
+			tags = append(tags, productTags(product)...)
+		}
+		return *result, tags, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := value.(domain.SearchResult)
+	return &result, nil
+}
+
+// Tags returns the product and category tags of products
+func (ProductDocumentTagger) Tags(document searchDomain.Document) []string {
+	product, ok := document.(domain.BasicProduct)
+	if !ok {
+		return nil
+	}
+	return productTags(product)
+}
+
+// productTags returns the tags of the product, of its variants and of its categories including their parent categories
+func productTags(product domain.BasicProduct) []string {
+	if product == nil {
+		return nil
+	}
+
+	data := product.BaseData()
+	tags := []string{searchCache.TagProduct(data.MarketPlaceCode)}
+	var variants []domain.Variant
+	switch configurable := product.(type) {
+	case domain.ConfigurableProduct:
+		variants = configurable.Variants
+	case domain.ConfigurableProductWithActiveVariant:
+		variants = configurable.Variants
+	}
+	for _, variant := range variants {
+		tags = append(tags, searchCache.TagProduct(variant.MarketPlaceCode))
+	}
+
+	for _, category := range append([]domain.CategoryTeaser{data.MainCategory}, data.Categories...) {
+		if category.Code != "" {
+			tags = append(tags, searchCache.TagCategory(category.Code))
+		}
+		for _, parent := range strings.Split(category.Path, "/") {
+			if parent != "" && parent != category.Code {
+				tags = append(tags, searchCache.TagCategory(parent))
+			}
+		}
+	}
+	return tags
+}
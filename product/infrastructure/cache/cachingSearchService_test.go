@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	searchCache "flamingo.me/flamingo-commerce/v3/search/infrastructure/cache"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type productSearchServiceStub struct {
+	calls int
+}
+
+func (s *productSearchServiceStub) Search(context.Context, ...searchDomain.Filter) (*domain.SearchResult, error) {
+	s.calls++
+	return &domain.SearchResult{Hits: []domain.BasicProduct{
+		domain.SimpleProduct{BasicProductData: domain.BasicProductData{
+			MarketPlaceCode: "shirt",
+			MainCategory:    domain.CategoryTeaser{Code: "shirts", Path: "clothing/shirts"},
+		}},
+		domain.ConfigurableProduct{
+			BasicProductData: domain.BasicProductData{MarketPlaceCode: "shoe"},
+			Variants:         []domain.Variant{{BasicProductData: domain.BasicProductData{MarketPlaceCode: "shoe-42"}}},
+		},
+	}}, nil
+}
+
+func (s *productSearchServiceStub) SearchBy(ctx context.Context, _ string, _ []string, filter ...searchDomain.Filter) (*domain.SearchResult, error) {
+	return s.Search(ctx, filter...)
+}
+
+func TestCachingSearchService(t *testing.T) {
+	cache := new(searchCache.SearchCache)
+	cache.Inject(flamingo.NullLogger{}, nil, nil)
+	stub := new(productSearchServiceStub)
+	service := &CachingSearchService{SearchService: stub}
+	service.Inject(cache)
+
+	for i := 0; i < 2; i++ {
+		result, err := service.Search(context.Background(), searchDomain.NewQueryFilter("shirt"))
+		assert.NoError(t, err)
+		assert.Len(t, result.Hits, 2)
+		_, err = service.SearchBy(context.Background(), "brand", []string{"acme"}, searchDomain.NewQueryFilter("shirt"))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, stub.calls)
+
+	cache.InvalidateCategories("clothing")
+	assert.Equal(t, 0, cache.Len(), "the results are tagged with the parent categories of the products")
+
+	_, _ = service.Search(context.Background())
+	cache.InvalidateProducts("shoe-42")
+	assert.Equal(t, 0, cache.Len(), "the results are tagged with the variants of the products")
+}
+
+func TestProductDocumentTagger_Tags(t *testing.T) {
+	tags := ProductDocumentTagger{}.Tags(domain.SimpleProduct{BasicProductData: domain.BasicProductData{
+		MarketPlaceCode: "shirt",
+		Categories:      []domain.CategoryTeaser{{Code: "shirts", Path: "clothing/shirts"}},
+	}})
+	assert.Equal(t, []string{"product:shirt", "category:shirts", "category:clothing"}, tags)
+	assert.Nil(t, ProductDocumentTagger{}.Tags("no product"))
+}
@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"golang.org/x/sync/singleflight"
+)
+
+type (
+	// ProductCache is the shared storage of the CachingProductService - a size limited LRU cache with time to live.
+	// It needs to be bound as singleton
+	ProductCache struct {
+		mutex       sync.Mutex
+		entries     map[string]*list.Element
+		lru         *list.List
+		size        int
+		ttl         time.Duration
+		notFoundTTL time.Duration
+		loader      singleflight.Group
+		now         func() time.Time
+	}
+
+	cacheEntry struct {
+		marketplaceCode string
+		product         domain.BasicProduct
+		expiresAt       time.Time
+	}
+)
+
+const (
+	defaultSize = 1000
+	defaultTTL  = 5 * time.Minute
+)
+
+// Inject dependencies
+func (c *ProductCache) Inject(
+	logger flamingo.Logger,
+	config *struct {
+		Size        float64 `inject:"config:commerce.product.cache.size,optional"`
+		TTL         string  `inject:"config:commerce.product.cache.ttl,optional"`
+		NotFoundTTL string  `inject:"config:commerce.product.cache.notFoundTTL,optional"`
+	},
+) {
+	c.init()
+	if config == nil {
+		return
+	}
+
+	logger = logger.WithField(flamingo.LogKeyCategory, "productcache").WithField(flamingo.LogKeyModule, "product")
+	if config.Size > 0 {
+		c.size = int(config.Size)
+	}
+	if config.TTL != "" {
+		ttl, err := time.ParseDuration(config.TTL)
+		if err != nil {
+			logger.Error("product.cache.ProductCache: invalid ttl ", err)
+		} else {
+			c.ttl = ttl
+		}
+	}
+	if config.NotFoundTTL != "" {
+		notFoundTTL, err := time.ParseDuration(config.NotFoundTTL)
+		if err != nil {
+			logger.Error("product.cache.ProductCache: invalid notFoundTTL ", err)
+		} else {
+			c.notFoundTTL = notFoundTTL
+		}
+	}
+}
+
+func (c *ProductCache) init() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries != nil {
+		return
+	}
+	c.entries = make(map[string]*list.Element)
+	c.lru = list.New()
+	c.size = defaultSize
+	c.ttl = defaultTTL
+	c.now = time.Now
+}
+
+// get returns the cached product - a cached nil product means the product was not found
+func (c *ProductCache) get(marketplaceCode string) (domain.BasicProduct, bool) {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[marketplaceCode]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry.product, true
+}
+
+// set caches the product - a nil product is cached as not found for the notFoundTTL
+func (c *ProductCache) set(marketplaceCode string, product domain.BasicProduct) {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ttl := c.ttl
+	if product == nil {
+		ttl = c.notFoundTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	entry := &cacheEntry{marketplaceCode: marketplaceCode, product: product, expiresAt: c.now().Add(ttl)}
+	if element, ok := c.entries[marketplaceCode]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[marketplaceCode] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *ProductCache) remove(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).marketplaceCode)
+}
+
+// Invalidate removes the products with the given marketplace codes from the cache
+func (c *ProductCache) Invalidate(marketplaceCodes ...string) {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, marketplaceCode := range marketplaceCodes {
+		if element, ok := c.entries[marketplaceCode]; ok {
+			c.remove(element)
+		}
+	}
+}
+
+// Flush removes all products from the cache
+func (c *ProductCache) Flush() {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+// Len returns the number of cached entries (including expired ones that have not been removed yet)
+func (c *ProductCache) Len() int {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.lru.Len()
+}
@@ -0,0 +1,330 @@
+package fake_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/product/infrastructure/fake"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+func newRepository(t *testing.T) *fake.ProductRepository {
+	t.Helper()
+	repository := new(fake.ProductRepository)
+	repository.Inject(flamingo.NullLogger{}, nil)
+	require.NoError(t, repository.LoadFixtures("testdata"))
+	return repository
+}
+
+func newSearchService(t *testing.T, defaultPageSize float64) *fake.SearchService {
+	t.Helper()
+	searchService := new(fake.SearchService)
+	searchService.Inject(newRepository(t), &struct {
+		DefaultPageSize float64 `inject:"config:commerce.product.fakeservice.defaultPageSize,optional"`
+	}{DefaultPageSize: defaultPageSize})
+	return searchService
+}
+
+func marketplaceCodes(products []domain.BasicProduct) []string {
+	var codes []string
+	for _, product := range products {
+		codes = append(codes, product.BaseData().MarketPlaceCode)
+	}
+	return codes
+}
+
+func TestProductService_Get(t *testing.T) {
+	productService := new(fake.ProductService)
+	productService.Inject(newRepository(t))
+
+	t.Run("simple product", func(t *testing.T) {
+		product, err := productService.Get(context.Background(), "fake_simple")
+		require.NoError(t, err)
+
+		simple, ok := product.(domain.SimpleProduct)
+		require.True(t, ok)
+		assert.Equal(t, "fake_simple", simple.Identifier)
+		assert.Equal(t, "Flamingo T-Shirt", simple.Title)
+		assert.Equal(t, "cotton", simple.Attributes["material"].Value())
+		assert.Equal(t, "Material", simple.Attributes["material"].Label)
+		assert.Equal(t, "KILOGRAM", simple.Attributes["weight"].UnitCode)
+		assert.Equal(t, "brandCode", simple.Attributes["brandCode"].Label)
+		assert.Equal(t, "shirts", simple.MainCategory.Code)
+		assert.Equal(t, "http://example.com/shirt-detail.jpg", simple.BasicProductData.GetMedia(domain.MediaUsageDetail).Reference)
+
+		assert.True(t, simple.IsSaleableNow())
+		assert.True(t, simple.ActivePrice.IsDiscounted)
+		assert.Equal(t, 18.99, simple.ActivePrice.GetFinalPrice().FloatAmount())
+		assert.Equal(t, "EUR", simple.ActivePrice.GetFinalPrice().Currency())
+
+		loyaltyPrice, found := simple.GetLoyaltyPriceByType("loyalty.miles")
+		require.True(t, found)
+		assert.Equal(t, "Miles", loyaltyPrice.Default.Currency())
+		assert.True(t, loyaltyPrice.HasMax())
+		assert.Equal(t, loyaltyPrice, simple.Teaser.TeaserLoyaltyPriceInfo)
+	})
+
+	t.Run("configurable product", func(t *testing.T) {
+		product, err := productService.Get(context.Background(), "fake_configurable")
+		require.NoError(t, err)
+
+		configurable, ok := product.(domain.ConfigurableProduct)
+		require.True(t, ok)
+		assert.Len(t, configurable.Variants, 3)
+		assert.Equal(t, []string{"red", "blue"}, configurable.VariantVariationAttributesSorting["color"])
+		assert.Equal(t, []string{"42", "44"}, configurable.VariantVariationAttributesSorting["size"])
+
+		assert.True(t, configurable.Teaser.TeaserPriceIsFromPrice)
+		assert.Equal(t, 39.99, configurable.Teaser.TeaserPrice.GetFinalPrice().FloatAmount())
+
+		variant, err := configurable.Variant("fake_configurable_red_42")
+		require.NoError(t, err)
+		assert.Equal(t, "Flamingo Sneaker", variant.Title, "variant should inherit the title")
+		assert.Equal(t, "shoes", variant.MainCategory.Code)
+
+		variant, err = configurable.Variant("fake_configurable_blue_44")
+		require.NoError(t, err)
+		assert.Equal(t, "Flamingo Sneaker XL", variant.Title)
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		_, err := productService.Get(context.Background(), "unknown")
+		assert.Equal(t, domain.ProductNotFound{MarketplaceCode: "unknown"}, err)
+	})
+}
+
+func TestProductRepository_Inject(t *testing.T) {
+	repository := new(fake.ProductRepository)
+	repository.Inject(flamingo.NullLogger{}, &struct {
+		Fixtures string     `inject:"config:commerce.product.fakeservice.fixtures,optional"`
+		Products config.Map `inject:"config:commerce.product.fakeservice.products,optional"`
+	}{
+		Fixtures: "testdata/products.json",
+		Products: config.Map{
+			"config_product": config.Map{
+				"title": "Configured product",
+				"price": "1.50 EUR",
+			},
+			"fake_simple_hat": config.Map{
+				"title": "Replaced hat",
+			},
+		},
+	})
+
+	assert.Len(t, repository.All(), 4)
+
+	product, found := repository.Get("config_product")
+	require.True(t, found)
+	assert.Equal(t, "Configured product", product.BaseData().Title)
+	assert.Equal(t, 1.5, product.SaleableData().ActivePrice.GetFinalPrice().FloatAmount())
+
+	product, found = repository.Get("fake_simple_hat")
+	require.True(t, found)
+	assert.Equal(t, "Replaced hat", product.BaseData().Title)
+}
+
+func TestSearchService_Search(t *testing.T) {
+	tests := []struct {
+		name        string
+		filters     []searchDomain.Filter
+		wantCodes   []string
+		wantPage    int
+		wantPages   int
+		wantResults int
+	}{
+		{
+			name:        "no filters",
+			wantCodes:   []string{"fake_simple", "fake_configurable", "fake_simple_hat"},
+			wantPage:    1,
+			wantPages:   1,
+			wantResults: 3,
+		},
+		{
+			name:        "query matches all words",
+			filters:     []searchDomain.Filter{searchDomain.NewQueryFilter("Pink SHIRT")},
+			wantCodes:   []string{"fake_simple"},
+			wantPage:    1,
+			wantPages:   1,
+			wantResults: 1,
+		},
+		{
+			name:        "query matches variant",
+			filters:     []searchDomain.Filter{searchDomain.NewQueryFilter("xl")},
+			wantCodes:   []string{"fake_configurable"},
+			wantPage:    1,
+			wantPages:   1,
+			wantResults: 1,
+		},
+		{
+			name:        "attribute filter",
+			filters:     []searchDomain.Filter{searchDomain.NewKeyValueFilter("brandCode", []string{"Flamingo"})},
+			wantCodes:   []string{"fake_simple", "fake_configurable"},
+			wantPage:    1,
+			wantPages:   1,
+			wantResults: 2,
+		},
+		{
+			name:        "parent category filter",
+			filters:     []searchDomain.Filter{searchDomain.NewKeyValueFilter("category", []string{"clothing"})},
+			wantCodes:   []string{"fake_simple", "fake_configurable"},
+			wantPage:    1,
+			wantPages:   1,
+			wantResults: 2,
+		},
+		{
+			name: "values are or combined, keys are and combined",
+			filters: []searchDomain.Filter{
+				searchDomain.NewKeyValueFilter("material", []string{"straw", "cotton"}),
+				searchDomain.NewKeyValueFilter("retailerCode", []string{"retailer"}),
+			},
+			wantCodes:   []string{"fake_simple"},
+			wantPage:    1,
+			wantPages:   1,
+			wantResults: 1,
+		},
+		{
+			name:        "sort by price descending",
+			filters:     []searchDomain.Filter{searchDomain.NewSortFilter("price", "D")},
+			wantCodes:   []string{"fake_configurable", "fake_simple", "fake_simple_hat"},
+			wantPage:    1,
+			wantPages:   1,
+			wantResults: 3,
+		},
+		{
+			name:        "sort by attribute",
+			filters:     []searchDomain.Filter{searchDomain.NewSortFilter("material", "A")},
+			wantCodes:   []string{"fake_simple", "fake_simple_hat", "fake_configurable"},
+			wantPage:    1,
+			wantPages:   1,
+			wantResults: 3,
+		},
+		{
+			name: "pagination",
+			filters: []searchDomain.Filter{
+				searchDomain.NewSortFilter("createdAt", "A"),
+				searchDomain.NewPaginationPageSizeFilter(2),
+				searchDomain.NewPaginationPageFilter(2),
+			},
+			wantCodes:   []string{"fake_configurable"},
+			wantPage:    2,
+			wantPages:   2,
+			wantResults: 3,
+		},
+		{
+			name: "page out of range",
+			filters: []searchDomain.Filter{
+				searchDomain.NewPaginationPageSizeFilter(2),
+				searchDomain.NewPaginationPageFilter(5),
+			},
+			wantPage:    5,
+			wantPages:   2,
+			wantResults: 3,
+		},
+	}
+
+	searchService := newSearchService(t, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := searchService.Search(context.Background(), tt.filters...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCodes, marketplaceCodes(result.Hits))
+			assert.Len(t, result.Result.Hits, len(result.Hits))
+			assert.Equal(t, tt.wantPage, result.SearchMeta.Page)
+			assert.Equal(t, tt.wantPages, result.SearchMeta.NumPages)
+			assert.Equal(t, tt.wantResults, result.SearchMeta.NumResults)
+		})
+	}
+}
+
+func TestSearchService_SearchCursor(t *testing.T) {
+	searchService := newSearchService(t, 0)
+	search := func(cursor string) *domain.SearchResult {
+		filters := []searchDomain.Filter{searchDomain.NewSortFilter("createdAt", "A"), searchDomain.NewPaginationPageSizeFilter(1)}
+		if cursor != "" {
+			filters = append(filters, searchDomain.NewPaginationCursorFilter(cursor))
+		}
+		result, err := searchService.Search(context.Background(), filters...)
+		require.NoError(t, err)
+		return result
+	}
+
+	var codes []string
+	result := search("")
+	assert.Empty(t, result.SearchMeta.PreviousCursor)
+	for {
+		codes = append(codes, marketplaceCodes(result.Hits)...)
+		if result.SearchMeta.NextCursor == "" {
+			break
+		}
+		require.True(t, len(codes) < 3, "the last page has no next cursor")
+		result = search(result.SearchMeta.NextCursor)
+	}
+	assert.Len(t, codes, 3)
+	assert.Equal(t, "fake_configurable", codes[2])
+	assert.Equal(t, 3, result.SearchMeta.Page)
+
+	result = search(result.SearchMeta.PreviousCursor)
+	assert.Equal(t, codes[1:2], marketplaceCodes(result.Hits))
+	assert.Equal(t, 2, result.SearchMeta.Page)
+
+	result = search("invalid")
+	assert.Equal(t, codes[0:1], marketplaceCodes(result.Hits), "invalid cursors are ignored")
+
+	for _, position := range []map[string]interface{}{
+		{"o": math.MaxInt64},
+		{"o": math.MaxInt64, "b": true},
+		{"o": math.MinInt64, "b": true},
+		{"o": -1},
+	} {
+		cursor, err := searchDomain.EncodeCursor(position)
+		require.NoError(t, err)
+		result = search(cursor)
+		assert.Equal(t, codes[0:1], marketplaceCodes(result.Hits), "cursors with an offset outside of the result are ignored")
+	}
+}
+
+func TestSearchService_SearchHugePage(t *testing.T) {
+	searchService := newSearchService(t, 0)
+
+	result, err := searchService.Search(context.Background(), searchDomain.NewPaginationPageFilter(math.MaxInt64), searchDomain.NewPaginationPageSizeFilter(math.MaxInt64))
+	require.NoError(t, err)
+	assert.Empty(t, result.Hits)
+
+	result, err = searchService.Search(context.Background(), searchDomain.NewPaginationPageFilter(1), searchDomain.NewPaginationPageSizeFilter(math.MaxInt64))
+	require.NoError(t, err)
+	assert.Len(t, result.Hits, result.SearchMeta.NumResults)
+}
+
+func TestSearchService_SearchBy(t *testing.T) {
+	searchService := newSearchService(t, 0)
+
+	result, err := searchService.SearchBy(context.Background(), "color", []string{"blue"}, searchDomain.NewKeyValueFilter("size", []string{"44"}))
+	require.NoError(t, err)
+	require.Len(t, result.Hits, 1)
+	assert.Equal(t, "fake_configurable_blue_44", result.Hits[0].TeaserData().PreSelectedVariantSku)
+
+	result, err = searchService.SearchBy(context.Background(), "color", []string{"red"}, searchDomain.NewKeyValueFilter("size", []string{"44"}))
+	require.NoError(t, err)
+	assert.Len(t, result.Hits, 0, "one variant needs to match all variant filters")
+}
+
+func TestSearchService_DefaultPageSize(t *testing.T) {
+	searchService := newSearchService(t, 2)
+
+	result, err := searchService.Search(context.Background(), searchDomain.NewSortFilter("title", "A"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fake_configurable", "fake_simple"}, marketplaceCodes(result.Hits))
+	assert.NoError(t, result.SearchMeta.ValidatePageSize(2))
+
+	for _, option := range result.SearchMeta.SortOptions {
+		assert.Equal(t, option.Label == "title", option.SelectedAsc, option.Label)
+		assert.False(t, option.SelectedDesc, option.Label)
+	}
+}
@@ -0,0 +1,351 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// fixtureProduct is the JSON representation of a (simple or configurable) product or a variant in the fixtures
+	fixtureProduct struct {
+		Identifier       string `json:"identifier"`
+		MarketplaceCode  string `json:"marketplaceCode"`
+		Type             string `json:"type"`
+		Title            string `json:"title"`
+		ShortTitle       string `json:"shortTitle"`
+		ShortDescription string `json:"shortDescription"`
+		Description      string `json:"description"`
+
+		RetailerCode string `json:"retailerCode"`
+		RetailerSku  string `json:"retailerSku"`
+		RetailerName string `json:"retailerName"`
+
+		CreatedAt   time.Time `json:"createdAt"`
+		UpdatedAt   time.Time `json:"updatedAt"`
+		VisibleFrom time.Time `json:"visibleFrom"`
+		VisibleTo   time.Time `json:"visibleTo"`
+
+		StockLevel string   `json:"stockLevel"`
+		Keywords   []string `json:"keywords"`
+		IsNew      bool     `json:"isNew"`
+
+		Attributes   map[string]fixtureAttribute `json:"attributes"`
+		Media        []fixtureMedia              `json:"media"`
+		Categories   []fixtureCategory           `json:"categories"`
+		MainCategory *fixtureCategory            `json:"mainCategory"`
+
+		Saleable        *bool                 `json:"saleable"`
+		SaleableFrom    time.Time             `json:"saleableFrom"`
+		SaleableTo      time.Time             `json:"saleableTo"`
+		Price           *priceDomain.Price    `json:"price"`
+		DiscountedPrice *priceDomain.Price    `json:"discountedPrice"`
+		DiscountText    string                `json:"discountText"`
+		TaxClass        string                `json:"taxClass"`
+		LoyaltyPrices   []fixtureLoyaltyPrice `json:"loyaltyPrices"`
+
+		VariantVariationAttributes []string         `json:"variantVariationAttributes"`
+		Variants                   []fixtureProduct `json:"variants"`
+	}
+
+	// fixtureAttribute can be given as plain value ("brandCode": "apple") or as object with label, value and unitCode
+	fixtureAttribute struct {
+		Label    string      `json:"label"`
+		Value    interface{} `json:"value"`
+		UnitCode string      `json:"unitCode"`
+	}
+
+	fixtureMedia struct {
+		Type      string `json:"type"`
+		MimeType  string `json:"mimeType"`
+		Usage     string `json:"usage"`
+		Title     string `json:"title"`
+		Reference string `json:"reference"`
+	}
+
+	fixtureCategory struct {
+		Code string `json:"code"`
+		Path string `json:"path"`
+		Name string `json:"name"`
+	}
+
+	fixtureLoyaltyPrice struct {
+		Type             string             `json:"type"`
+		Default          priceDomain.Price  `json:"default"`
+		Discounted       *priceDomain.Price `json:"discounted"`
+		DiscountText     string             `json:"discountText"`
+		MinPointsToSpent float64            `json:"minPointsToSpent"`
+		MaxPointsToSpent *float64           `json:"maxPointsToSpent"`
+	}
+)
+
+// UnmarshalJSON accepts the object form with label, value and unitCode or any plain JSON value
+func (a *fixtureAttribute) UnmarshalJSON(data []byte) error {
+	var object struct {
+		Label    string      `json:"label"`
+		Value    interface{} `json:"value"`
+		UnitCode string      `json:"unitCode"`
+	}
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if m, ok := raw.(map[string]interface{}); ok {
+		if _, hasValue := m["value"]; hasValue {
+			if err := json.Unmarshal(data, &object); err != nil {
+				return err
+			}
+			*a = fixtureAttribute(object)
+			return nil
+		}
+	}
+	*a = fixtureAttribute{Value: raw}
+	return nil
+}
+
+// toProduct maps the fixture to the matching product type
+func (f fixtureProduct) toProduct() (domain.BasicProduct, error) {
+	if f.MarketplaceCode == "" {
+		return nil, fmt.Errorf("product fixture without marketplaceCode (title %q)", f.Title)
+	}
+	identifier := f.Identifier
+	if identifier == "" {
+		identifier = f.MarketplaceCode
+	}
+
+	switch f.Type {
+	case "", domain.TypeSimple:
+		if len(f.Variants) > 0 {
+			return nil, fmt.Errorf("product fixture %q: simple products cannot have variants", f.MarketplaceCode)
+		}
+		saleable := f.saleable()
+		basicData := f.basicProductData()
+		return domain.SimpleProduct{
+			Identifier:       identifier,
+			BasicProductData: basicData,
+			Saleable:         saleable,
+			Teaser:           teaser(f, basicData, saleable),
+		}, nil
+	case domain.TypeConfigurable:
+		if len(f.Variants) == 0 {
+			return nil, fmt.Errorf("product fixture %q: configurable products need at least one variant", f.MarketplaceCode)
+		}
+		product := domain.ConfigurableProduct{
+			Identifier:                        identifier,
+			BasicProductData:                  f.basicProductData(),
+			VariantVariationAttributes:        f.VariantVariationAttributes,
+			VariantVariationAttributesSorting: make(map[string][]string),
+		}
+		for _, fixtureVariant := range f.Variants {
+			if fixtureVariant.MarketplaceCode == "" {
+				return nil, fmt.Errorf("product fixture %q: variant without marketplaceCode", f.MarketplaceCode)
+			}
+			fixtureVariant = fixtureVariant.inheritFrom(f)
+			product.Variants = append(product.Variants, domain.Variant{
+				BasicProductData: fixtureVariant.basicProductData(),
+				Saleable:         fixtureVariant.saleable(),
+			})
+		}
+		for _, attribute := range product.VariantVariationAttributes {
+			product.VariantVariationAttributesSorting[attribute] = variationValues(product.Variants, attribute)
+		}
+		product.Teaser = configurableTeaser(f, product)
+		return product, nil
+	}
+
+	return nil, fmt.Errorf("product fixture %q: unsupported type %q", f.MarketplaceCode, f.Type)
+}
+
+// inheritFrom fills the empty descriptive fields of a variant with the ones of its configurable
+func (f fixtureProduct) inheritFrom(configurable fixtureProduct) fixtureProduct {
+	if f.Title == "" {
+		f.Title = configurable.Title
+	}
+	if f.ShortDescription == "" {
+		f.ShortDescription = configurable.ShortDescription
+	}
+	if f.Description == "" {
+		f.Description = configurable.Description
+	}
+	if f.RetailerCode == "" {
+		f.RetailerCode = configurable.RetailerCode
+	}
+	if f.RetailerName == "" {
+		f.RetailerName = configurable.RetailerName
+	}
+	if len(f.Media) == 0 {
+		f.Media = configurable.Media
+	}
+	if len(f.Categories) == 0 {
+		f.Categories = configurable.Categories
+	}
+	if f.MainCategory == nil {
+		f.MainCategory = configurable.MainCategory
+	}
+	if f.Price == nil {
+		f.Price = configurable.Price
+		f.DiscountedPrice = configurable.DiscountedPrice
+		f.DiscountText = configurable.DiscountText
+	}
+	if len(f.LoyaltyPrices) == 0 {
+		f.LoyaltyPrices = configurable.LoyaltyPrices
+	}
+	if f.TaxClass == "" {
+		f.TaxClass = configurable.TaxClass
+	}
+	if f.Saleable == nil {
+		f.Saleable = configurable.Saleable
+	}
+	return f
+}
+
+func (f fixtureProduct) basicProductData() domain.BasicProductData {
+	data := domain.BasicProductData{
+		Title:            f.Title,
+		ShortDescription: f.ShortDescription,
+		Description:      f.Description,
+		MarketPlaceCode:  f.MarketplaceCode,
+		RetailerCode:     f.RetailerCode,
+		RetailerSku:      f.RetailerSku,
+		RetailerName:     f.RetailerName,
+		CreatedAt:        f.CreatedAt,
+		UpdatedAt:        f.UpdatedAt,
+		VisibleFrom:      f.VisibleFrom,
+		VisibleTo:        f.VisibleTo,
+		StockLevel:       f.StockLevel,
+		Keywords:         f.Keywords,
+		IsNew:            f.IsNew,
+		Attributes:       make(domain.Attributes, len(f.Attributes)),
+	}
+
+	for code, attribute := range f.Attributes {
+		label := attribute.Label
+		if label == "" {
+			label = code
+		}
+		data.Attributes[code] = domain.Attribute{
+			Code:     code,
+			Label:    label,
+			RawValue: attribute.Value,
+			UnitCode: attribute.UnitCode,
+		}
+	}
+
+	for _, media := range f.Media {
+		data.Media = append(data.Media, domain.Media(media))
+	}
+
+	for _, category := range f.Categories {
+		data.Categories = append(data.Categories, domain.CategoryTeaser(category))
+		data.CategoryToCodeMapping = append(data.CategoryToCodeMapping, category.Code)
+	}
+	if f.MainCategory != nil {
+		data.MainCategory = domain.CategoryTeaser(*f.MainCategory)
+	} else if len(data.Categories) > 0 {
+		data.MainCategory = data.Categories[0]
+	}
+
+	return data
+}
+
+func (f fixtureProduct) saleable() domain.Saleable {
+	saleable := domain.Saleable{
+		IsSaleable:   f.Saleable == nil || *f.Saleable,
+		SaleableFrom: f.SaleableFrom,
+		SaleableTo:   f.SaleableTo,
+	}
+
+	if f.Price != nil {
+		saleable.ActivePrice = domain.PriceInfo{
+			Default:      *f.Price,
+			DiscountText: f.DiscountText,
+			TaxClass:     f.TaxClass,
+		}
+		if f.DiscountedPrice != nil {
+			saleable.ActivePrice.Discounted = *f.DiscountedPrice
+			saleable.ActivePrice.IsDiscounted = f.DiscountedPrice.IsLessThen(*f.Price)
+		}
+		saleable.AvailablePrices = []domain.PriceInfo{saleable.ActivePrice}
+	}
+
+	for _, loyaltyPrice := range f.LoyaltyPrices {
+		info := domain.LoyaltyPriceInfo{
+			Type:             loyaltyPrice.Type,
+			Default:          loyaltyPrice.Default,
+			DiscountText:     loyaltyPrice.DiscountText,
+			MinPointsToSpent: *big.NewFloat(loyaltyPrice.MinPointsToSpent),
+		}
+		if loyaltyPrice.Discounted != nil {
+			info.Discounted = *loyaltyPrice.Discounted
+			info.IsDiscounted = loyaltyPrice.Discounted.IsLessThen(loyaltyPrice.Default)
+		}
+		if loyaltyPrice.MaxPointsToSpent != nil {
+			info.MaxPointsToSpent = big.NewFloat(*loyaltyPrice.MaxPointsToSpent)
+		}
+		saleable.LoyaltyPrices = append(saleable.LoyaltyPrices, info)
+	}
+
+	return saleable
+}
+
+// teaser builds the teaser of a simple product
+func teaser(f fixtureProduct, basicData domain.BasicProductData, saleable domain.Saleable) domain.TeaserData {
+	shortTitle := f.ShortTitle
+	if shortTitle == "" {
+		shortTitle = f.Title
+	}
+	teaserData := domain.TeaserData{
+		ShortTitle:            shortTitle,
+		ShortDescription:      f.ShortDescription,
+		TeaserPrice:           saleable.ActivePrice,
+		TeaserAvailablePrices: saleable.AvailablePrices,
+		Media:                 basicData.Media,
+		MarketPlaceCode:       f.MarketplaceCode,
+	}
+	if len(saleable.LoyaltyPrices) > 0 {
+		teaserData.TeaserLoyaltyPriceInfo = &saleable.LoyaltyPrices[0]
+	}
+	return teaserData
+}
+
+// configurableTeaser builds the teaser of a configurable - the teaser price is the cheapest variant price
+func configurableTeaser(f fixtureProduct, product domain.ConfigurableProduct) domain.TeaserData {
+	cheapest := product.Variants[0]
+	fromPrice := false
+	for _, variant := range product.Variants[1:] {
+		variantPrice := variant.ActivePrice.GetFinalPrice()
+		cheapestPrice := cheapest.ActivePrice.GetFinalPrice()
+		if !variantPrice.Equal(cheapestPrice) {
+			fromPrice = true
+		}
+		if variantPrice.IsLessThen(cheapestPrice) {
+			cheapest = variant
+		}
+	}
+
+	teaserData := teaser(f, product.BasicProductData, cheapest.Saleable)
+	teaserData.TeaserPriceIsFromPrice = fromPrice
+	return teaserData
+}
+
+// variationValues returns the distinct values of the given attribute in the order of the variants
+func variationValues(variants []domain.Variant, attribute string) []string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, variant := range variants {
+		if !variant.HasAttribute(attribute) {
+			continue
+		}
+		value := variant.Attributes[attribute].Value()
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	return values
+}
@@ -0,0 +1,32 @@
+package fake
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// ProductService is a fake product service adapter that returns the products of the fixtures
+	ProductService struct {
+		repository *ProductRepository
+	}
+)
+
+var (
+	_ domain.ProductService = (*ProductService)(nil)
+)
+
+// Inject dependencies
+func (ps *ProductService) Inject(repository *ProductRepository) {
+	ps.repository = repository
+}
+
+// Get returns the product with the given marketplace code or domain.ProductNotFound
+func (ps *ProductService) Get(ctx context.Context, marketplaceCode string) (domain.BasicProduct, error) {
+	product, ok := ps.repository.Get(marketplaceCode)
+	if !ok {
+		return nil, domain.ProductNotFound{MarketplaceCode: marketplaceCode}
+	}
+	return product, nil
+}
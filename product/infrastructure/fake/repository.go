@@ -0,0 +1,156 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// ProductRepository holds the products loaded from the configured fixtures and is shared by the fake ProductService and SearchService
+	ProductRepository struct {
+		mutex    sync.RWMutex
+		products map[string]domain.BasicProduct
+		order    []string
+		logger   flamingo.Logger
+	}
+)
+
+// Inject dependencies and load the configured fixtures
+func (r *ProductRepository) Inject(
+	logger flamingo.Logger,
+	config *struct {
+		Fixtures string     `inject:"config:commerce.product.fakeservice.fixtures,optional"`
+		Products config.Map `inject:"config:commerce.product.fakeservice.products,optional"`
+	},
+) {
+	r.logger = logger.WithField(flamingo.LogKeyCategory, "fakeproductservice").WithField(flamingo.LogKeyModule, "product")
+	if config == nil {
+		return
+	}
+
+	if config.Fixtures != "" {
+		if err := r.LoadFixtures(config.Fixtures); err != nil {
+			r.logger.Error("product.fake.ProductRepository: ", err)
+		}
+	}
+	if len(config.Products) > 0 {
+		if err := r.loadConfig(config.Products); err != nil {
+			r.logger.Error("product.fake.ProductRepository: ", err)
+		}
+	}
+}
+
+// LoadFixtures reads products from a JSON file or from all JSON files of a directory (in lexical order).
+// A fixture file contains a list of products
+func (r *ProductRepository) LoadFixtures(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return err
+		}
+		sort.Strings(files)
+	}
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var fixtures []fixtureProduct
+		if err := json.Unmarshal(content, &fixtures); err != nil {
+			return fmt.Errorf("%v: %v", file, err)
+		}
+		if err := r.addFixtures(fixtures); err != nil {
+			return fmt.Errorf("%v: %v", file, err)
+		}
+	}
+
+	return nil
+}
+
+// loadConfig reads products from a config map keyed by marketplace code
+func (r *ProductRepository) loadConfig(products config.Map) error {
+	fixturesByCode := make(map[string]fixtureProduct)
+	if err := products.MapInto(&fixturesByCode); err != nil {
+		return err
+	}
+
+	codes := make([]string, 0, len(fixturesByCode))
+	for code := range fixturesByCode {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	fixtures := make([]fixtureProduct, 0, len(codes))
+	for _, code := range codes {
+		fixture := fixturesByCode[code]
+		if fixture.MarketplaceCode == "" {
+			fixture.MarketplaceCode = code
+		}
+		fixtures = append(fixtures, fixture)
+	}
+
+	return r.addFixtures(fixtures)
+}
+
+func (r *ProductRepository) addFixtures(fixtures []fixtureProduct) error {
+	for _, fixture := range fixtures {
+		product, err := fixture.toProduct()
+		if err != nil {
+			return err
+		}
+		r.Add(product)
+	}
+	return nil
+}
+
+// Add adds a product - an existing product with the same marketplace code is replaced
+func (r *ProductRepository) Add(product domain.BasicProduct) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.products == nil {
+		r.products = make(map[string]domain.BasicProduct)
+	}
+	code := product.BaseData().MarketPlaceCode
+	if _, exists := r.products[code]; !exists {
+		r.order = append(r.order, code)
+	}
+	r.products[code] = product
+}
+
+// Get returns the product with the given marketplace code
+func (r *ProductRepository) Get(marketplaceCode string) (domain.BasicProduct, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	product, ok := r.products[marketplaceCode]
+	return product, ok
+}
+
+// All returns all products in the order they have been added
+func (r *ProductRepository) All() []domain.BasicProduct {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	products := make([]domain.BasicProduct, 0, len(r.order))
+	for _, code := range r.order {
+		products = append(products, r.products[code])
+	}
+	return products
+}
@@ -0,0 +1,119 @@
+package fake
+
+import (
+	"context"
+	"strings"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type (
+	// SearchDocumentSource provides the products of the fixtures as documents of the type "product" for the in-memory search
+	SearchDocumentSource struct {
+		repository *ProductRepository
+	}
+)
+
+// DocumentTypeProduct is the document type of the products
+const DocumentTypeProduct = "product"
+
+var _ searchDomain.DocumentSource = (*SearchDocumentSource)(nil)
+
+// Inject dependencies
+func (s *SearchDocumentSource) Inject(repository *ProductRepository) {
+	s.repository = repository
+}
+
+// Documents maps all products of the repository.
+// The full-text fields are title, description, keywords, code and retailer - configurables include the texts of their variants.
+// Keywords are title, marketplaceCode, retailerCode, category (the category paths) and all attributes, numbers are price (final teaser price) and createdAt (unix time)
+func (s *SearchDocumentSource) Documents(_ context.Context) ([]searchDomain.IndexDocument, error) {
+	products := s.repository.All()
+	documents := make([]searchDomain.IndexDocument, 0, len(products))
+	for _, product := range products {
+		documents = append(documents, productDocument(product))
+	}
+	return documents, nil
+}
+
+func productDocument(product domain.BasicProduct) searchDomain.IndexDocument {
+	data := product.BaseData()
+	document := searchDomain.IndexDocument{
+		ID:       data.MarketPlaceCode,
+		Type:     DocumentTypeProduct,
+		Fields:   make(map[string]string),
+		Keywords: make(map[string][]string),
+		Numbers: map[string]float64{
+			"price":     product.TeaserData().TeaserPrice.GetFinalPrice().FloatAmount(),
+			"createdAt": float64(data.CreatedAt.Unix()),
+		},
+		Labels:   map[string]map[string]string{"category": {}},
+		Document: product,
+	}
+
+	allData := []domain.BasicProductData{data}
+	if configurable, ok := product.(domain.ConfigurableProduct); ok {
+		for _, variant := range configurable.Variants {
+			allData = append(allData, variant.BasicProductData)
+		}
+	}
+
+	addKeywords(document.Keywords, "title", data.Title)
+
+	var titles, descriptions, keywords, codes, retailers []string
+	for _, data := range allData {
+		titles = append(titles, data.Title)
+		descriptions = append(descriptions, data.ShortDescription, data.Description)
+		keywords = append(keywords, data.Keywords...)
+		codes = append(codes, data.MarketPlaceCode, data.RetailerSku)
+		retailers = append(retailers, data.RetailerName)
+
+		addKeywords(document.Keywords, "marketplaceCode", data.MarketPlaceCode)
+		addKeywords(document.Keywords, "retailerCode", data.RetailerCode)
+		for _, attribute := range data.Attributes {
+			if attribute.HasMultipleValues() {
+				addKeywords(document.Keywords, attribute.Code, attribute.Values()...)
+			} else {
+				addKeywords(document.Keywords, attribute.Code, attribute.Value())
+			}
+		}
+		for _, category := range append([]domain.CategoryTeaser{data.MainCategory}, data.Categories...) {
+			path := category.Path
+			if path == "" {
+				path = category.Code
+			}
+			addKeywords(document.Keywords, "category", path)
+			if category.Code != "" && category.Name != "" {
+				document.Labels["category"][category.Code] = category.Name
+			}
+		}
+	}
+
+	document.Fields["title"] = strings.Join(append(titles, product.TeaserData().ShortTitle), " ")
+	document.Fields["description"] = strings.Join(descriptions, " ")
+	document.Fields["keywords"] = strings.Join(keywords, " ")
+	document.Fields["code"] = strings.Join(codes, " ")
+	document.Fields["retailer"] = strings.Join(retailers, " ")
+
+	return document
+}
+
+// addKeywords adds the non empty values that are not yet part of the keywords of the field
+func addKeywords(keywords map[string][]string, field string, values ...string) {
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		exists := false
+		for _, existing := range keywords[field] {
+			if existing == value {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			keywords[field] = append(keywords[field], value)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/inmemory"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+func TestSearchDocumentSource_Documents(t *testing.T) {
+	repository := new(ProductRepository)
+	assert.NoError(t, repository.LoadFixtures("testdata/products.json"))
+	source := new(SearchDocumentSource)
+	source.Inject(repository)
+
+	documents, err := source.Documents(context.Background())
+	assert.NoError(t, err)
+	if !assert.Len(t, documents, len(repository.All())) {
+		return
+	}
+
+	sneaker := documents[1]
+	assert.Equal(t, "fake_configurable", sneaker.ID)
+	assert.Equal(t, DocumentTypeProduct, sneaker.Type)
+	assert.Contains(t, sneaker.Fields["title"], "Flamingo Sneaker XL", "the texts of the variants are searchable")
+	assert.ElementsMatch(t, []string{"red", "blue"}, sneaker.Keywords["color"])
+	assert.Equal(t, []string{"clothing/shoes"}, sneaker.Keywords["category"])
+	assert.Equal(t, "Shoes", sneaker.Labels["category"]["shoes"])
+
+	index := new(inmemory.DocumentIndex)
+	index.Index(documents...)
+	service := new(inmemory.SearchService)
+	service.Inject(index, flamingo.NullLogger{}, nil)
+
+	result, err := service.SearchFor(context.Background(), DocumentTypeProduct, searchDomain.NewQueryFilter("sneakers"), searchDomain.NewKeyValueFilter("color", []string{"blue"}))
+	assert.NoError(t, err)
+	if assert.Len(t, result.Hits, 1) {
+		assert.Equal(t, repository.All()[1], result.Hits[0])
+	}
+}
@@ -0,0 +1,544 @@
+package fake
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type (
+	// SearchService is a fake product search adapter that searches in the products of the fixtures
+	SearchService struct {
+		repository      *ProductRepository
+		defaultPageSize int
+	}
+
+	// searchRequest collects the interpreted filters
+	searchRequest struct {
+		query         string
+		page          int
+		pageSize      int
+		sortBy        string
+		sortDirection string
+		keyValues     map[string][]string
+		keyOrder      []string
+		// filters contains the key value filters (combined per key) and the typed and composite filters
+		filters []searchDomain.Filter
+		cursor  *cursorPosition
+	}
+
+	// matchData are the values of a product or a variant the filters are evaluated on
+	matchData struct {
+		data  domain.BasicProductData
+		price float64
+	}
+
+	// cursorPosition is the content of the opaque pagination cursors - the page continues after (or ends before) the product with the code.
+	// The offset is used if the product is no longer part of the result
+	cursorPosition struct {
+		Code     string `json:"c,omitempty"`
+		Offset   int    `json:"o"`
+		Backward bool   `json:"b,omitempty"`
+	}
+)
+
+const (
+	// SortByPrice sorts by the final teaser price
+	SortByPrice = "price"
+	// SortByTitle sorts by the product title
+	SortByTitle = "title"
+	// SortByCreatedAt sorts by creation date
+	SortByCreatedAt = "createdAt"
+
+	defaultPageSize = 20
+)
+
+var (
+	_ domain.SearchService = (*SearchService)(nil)
+
+	sortLabels = []string{SortByPrice, SortByTitle, SortByCreatedAt}
+)
+
+// Inject dependencies
+func (s *SearchService) Inject(
+	repository *ProductRepository,
+	config *struct {
+		DefaultPageSize float64 `inject:"config:commerce.product.fakeservice.defaultPageSize,optional"`
+	},
+) {
+	s.repository = repository
+	if config != nil {
+		s.defaultPageSize = int(config.DefaultPageSize)
+	}
+}
+
+// Search returns the products matching all given filters.
+// Supported are QueryFilter (all words need to be found in title, descriptions, keywords or codes), SortFilter, PaginationPage, PaginationPageSize, PaginationCursor
+// and key value filters on "marketplaceCode", "retailerCode", "category" (category code or parent category code) and any attribute code.
+// Values of one key are OR combined, different keys are AND combined. Configurables match if they or one of their variants match.
+// The typed filters are evaluated on the same keys: RangeFilter on "price" (final price), "createdAt" (unix time) and numeric attributes,
+// TreeFilter on the "category" paths and attribute values, BoolFilter and ExistsFilter on attributes - AndFilter, OrFilter and NotFilter combine them.
+func (s *SearchService) Search(ctx context.Context, filter ...searchDomain.Filter) (*domain.SearchResult, error) {
+	request := s.buildRequest(filter)
+
+	var matches []domain.BasicProduct
+	for _, product := range s.repository.All() {
+		if match, ok := request.match(product); ok {
+			matches = append(matches, match)
+		}
+	}
+
+	request.sort(matches)
+
+	numResults := len(matches)
+	numPages := int(math.Ceil(float64(numResults) / float64(request.pageSize)))
+	start, end := request.window(matches)
+	hits := matches[start:end]
+	nextCursor, previousCursor := cursors(matches, start, end)
+	page := request.page
+	if request.cursor != nil {
+		page = start/request.pageSize + 1
+	}
+
+	documents := make([]searchDomain.Document, len(hits))
+	for i, hit := range hits {
+		documents[i] = hit
+	}
+
+	return &domain.SearchResult{
+		Result: searchDomain.Result{
+			SearchMeta: searchDomain.SearchMeta{
+				Query:          request.query,
+				OriginalQuery:  request.query,
+				Page:           page,
+				NumPages:       numPages,
+				NumResults:     numResults,
+				SortOptions:    request.sortOptions(),
+				NextCursor:     nextCursor,
+				PreviousCursor: previousCursor,
+			},
+			Hits:   documents,
+			Facets: searchDomain.FacetCollection{},
+		},
+		Hits: hits,
+	}, nil
+}
+
+// SearchBy returns the products that match the given attribute values and all other filters
+func (s *SearchService) SearchBy(ctx context.Context, attribute string, values []string, filter ...searchDomain.Filter) (*domain.SearchResult, error) {
+	return s.Search(ctx, append(filter, searchDomain.NewKeyValueFilter(attribute, values))...)
+}
+
+func (s *SearchService) buildRequest(filters []searchDomain.Filter) *searchRequest {
+	request := &searchRequest{
+		page:      1,
+		pageSize:  s.defaultPageSize,
+		keyValues: make(map[string][]string),
+	}
+	if request.pageSize < 1 {
+		request.pageSize = defaultPageSize
+	}
+
+	for _, filter := range filters {
+		switch f := filter.(type) {
+		case *searchDomain.QueryFilter:
+			_, values := f.Value()
+			request.query = values[0]
+		case *searchDomain.SortFilter:
+			label, direction := f.Value()
+			request.sortBy = label
+			request.sortDirection = direction[0]
+		case *searchDomain.PaginationPage:
+			_, values := f.Value()
+			if page, err := strconv.Atoi(values[0]); err == nil && page > 0 {
+				request.page = page
+			}
+		case *searchDomain.PaginationPageSize:
+			if f.GetPageSize() > 0 {
+				request.pageSize = f.GetPageSize()
+			}
+		case *searchDomain.PaginationCursor:
+			// invalid cursors are ignored like invalid pages
+			position := new(cursorPosition)
+			if err := searchDomain.DecodeCursor(f.Cursor(), position); err == nil {
+				request.cursor = position
+			}
+		case *searchDomain.RangeFilter, *searchDomain.TreeFilter, *searchDomain.BoolFilter, *searchDomain.ExistsFilter,
+			*searchDomain.AndFilter, *searchDomain.OrFilter, *searchDomain.NotFilter:
+			request.filters = append(request.filters, filter)
+		default:
+			key, values := filter.Value()
+			if len(values) == 0 {
+				continue
+			}
+			if _, exists := request.keyValues[key]; !exists {
+				request.keyOrder = append(request.keyOrder, key)
+			}
+			request.keyValues[key] = append(request.keyValues[key], values...)
+		}
+	}
+
+	for _, key := range request.keyOrder {
+		request.filters = append(request.filters, searchDomain.NewKeyValueFilter(key, request.keyValues[key]))
+	}
+
+	return request
+}
+
+// window returns the range of the page or of the cursor in the sorted products - cursors with an offset outside of the products are ignored like invalid pages
+func (r *searchRequest) window(products []domain.BasicProduct) (int, int) {
+	length := len(products)
+	if r.cursor == nil || r.cursor.Offset < 0 || r.cursor.Offset > length {
+		// pages behind the last page are empty - checked before multiplying, so that huge page numbers do not overflow
+		if r.page-1 > length/r.pageSize {
+			return length, length
+		}
+		return bounded((r.page-1)*r.pageSize, r.pageSize, length)
+	}
+
+	position := r.cursor.Offset
+	for i, product := range products {
+		if r.cursor.Code != "" && product.BaseData().MarketPlaceCode == r.cursor.Code {
+			position = i
+			if !r.cursor.Backward {
+				position++
+			}
+			break
+		}
+	}
+	if r.cursor.Backward {
+		if position > r.pageSize {
+			return position - r.pageSize, position
+		}
+		return 0, position
+	}
+	return bounded(position, r.pageSize, length)
+}
+
+// bounded returns the range of at most size products from start limited to the length, start is never behind end
+func bounded(start int, size int, length int) (int, int) {
+	if start < 0 {
+		start = 0
+	}
+	if start > length {
+		start = length
+	}
+	if size < length-start {
+		return start, start + size
+	}
+	return start, length
+}
+
+// cursors returns the cursor after the last and the cursor before the first product of the page - empty at the ends of the result
+func cursors(products []domain.BasicProduct, start int, end int) (string, string) {
+	var next, previous string
+	if end < len(products) && end > 0 {
+		next, _ = searchDomain.EncodeCursor(cursorPosition{Code: products[end-1].BaseData().MarketPlaceCode, Offset: end})
+	}
+	if start > 0 {
+		position := cursorPosition{Offset: start, Backward: true}
+		if start < len(products) {
+			position.Code = products[start].BaseData().MarketPlaceCode
+		}
+		previous, _ = searchDomain.EncodeCursor(position)
+	}
+	return next, previous
+}
+
+// match checks the product against the request and returns the product - configurables get the matching variant preselected
+func (r *searchRequest) match(product domain.BasicProduct) (domain.BasicProduct, bool) {
+	if !matchesQuery(product, r.query) {
+		return nil, false
+	}
+
+	configurable, isConfigurable := product.(domain.ConfigurableProduct)
+	if !isConfigurable {
+		data := matchData{data: product.BaseData(), price: product.TeaserData().TeaserPrice.GetFinalPrice().FloatAmount()}
+		for _, filter := range r.filters {
+			if !data.matches(filter) {
+				return nil, false
+			}
+		}
+		return product, true
+	}
+
+	// filters not matching the configurable itself need to be matched by the same variant
+	configurableData := matchData{data: configurable.BasicProductData, price: configurable.TeaserData().TeaserPrice.GetFinalPrice().FloatAmount()}
+	var variantFilters []searchDomain.Filter
+	for _, filter := range r.filters {
+		if !configurableData.matches(filter) {
+			variantFilters = append(variantFilters, filter)
+		}
+	}
+	if len(variantFilters) == 0 {
+		return configurable, true
+	}
+
+	for _, variant := range configurable.Variants {
+		variantData := matchData{data: inheritedData(variant.BasicProductData, configurable.BasicProductData), price: variant.ActivePrice.GetFinalPrice().FloatAmount()}
+		variantMatches := true
+		for _, filter := range variantFilters {
+			if !variantData.matches(filter) {
+				variantMatches = false
+				break
+			}
+		}
+		if variantMatches {
+			configurable.Teaser.PreSelectedVariantSku = variant.MarketPlaceCode
+			return configurable, true
+		}
+	}
+
+	return nil, false
+}
+
+// inheritedData returns the variant data with the attributes and categories of the configurable that the variant does not set itself - e.g. so that negated filters see the brand of the configurable
+func inheritedData(variant domain.BasicProductData, configurable domain.BasicProductData) domain.BasicProductData {
+	attributes := make(domain.Attributes, len(variant.Attributes)+len(configurable.Attributes))
+	for code, attribute := range configurable.Attributes {
+		attributes[code] = attribute
+	}
+	for code, attribute := range variant.Attributes {
+		attributes[code] = attribute
+	}
+	variant.Attributes = attributes
+	if len(variant.Categories) == 0 && variant.MainCategory.Code == "" {
+		variant.Categories = configurable.Categories
+		variant.MainCategory = configurable.MainCategory
+	}
+	return variant
+}
+
+// matches evaluates the filter - unknown filter types are handled as key value filters
+func (m matchData) matches(filter searchDomain.Filter) bool {
+	switch f := filter.(type) {
+	case *searchDomain.RangeFilter:
+		number, ok := m.number(f.Key())
+		return ok && f.Matches(number)
+	case *searchDomain.TreeFilter:
+		for _, path := range m.paths(f.Key()) {
+			if f.Matches(strings.Split(path, searchDomain.TreePathSeparator)) {
+				return true
+			}
+		}
+		return false
+	case *searchDomain.BoolFilter:
+		for _, value := range m.values(f.Key()) {
+			if b, err := strconv.ParseBool(value); err == nil && b == f.Bool() {
+				return true
+			}
+		}
+		return false
+	case *searchDomain.ExistsFilter:
+		return len(m.values(f.Key())) > 0
+	case *searchDomain.AndFilter:
+		for _, child := range f.Filters() {
+			if !m.matches(child) {
+				return false
+			}
+		}
+		return true
+	case *searchDomain.OrFilter:
+		for _, child := range f.Filters() {
+			if m.matches(child) {
+				return true
+			}
+		}
+		return false
+	case *searchDomain.NotFilter:
+		return f.Filter() == nil || !m.matches(f.Filter())
+	default:
+		key, values := filter.Value()
+		return matchesKeyValues(m.data, key, values)
+	}
+}
+
+// number returns the price, the creation date (unix time) or the numeric value of the attribute
+func (m matchData) number(key string) (float64, bool) {
+	switch key {
+	case SortByPrice:
+		return m.price, true
+	case SortByCreatedAt:
+		return float64(m.data.CreatedAt.Unix()), !m.data.CreatedAt.IsZero()
+	}
+	if !m.data.HasAttribute(key) {
+		return 0, false
+	}
+	number, err := m.data.Attributes[key].FloatValue()
+	return number, err == nil
+}
+
+// paths returns the category paths (the code for categories without path) or the values of the attribute
+func (m matchData) paths(key string) []string {
+	if key != "category" {
+		return m.values(key)
+	}
+	var paths []string
+	for _, category := range append([]domain.CategoryTeaser{m.data.MainCategory}, m.data.Categories...) {
+		if category.Path != "" {
+			paths = append(paths, category.Path)
+		} else if category.Code != "" {
+			paths = append(paths, category.Code)
+		}
+	}
+	return paths
+}
+
+// values returns the non empty values of the key
+func (m matchData) values(key string) []string {
+	var values []string
+	for _, value := range productValues(m.data, key) {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (r *searchRequest) sort(products []domain.BasicProduct) {
+	if r.sortBy == "" {
+		return
+	}
+
+	less := func(i, j int) bool {
+		switch r.sortBy {
+		case SortByPrice:
+			return products[i].TeaserData().TeaserPrice.GetFinalPrice().IsLessThen(products[j].TeaserData().TeaserPrice.GetFinalPrice())
+		case SortByTitle:
+			return strings.ToLower(products[i].BaseData().Title) < strings.ToLower(products[j].BaseData().Title)
+		case SortByCreatedAt:
+			return products[i].BaseData().CreatedAt.Before(products[j].BaseData().CreatedAt)
+		}
+		return compareAttribute(products[i].BaseData().Attributes[r.sortBy], products[j].BaseData().Attributes[r.sortBy])
+	}
+
+	if r.sortDirection == searchDomain.SortDirectionDescending {
+		sort.SliceStable(products, func(i, j int) bool {
+			return less(j, i)
+		})
+		return
+	}
+	sort.SliceStable(products, less)
+}
+
+func (r *searchRequest) sortOptions() []searchDomain.SortOption {
+	labels := sortLabels
+	isDefaultLabel := false
+	for _, label := range sortLabels {
+		if label == r.sortBy {
+			isDefaultLabel = true
+		}
+	}
+	if r.sortBy != "" && !isDefaultLabel {
+		labels = append(labels[:len(labels):len(labels)], r.sortBy)
+	}
+
+	options := make([]searchDomain.SortOption, 0, len(labels))
+	for _, label := range labels {
+		selected := label == r.sortBy
+		options = append(options, searchDomain.SortOption{
+			Label:        label,
+			Asc:          label,
+			Desc:         label,
+			SelectedAsc:  selected && r.sortDirection != searchDomain.SortDirectionDescending,
+			SelectedDesc: selected && r.sortDirection == searchDomain.SortDirectionDescending,
+		})
+	}
+	return options
+}
+
+// matchesQuery checks if all words of the query are found in the searchable texts of the product or its variants
+func matchesQuery(product domain.BasicProduct, query string) bool {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return true
+	}
+
+	texts := searchableTexts(product.BaseData())
+	texts = append(texts, product.TeaserData().ShortTitle)
+	if configurable, ok := product.(domain.ConfigurableProduct); ok {
+		for _, variant := range configurable.Variants {
+			texts = append(texts, searchableTexts(variant.BasicProductData)...)
+		}
+	}
+	text := strings.ToLower(strings.Join(texts, " "))
+
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func searchableTexts(data domain.BasicProductData) []string {
+	texts := []string{data.Title, data.ShortDescription, data.Description, data.MarketPlaceCode, data.RetailerName}
+	return append(texts, data.Keywords...)
+}
+
+// matchesKeyValues checks if the product data matches one of the values
+func matchesKeyValues(data domain.BasicProductData, key string, values []string) bool {
+	for _, productValue := range productValues(data, key) {
+		for _, value := range values {
+			if strings.EqualFold(productValue, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// productValues returns the values of the key value filters on the key
+func productValues(data domain.BasicProductData, key string) []string {
+	switch key {
+	case "marketplaceCode":
+		return []string{data.MarketPlaceCode}
+	case "retailerCode":
+		return []string{data.RetailerCode}
+	case "category":
+		return categoryCodes(data)
+	}
+	if !data.HasAttribute(key) {
+		return nil
+	}
+	attribute := data.Attributes[key]
+	if attribute.HasMultipleValues() {
+		return attribute.Values()
+	}
+	return []string{attribute.Value()}
+}
+
+// categoryCodes returns the codes of the categories and the parent codes of their paths
+func categoryCodes(data domain.BasicProductData) []string {
+	categories := append([]domain.CategoryTeaser{data.MainCategory}, data.Categories...)
+	var codes []string
+	for _, category := range categories {
+		if category.Code != "" {
+			codes = append(codes, category.Code)
+		}
+		for _, parent := range strings.Split(category.Path, "/") {
+			if parent != "" {
+				codes = append(codes, parent)
+			}
+		}
+	}
+	return codes
+}
+
+// compareAttribute compares numeric values numerically and all other values alphabetically - missing values are sorted last
+func compareAttribute(a, b domain.Attribute) bool {
+	if a.RawValue == nil || b.RawValue == nil {
+		return a.RawValue != nil
+	}
+	aNumber, aErr := strconv.ParseFloat(a.Value(), 64)
+	bNumber, bErr := strconv.ParseFloat(b.Value(), 64)
+	if aErr == nil && bErr == nil {
+		return aNumber < bNumber
+	}
+	return strings.ToLower(a.Value()) < strings.ToLower(b.Value())
+}
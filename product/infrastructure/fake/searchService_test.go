@@ -0,0 +1,82 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+func TestSearchService_SearchTypedFilters(t *testing.T) {
+	price := func(f float64) *float64 {
+		return &f
+	}
+
+	tests := []struct {
+		name      string
+		filters   []searchDomain.Filter
+		wantCodes []string
+	}{
+		{
+			name:      "not filter from url",
+			filters:   searchDomain.NewKeyValueFilters(map[string][]string{"not:brandCode": {"Flamingo"}}),
+			wantCodes: []string{"fake_simple_hat"},
+		},
+		{
+			name:      "or filter from url",
+			filters:   searchDomain.NewKeyValueFilters(map[string][]string{"or:1:brandCode": {"Flamingo"}, "or:1:material": {"straw"}}),
+			wantCodes: []string{"fake_simple", "fake_configurable", "fake_simple_hat"},
+		},
+		{
+			name:      "or filter",
+			filters:   []searchDomain.Filter{searchDomain.NewOrFilter(searchDomain.NewKeyValueFilter("material", []string{"cotton"}), searchDomain.NewKeyValueFilter("material", []string{"straw"}))},
+			wantCodes: []string{"fake_simple", "fake_simple_hat"},
+		},
+		{
+			name:      "range filter on the price",
+			filters:   []searchDomain.Filter{searchDomain.NewRangeFilter("price", price(10), price(30))},
+			wantCodes: []string{"fake_simple"},
+		},
+		{
+			name:      "range filter from url",
+			filters:   searchDomain.NewKeyValueFilters(map[string][]string{"price": {"[,10]"}}),
+			wantCodes: []string{"fake_simple_hat"},
+		},
+		{
+			name:      "tree filter",
+			filters:   []searchDomain.Filter{searchDomain.NewTreeFilter("category", "clothing")},
+			wantCodes: []string{"fake_simple", "fake_configurable"},
+		},
+		{
+			name:      "exists filter",
+			filters:   []searchDomain.Filter{searchDomain.NewExistsFilter("material")},
+			wantCodes: []string{"fake_simple", "fake_simple_hat"},
+		},
+		{
+			name:      "not combined with key value filter",
+			filters:   []searchDomain.Filter{searchDomain.NewKeyValueFilter("category", []string{"clothing"}), searchDomain.NewNotFilter(searchDomain.NewKeyValueFilter("material", []string{"cotton"}))},
+			wantCodes: []string{"fake_configurable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newSearchService(t, 0).Search(context.Background(), tt.filters...)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantCodes, marketplaceCodes(result.Hits))
+			}
+		})
+	}
+}
+
+func TestSearchService_SearchRangeFilterVariant(t *testing.T) {
+	min, max := 44.0, 45.0
+	result, err := newSearchService(t, 0).Search(context.Background(), searchDomain.NewRangeFilter("price", &min, &max))
+	if assert.NoError(t, err) && assert.Len(t, result.Hits, 1) {
+		configurable := result.Hits[0].(domain.ConfigurableProduct)
+		assert.Equal(t, "fake_configurable_blue_44", configurable.TeaserData().PreSelectedVariantSku, "the variant in the range is preselected")
+	}
+}
@@ -0,0 +1,78 @@
+package relations
+
+import (
+	"context"
+	"sort"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// ConfigRelationService is a domain.ProductRelationService that reads the relations from the configuration
+	// commerce.product.relations.configAdapter.relations - a map of marketplace codes to the related marketplace codes per relation type:
+	//
+	//	camera:
+	//	  crossSell: [memorycard]
+	//	  accessory: [camerabag, battery]
+	ConfigRelationService struct {
+		relations map[string]map[string][]string
+	}
+)
+
+var _ domain.ProductRelationService = (*ConfigRelationService)(nil)
+
+// Inject dependencies
+func (s *ConfigRelationService) Inject(
+	logger flamingo.Logger,
+	config *struct {
+		Relations config.Map `inject:"config:commerce.product.relations.configAdapter.relations,optional"`
+	},
+) {
+	if config == nil || config.Relations == nil {
+		return
+	}
+	relations := make(map[string]map[string][]string)
+	if err := config.Relations.MapInto(&relations); err != nil {
+		logger.WithField(flamingo.LogKeyCategory, "configrelationservice").WithField(flamingo.LogKeyModule, "product").Error("product.relations.ConfigRelationService: invalid relations config ", err)
+		return
+	}
+	s.relations = relations
+}
+
+// GetRelations returns the configured relations - ordered by the relation types and then by their configured order
+func (s *ConfigRelationService) GetRelations(_ context.Context, marketplaceCode string, relationTypes ...string) ([]domain.ProductRelation, error) {
+	relationsByType, ok := s.relations[marketplaceCode]
+	if !ok {
+		return nil, nil
+	}
+
+	if len(relationTypes) == 0 {
+		relationTypes = domain.RelationTypes()
+		for relationType := range relationsByType {
+			if !contains(relationTypes, relationType) {
+				relationTypes = append(relationTypes, relationType)
+			}
+		}
+		// custom relation types follow the known ones in a stable order
+		sort.Strings(relationTypes[len(domain.RelationTypes()):])
+	}
+
+	var relations []domain.ProductRelation
+	for _, relationType := range relationTypes {
+		for _, relatedCode := range relationsByType[relationType] {
+			relations = append(relations, domain.ProductRelation{Type: relationType, MarketplaceCode: relatedCode})
+		}
+	}
+	return relations, nil
+}
+
+func contains(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
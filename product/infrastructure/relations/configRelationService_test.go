@@ -0,0 +1,45 @@
+package relations_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/product/infrastructure/relations"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+func TestConfigRelationService_GetRelations(t *testing.T) {
+	service := new(relations.ConfigRelationService)
+	service.Inject(flamingo.NullLogger{}, &struct {
+		Relations config.Map `inject:"config:commerce.product.relations.configAdapter.relations,optional"`
+	}{
+		Relations: config.Map{
+			"camera": config.Map{
+				"accessory": config.Slice{"camerabag", "battery"},
+				"crossSell": config.Slice{"memorycard"},
+				"bundle":    config.Slice{"camera-kit"},
+			},
+		},
+	})
+
+	result, err := service.GetRelations(context.Background(), "camera")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ProductRelation{
+		{Type: domain.RelationTypeCrossSell, MarketplaceCode: "memorycard"},
+		{Type: domain.RelationTypeAccessory, MarketplaceCode: "camerabag"},
+		{Type: domain.RelationTypeAccessory, MarketplaceCode: "battery"},
+		{Type: "bundle", MarketplaceCode: "camera-kit"},
+	}, result)
+
+	result, err = service.GetRelations(context.Background(), "camera", domain.RelationTypeAccessory)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	result, err = service.GetRelations(context.Background(), "unknown")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// APIController returns products as JSON and as data for templates
+	APIController struct {
+		responder         *web.Responder
+		productService    domain.ProductService
+		urlService        *application.URLService
+		variantService    *application.VariantSelectionService
+		visibilityService *application.VisibilityService
+		logger            flamingo.Logger
+	}
+
+	// APIResult is the JSON result of the product api
+	APIResult struct {
+		Success bool        `json:"success"`
+		Error   *APIError   `json:"error,omitempty"`
+		Product *APIProduct `json:"product,omitempty"`
+	}
+
+	// APIVariantSelectionResult is the JSON result of the variant selection api
+	APIVariantSelectionResult struct {
+		Success          bool                 `json:"success"`
+		Error            *APIError            `json:"error,omitempty"`
+		VariantSelection *APIVariantSelection `json:"variantSelection,omitempty"`
+	}
+
+	// APIError contains details if success is false
+	APIError struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	}
+
+	// APIProduct is the stable representation of a domain.BasicProduct - the domain structs are mapped to the tagged API types, so that the json does not change with the domain model
+	APIProduct struct {
+		// Type is the product type (simple, configurable, configurable_with_activevariant, bundle ...)
+		Type            string        `json:"type"`
+		MarketplaceCode string        `json:"marketplaceCode"`
+		URL             string        `json:"url"`
+		IsSaleable      bool          `json:"isSaleable"`
+		IsInStock       bool          `json:"isInStock"`
+		BaseData        APIBaseData   `json:"baseData"`
+		TeaserData      APITeaserData `json:"teaserData"`
+		SaleableData    APISaleable   `json:"saleableData"`
+		// ActiveVariantCode is set for configurables with an active variant
+		ActiveVariantCode string `json:"activeVariantCode,omitempty"`
+		// Variants and VariantSelection are set for configurables
+		Variants         []APIVariant         `json:"variants,omitempty"`
+		VariantSelection *APIVariantSelection `json:"variantSelection,omitempty"`
+		// BundleOptions are set for bundles
+		BundleOptions []APIBundleOption `json:"bundleOptions,omitempty"`
+	}
+
+	// APIVariant is a variant of a configurable product
+	APIVariant struct {
+		MarketplaceCode string      `json:"marketplaceCode"`
+		URL             string      `json:"url"`
+		IsSaleable      bool        `json:"isSaleable"`
+		IsInStock       bool        `json:"isInStock"`
+		BaseData        APIBaseData `json:"baseData"`
+		SaleableData    APISaleable `json:"saleableData"`
+	}
+)
+
+const (
+	apiErrorNotFound   = "product_not_found"
+	apiErrorGeneral    = "get_error"
+	apiErrorNoVariants = "product_not_configurable"
+)
+
+// Inject dependencies
+func (c *APIController) Inject(
+	responder *web.Responder,
+	productService domain.ProductService,
+	urlService *application.URLService,
+	variantService *application.VariantSelectionService,
+	visibilityService *application.VisibilityService,
+	logger flamingo.Logger,
+) {
+	c.responder = responder
+	c.productService = productService
+	c.urlService = urlService
+	c.variantService = variantService
+	c.visibilityService = visibilityService
+	c.logger = logger.WithField(flamingo.LogKeyModule, "product").WithField(flamingo.LogKeyCategory, "controller.APIController")
+}
+
+// GetAction returns the product (with the optional variantcode as active variant) as JSON
+func (c *APIController) GetAction(ctx context.Context, r *web.Request) web.Result {
+	product, err := c.getProduct(ctx, r.Params["marketplacecode"], r.Params["variantcode"])
+	if err != nil {
+		if _, notFound := errors.Cause(err).(domain.ProductNotFound); notFound {
+			return c.responder.Data(APIResult{Error: &APIError{Message: err.Error(), Code: apiErrorNotFound}}).Status(404)
+		}
+		c.logger.WithContext(ctx).Error("product could not be loaded: ", err)
+		return c.responder.Data(APIResult{Error: &APIError{Message: err.Error(), Code: apiErrorGeneral}}).Status(500)
+	}
+
+	return c.responder.Data(APIResult{Success: true, Product: product})
+}
+
+// VariantSelectionAction returns the variant selection of a configurable (with the optional variantcode as active variant) as JSON
+func (c *APIController) VariantSelectionAction(ctx context.Context, r *web.Request) web.Result {
+	product, err := c.getVisibleProduct(ctx, r.Params["marketplacecode"], r.Params["variantcode"])
+	if err != nil {
+		if _, notFound := errors.Cause(err).(domain.ProductNotFound); notFound {
+			return c.responder.Data(APIVariantSelectionResult{Error: &APIError{Message: err.Error(), Code: apiErrorNotFound}}).Status(404)
+		}
+		c.logger.WithContext(ctx).Error("product could not be loaded: ", err)
+		return c.responder.Data(APIVariantSelectionResult{Error: &APIError{Message: err.Error(), Code: apiErrorGeneral}}).Status(500)
+	}
+
+	selection, ok := c.variantService.GetForProduct(product)
+	if !ok {
+		message := fmt.Sprintf("product %q is not configurable", product.BaseData().MarketPlaceCode)
+		return c.responder.Data(APIVariantSelectionResult{Error: &APIError{Message: message, Code: apiErrorNoVariants}}).Status(400)
+	}
+	return c.responder.Data(APIVariantSelectionResult{Success: true, VariantSelection: apiVariantSelection(selection)})
+}
+
+// Data returns the product (with the optional variantcode as active variant) or nil
+func (c *APIController) Data(ctx context.Context, r *web.Request, params web.RequestParams) interface{} {
+	product, err := c.getProduct(ctx, params["marketplacecode"], params["variantcode"])
+	if err != nil {
+		if _, notFound := errors.Cause(err).(domain.ProductNotFound); !notFound {
+			c.logger.WithContext(ctx).Error("product could not be loaded: ", err)
+		}
+		return nil
+	}
+	return product
+}
+
+// getProduct loads the product and maps it to the api representation
+func (c *APIController) getProduct(ctx context.Context, marketplaceCode string, variantCode string) (*APIProduct, error) {
+	product, err := c.getVisibleProduct(ctx, marketplaceCode, variantCode)
+	if err != nil {
+		return nil, err
+	}
+	return c.MapProduct(product), nil
+}
+
+// getVisibleProduct loads the product (with the active variant) - unknown and invisible products and variants are reported as domain.ProductNotFound
+func (c *APIController) getVisibleProduct(ctx context.Context, marketplaceCode string, variantCode string) (domain.BasicProduct, error) {
+	product, err := c.productService.Get(ctx, marketplaceCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.visibilityService.IsVisible(product) {
+		return nil, errors.Wrapf(domain.ProductNotFound{MarketplaceCode: marketplaceCode}, "product %q is not visible", marketplaceCode)
+	}
+
+	if variantCode == "" {
+		return product, nil
+	}
+
+	configurable, ok := product.(domain.ConfigurableProduct)
+	if !ok {
+		return nil, errors.Wrapf(domain.ProductNotFound{MarketplaceCode: variantCode}, "product %q has no variants", marketplaceCode)
+	}
+	withActiveVariant, err := configurable.GetConfigurableWithActiveVariant(variantCode)
+	if err != nil {
+		return nil, errors.Wrap(domain.ProductNotFound{MarketplaceCode: variantCode}, err.Error())
+	}
+	if !c.visibilityService.IsVisible(withActiveVariant) {
+		return nil, errors.Wrapf(domain.ProductNotFound{MarketplaceCode: variantCode}, "variant %q is not visible", variantCode)
+	}
+	return withActiveVariant, nil
+}
+
+// MapProduct maps the product to its stable api representation - e.g. for the product lists of other apis
+func (c *APIController) MapProduct(product domain.BasicProduct) *APIProduct {
+	result := &APIProduct{
+		Type:            product.Type(),
+		MarketplaceCode: product.BaseData().MarketPlaceCode,
+		IsSaleable:      product.IsSaleable(),
+		IsInStock:       product.BaseData().IsInStock(),
+		BaseData:        apiBaseData(product.BaseData()),
+		TeaserData:      apiTeaserData(product.TeaserData()),
+		SaleableData:    apiSaleable(product.SaleableData()),
+	}
+	result.URL, _ = c.urlService.Get(product, "")
+
+	switch p := product.(type) {
+	case domain.ConfigurableProduct:
+		result.Variants = c.apiVariants(p, p.Variants)
+	case domain.ConfigurableProductWithActiveVariant:
+		configurable := domain.ConfigurableProduct{
+			Identifier:                 p.Identifier,
+			BasicProductData:           p.ConfigurableBaseData(),
+			Teaser:                     p.Teaser,
+			VariantVariationAttributes: p.VariantVariationAttributes,
+			Variants:                   p.Variants,
+		}
+		result.MarketplaceCode = p.ConfigurableBaseData().MarketPlaceCode
+		result.ActiveVariantCode = p.ActiveVariant.MarketPlaceCode
+		result.Variants = c.apiVariants(configurable, p.Variants)
+	case domain.BundleProduct:
+		result.IsInStock = p.IsInStock()
+		result.BundleOptions = apiBundleOptions(p.Options)
+	}
+
+	if selection, ok := c.variantService.GetForProduct(product); ok {
+		result.VariantSelection = apiVariantSelection(selection)
+	}
+
+	return result
+}
+
+func (c *APIController) apiVariants(configurable domain.ConfigurableProduct, variants []domain.Variant) []APIVariant {
+	result := make([]APIVariant, 0, len(variants))
+	for _, variant := range variants {
+		url, _ := c.urlService.Get(configurable, variant.MarketPlaceCode)
+		result = append(result, APIVariant{
+			MarketplaceCode: variant.MarketPlaceCode,
+			URL:             url,
+			IsSaleable:      variant.IsSaleable,
+			IsInStock:       variant.IsInStock(),
+			BaseData:        apiBaseData(variant.BasicProductData),
+			SaleableData:    apiSaleable(variant.Saleable),
+		})
+	}
+	return result
+}
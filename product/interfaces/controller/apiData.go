@@ -0,0 +1,369 @@
+package controller
+
+import (
+	"math/big"
+	"time"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// APIBaseData is the stable representation of the domain.BasicProductData
+	APIBaseData struct {
+		Title            string                  `json:"title"`
+		ShortDescription string                  `json:"shortDescription"`
+		Description      string                  `json:"description"`
+		Attributes       map[string]APIAttribute `json:"attributes"`
+		Media            []APIMedia              `json:"media"`
+		RetailerCode     string                  `json:"retailerCode"`
+		RetailerSku      string                  `json:"retailerSku"`
+		RetailerName     string                  `json:"retailerName"`
+		CreatedAt        *time.Time              `json:"createdAt,omitempty"`
+		UpdatedAt        *time.Time              `json:"updatedAt,omitempty"`
+		Categories       []APICategory           `json:"categories"`
+		MainCategory     APICategory             `json:"mainCategory"`
+		StockLevel       string                  `json:"stockLevel"`
+		Keywords         []string                `json:"keywords"`
+		IsNew            bool                    `json:"isNew"`
+	}
+
+	// APIAttribute is a product attribute - Values are set for attributes with multiple values
+	APIAttribute struct {
+		Code     string   `json:"code"`
+		Label    string   `json:"label"`
+		Value    string   `json:"value"`
+		Values   []string `json:"values,omitempty"`
+		UnitCode string   `json:"unitCode,omitempty"`
+	}
+
+	// APIMedia is a product image or other media
+	APIMedia struct {
+		Type      string `json:"type"`
+		MimeType  string `json:"mimeType"`
+		Usage     string `json:"usage"`
+		Title     string `json:"title"`
+		Reference string `json:"reference"`
+	}
+
+	// APICategory is a category of the product
+	APICategory struct {
+		Code string `json:"code"`
+		Path string `json:"path"`
+		Name string `json:"name"`
+	}
+
+	// APITeaserData is the stable representation of the domain.TeaserData
+	APITeaserData struct {
+		ShortTitle             string           `json:"shortTitle"`
+		ShortDescription       string           `json:"shortDescription"`
+		TeaserPrice            APIPriceInfo     `json:"teaserPrice"`
+		TeaserPriceIsFromPrice bool             `json:"teaserPriceIsFromPrice"`
+		PreSelectedVariantSku  string           `json:"preSelectedVariantSku"`
+		Media                  []APIMedia       `json:"media"`
+		MarketplaceCode        string           `json:"marketplaceCode"`
+		TeaserLoyaltyPrice     *APILoyaltyPrice `json:"teaserLoyaltyPrice,omitempty"`
+	}
+
+	// APISaleable is the stable representation of the domain.Saleable
+	APISaleable struct {
+		IsSaleable      bool              `json:"isSaleable"`
+		SaleableFrom    *time.Time        `json:"saleableFrom,omitempty"`
+		SaleableTo      *time.Time        `json:"saleableTo,omitempty"`
+		ActivePrice     APIPriceInfo      `json:"activePrice"`
+		AvailablePrices []APIPriceInfo    `json:"availablePrices"`
+		LoyaltyPrices   []APILoyaltyPrice `json:"loyaltyPrices"`
+	}
+
+	// APIPriceInfo is the stable representation of the domain.PriceInfo - FinalPrice is the discounted or the default price.
+	// The base price fields are set if the product has a base price
+	APIPriceInfo struct {
+		Default           priceDomain.Price `json:"default"`
+		Discounted        priceDomain.Price `json:"discounted"`
+		FinalPrice        priceDomain.Price `json:"finalPrice"`
+		IsDiscounted      bool              `json:"isDiscounted"`
+		DiscountText      string            `json:"discountText"`
+		ActiveBase        float64           `json:"activeBase,omitempty"`
+		ActiveBaseAmount  float64           `json:"activeBaseAmount,omitempty"`
+		ActiveBaseUnit    string            `json:"activeBaseUnit,omitempty"`
+		CampaignRules     []string          `json:"campaignRules"`
+		DenyMoreDiscounts bool              `json:"denyMoreDiscounts"`
+		TaxClass          string            `json:"taxClass"`
+	}
+
+	// APILoyaltyPrice is the stable representation of the domain.LoyaltyPriceInfo
+	APILoyaltyPrice struct {
+		Type             string            `json:"type"`
+		Default          priceDomain.Price `json:"default"`
+		Discounted       priceDomain.Price `json:"discounted"`
+		IsDiscounted     bool              `json:"isDiscounted"`
+		DiscountText     string            `json:"discountText"`
+		MinPointsToSpent float64           `json:"minPointsToSpent"`
+		MaxPointsToSpent *float64          `json:"maxPointsToSpent,omitempty"`
+	}
+
+	// APIBundleOption is an option of a bundle with its choices
+	APIBundleOption struct {
+		Code     string            `json:"code"`
+		Label    string            `json:"label"`
+		Required bool              `json:"required"`
+		Choices  []APIBundleChoice `json:"choices"`
+	}
+
+	// APIBundleChoice is a product that can be chosen for a bundle option
+	APIBundleChoice struct {
+		MarketplaceCode string      `json:"marketplaceCode"`
+		BaseData        APIBaseData `json:"baseData"`
+		SaleableData    APISaleable `json:"saleableData"`
+		Qty             int         `json:"qty"`
+		CanChangeQty    bool        `json:"canChangeQty"`
+		MinQty          int         `json:"minQty"`
+		MaxQty          int         `json:"maxQty"`
+		IsDefault       bool        `json:"isDefault"`
+	}
+
+	// APIVariantSelection is the stable representation of the domain.VariantSelection
+	APIVariantSelection struct {
+		Attributes         []APIVariantSelectionAttribute `json:"attributes"`
+		Variants           []APIVariantSelectionVariant   `json:"variants"`
+		PreselectedVariant string                         `json:"preselectedVariant"`
+		PriceRange         APIPriceRange                  `json:"priceRange"`
+	}
+
+	// APIVariantSelectionAttribute is a variation attribute with its options
+	APIVariantSelectionAttribute struct {
+		Key     string                      `json:"key"`
+		Title   string                      `json:"title"`
+		Options []APIVariantSelectionOption `json:"options"`
+	}
+
+	// APIVariantSelectionOption is a value of a variation attribute
+	APIVariantSelectionOption struct {
+		Key                   string              `json:"key"`
+		Title                 string              `json:"title"`
+		Combinations          map[string][]string `json:"combinations"`
+		AvailableCombinations map[string][]string `json:"availableCombinations"`
+		Selected              bool                `json:"selected"`
+		Preselected           bool                `json:"preselected"`
+		InStock               bool                `json:"inStock"`
+		Saleable              bool                `json:"saleable"`
+		PriceRange            APIPriceRange       `json:"priceRange"`
+	}
+
+	// APIVariantSelectionVariant is a variant with the values of its variation attributes
+	APIVariantSelectionVariant struct {
+		Attributes      map[string]string `json:"attributes"`
+		MarketplaceCode string            `json:"marketplaceCode"`
+		Title           string            `json:"title"`
+		URL             string            `json:"url"`
+		InStock         bool              `json:"inStock"`
+		Saleable        bool              `json:"saleable"`
+		Price           priceDomain.Price `json:"price"`
+	}
+
+	// APIPriceRange is the minimum and maximum price
+	APIPriceRange struct {
+		Min priceDomain.Price `json:"min"`
+		Max priceDomain.Price `json:"max"`
+	}
+)
+
+func apiBaseData(baseData domain.BasicProductData) APIBaseData {
+	result := APIBaseData{
+		Title:            baseData.Title,
+		ShortDescription: baseData.ShortDescription,
+		Description:      baseData.Description,
+		Attributes:       make(map[string]APIAttribute, len(baseData.Attributes)),
+		Media:            apiMedia(baseData.Media),
+		RetailerCode:     baseData.RetailerCode,
+		RetailerSku:      baseData.RetailerSku,
+		RetailerName:     baseData.RetailerName,
+		CreatedAt:        apiTime(baseData.CreatedAt),
+		UpdatedAt:        apiTime(baseData.UpdatedAt),
+		Categories:       make([]APICategory, 0, len(baseData.Categories)),
+		MainCategory:     apiCategory(baseData.MainCategory),
+		StockLevel:       baseData.StockLevel,
+		Keywords:         baseData.Keywords,
+		IsNew:            baseData.IsNew,
+	}
+	if result.Keywords == nil {
+		result.Keywords = []string{}
+	}
+	for code, attribute := range baseData.Attributes {
+		result.Attributes[code] = APIAttribute{
+			Code:     attribute.Code,
+			Label:    attribute.Label,
+			Value:    attribute.Value(),
+			Values:   attribute.Values(),
+			UnitCode: attribute.UnitCode,
+		}
+	}
+	for _, category := range baseData.Categories {
+		result.Categories = append(result.Categories, apiCategory(category))
+	}
+	return result
+}
+
+func apiCategory(category domain.CategoryTeaser) APICategory {
+	return APICategory{Code: category.Code, Path: category.Path, Name: category.Name}
+}
+
+func apiMedia(media []domain.Media) []APIMedia {
+	result := make([]APIMedia, 0, len(media))
+	for _, m := range media {
+		result = append(result, APIMedia{Type: m.Type, MimeType: m.MimeType, Usage: m.Usage, Title: m.Title, Reference: m.Reference})
+	}
+	return result
+}
+
+func apiTeaserData(teaserData domain.TeaserData) APITeaserData {
+	result := APITeaserData{
+		ShortTitle:             teaserData.ShortTitle,
+		ShortDescription:       teaserData.ShortDescription,
+		TeaserPrice:            apiPriceInfo(teaserData.TeaserPrice),
+		TeaserPriceIsFromPrice: teaserData.TeaserPriceIsFromPrice,
+		PreSelectedVariantSku:  teaserData.PreSelectedVariantSku,
+		Media:                  apiMedia(teaserData.Media),
+		MarketplaceCode:        teaserData.MarketPlaceCode,
+	}
+	if teaserData.TeaserLoyaltyPriceInfo != nil {
+		loyaltyPrice := apiLoyaltyPrice(*teaserData.TeaserLoyaltyPriceInfo)
+		result.TeaserLoyaltyPrice = &loyaltyPrice
+	}
+	return result
+}
+
+func apiSaleable(saleable domain.Saleable) APISaleable {
+	result := APISaleable{
+		IsSaleable:      saleable.IsSaleable,
+		SaleableFrom:    apiTime(saleable.SaleableFrom),
+		SaleableTo:      apiTime(saleable.SaleableTo),
+		ActivePrice:     apiPriceInfo(saleable.ActivePrice),
+		AvailablePrices: make([]APIPriceInfo, 0, len(saleable.AvailablePrices)),
+		LoyaltyPrices:   make([]APILoyaltyPrice, 0, len(saleable.LoyaltyPrices)),
+	}
+	for _, price := range saleable.AvailablePrices {
+		result.AvailablePrices = append(result.AvailablePrices, apiPriceInfo(price))
+	}
+	for _, price := range saleable.LoyaltyPrices {
+		result.LoyaltyPrices = append(result.LoyaltyPrices, apiLoyaltyPrice(price))
+	}
+	return result
+}
+
+func apiPriceInfo(priceInfo domain.PriceInfo) APIPriceInfo {
+	result := APIPriceInfo{
+		Default:           priceInfo.Default,
+		Discounted:        priceInfo.Discounted,
+		FinalPrice:        priceInfo.GetFinalPrice(),
+		IsDiscounted:      priceInfo.IsDiscounted,
+		DiscountText:      priceInfo.DiscountText,
+		ActiveBase:        apiFloat(priceInfo.ActiveBase),
+		ActiveBaseAmount:  apiFloat(priceInfo.ActiveBaseAmount),
+		ActiveBaseUnit:    priceInfo.ActiveBaseUnit,
+		CampaignRules:     priceInfo.CampaignRules,
+		DenyMoreDiscounts: priceInfo.DenyMoreDiscounts,
+		TaxClass:          priceInfo.TaxClass,
+	}
+	if result.CampaignRules == nil {
+		result.CampaignRules = []string{}
+	}
+	return result
+}
+
+func apiLoyaltyPrice(loyaltyPrice domain.LoyaltyPriceInfo) APILoyaltyPrice {
+	result := APILoyaltyPrice{
+		Type:             loyaltyPrice.Type,
+		Default:          loyaltyPrice.Default,
+		Discounted:       loyaltyPrice.Discounted,
+		IsDiscounted:     loyaltyPrice.IsDiscounted,
+		DiscountText:     loyaltyPrice.DiscountText,
+		MinPointsToSpent: apiFloat(loyaltyPrice.MinPointsToSpent),
+	}
+	if loyaltyPrice.MaxPointsToSpent != nil {
+		maxPoints := apiFloat(*loyaltyPrice.MaxPointsToSpent)
+		result.MaxPointsToSpent = &maxPoints
+	}
+	return result
+}
+
+func apiBundleOptions(options []domain.BundleOption) []APIBundleOption {
+	result := make([]APIBundleOption, 0, len(options))
+	for _, option := range options {
+		apiOption := APIBundleOption{
+			Code:     option.Code,
+			Label:    option.Label,
+			Required: option.Required,
+			Choices:  make([]APIBundleChoice, 0, len(option.Choices)),
+		}
+		for _, choice := range option.Choices {
+			apiOption.Choices = append(apiOption.Choices, APIBundleChoice{
+				MarketplaceCode: choice.MarketPlaceCode,
+				BaseData:        apiBaseData(choice.BasicProductData),
+				SaleableData:    apiSaleable(choice.Saleable),
+				Qty:             choice.Qty,
+				CanChangeQty:    choice.CanChangeQty,
+				MinQty:          choice.MinQty,
+				MaxQty:          choice.MaxQty,
+				IsDefault:       choice.IsDefault,
+			})
+		}
+		result = append(result, apiOption)
+	}
+	return result
+}
+
+func apiVariantSelection(selection domain.VariantSelection) *APIVariantSelection {
+	result := &APIVariantSelection{
+		Attributes:         make([]APIVariantSelectionAttribute, 0, len(selection.Attributes)),
+		Variants:           make([]APIVariantSelectionVariant, 0, len(selection.Variants)),
+		PreselectedVariant: selection.PreselectedVariant,
+		PriceRange:         APIPriceRange{Min: selection.PriceRange.Min, Max: selection.PriceRange.Max},
+	}
+	for _, attribute := range selection.Attributes {
+		apiAttribute := APIVariantSelectionAttribute{
+			Key:     attribute.Key,
+			Title:   attribute.Title,
+			Options: make([]APIVariantSelectionOption, 0, len(attribute.Options)),
+		}
+		for _, option := range attribute.Options {
+			apiAttribute.Options = append(apiAttribute.Options, APIVariantSelectionOption{
+				Key:                   option.Key,
+				Title:                 option.Title,
+				Combinations:          option.Combinations,
+				AvailableCombinations: option.AvailableCombinations,
+				Selected:              option.Selected,
+				Preselected:           option.Preselected,
+				InStock:               option.InStock,
+				Saleable:              option.Saleable,
+				PriceRange:            APIPriceRange{Min: option.PriceRange.Min, Max: option.PriceRange.Max},
+			})
+		}
+		result.Attributes = append(result.Attributes, apiAttribute)
+	}
+	for _, variant := range selection.Variants {
+		result.Variants = append(result.Variants, APIVariantSelectionVariant{
+			Attributes:      variant.Attributes,
+			MarketplaceCode: variant.Marketplacecode,
+			Title:           variant.Title,
+			URL:             variant.URL,
+			InStock:         variant.InStock,
+			Saleable:        variant.Saleable,
+			Price:           variant.Price,
+		})
+	}
+	return result
+}
+
+// apiTime returns nil for the zero time, so that unset dates are left out
+func apiTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func apiFloat(f big.Float) float64 {
+	result, _ := f.Float64()
+	return result
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type notFoundProductService struct {
+	MockProductService
+}
+
+func (nps *notFoundProductService) Get(ctx context.Context, marketplacecode string) (domain.BasicProduct, error) {
+	if marketplacecode == "unknown" {
+		return nil, domain.ProductNotFound{MarketplaceCode: marketplacecode}
+	}
+	if marketplacecode == "scheduled" {
+		return domain.SimpleProduct{
+			BasicProductData: domain.BasicProductData{MarketPlaceCode: marketplacecode, VisibleFrom: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil
+	}
+	return nps.MockProductService.Get(ctx, marketplacecode)
+}
+
+func newVisibilityService(enforce bool, now time.Time) *application.VisibilityService {
+	service := new(application.VisibilityService)
+	service.Inject(domain.FixedClock{Time: now}, &struct {
+		EnforceVisibility bool `inject:"config:commerce.product.visibility.enforce,optional"`
+	}{EnforceVisibility: enforce})
+	return service
+}
+
+func newVariantSelectionService() *application.VariantSelectionService {
+	service := new(application.VariantSelectionService)
+	service.Inject(nil, domain.SystemClock{})
+	return service
+}
+
+func TestAPIController_GetActionNotFound(t *testing.T) {
+	controller := new(APIController)
+	controller.Inject(new(web.Responder), new(notFoundProductService), new(application.URLService), newVariantSelectionService(), newVisibilityService(false, time.Now()), flamingo.NullLogger{})
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		status uint
+	}{
+		{name: "unknown product", params: map[string]string{"marketplacecode": "unknown"}, status: http.StatusNotFound},
+		{name: "unknown variant", params: map[string]string{"marketplacecode": "configurable", "variantcode": "unknown"}, status: http.StatusNotFound},
+		{name: "variant of simple product", params: map[string]string{"marketplacecode": "simple", "variantcode": "simple_1"}, status: http.StatusNotFound},
+		{name: "service error", params: map[string]string{"marketplacecode": "fail"}, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := web.CreateRequest(nil, nil)
+			request.Params = tt.params
+
+			result := controller.GetAction(context.Background(), request)
+			response, ok := result.(*web.DataResponse)
+			assert.True(t, ok)
+			assert.Equal(t, tt.status, response.Response.Status)
+			assert.False(t, response.Data.(APIResult).Success)
+
+			assert.Nil(t, controller.Data(context.Background(), request, tt.params))
+		})
+	}
+}
+
+func TestAPIController_GetActionJSON(t *testing.T) {
+	controller := new(APIController)
+	controller.Inject(new(web.Responder), new(notFoundProductService), new(application.URLService), newVariantSelectionService(), newVisibilityService(false, time.Now()), flamingo.NullLogger{})
+
+	request := web.CreateRequest(nil, nil)
+	request.Params = map[string]string{"marketplacecode": "configurable"}
+
+	response := controller.GetAction(context.Background(), request).(*web.DataResponse)
+	assert.Equal(t, uint(http.StatusOK), response.Response.Status)
+
+	body, err := json.Marshal(response.Data)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var result struct {
+		Success bool                       `json:"success"`
+		Product map[string]json.RawMessage `json:"product"`
+	}
+	if !assert.NoError(t, json.Unmarshal(body, &result)) {
+		return
+	}
+	assert.True(t, result.Success)
+	for _, key := range []string{"type", "marketplaceCode", "url", "isSaleable", "isInStock", "baseData", "teaserData", "saleableData", "variants", "variantSelection"} {
+		assert.Contains(t, result.Product, key)
+	}
+
+	var baseData map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(result.Product["baseData"], &baseData))
+	assert.JSONEq(t, `"My Configurable Product Title"`, string(baseData["title"]))
+	for _, key := range []string{"shortDescription", "description", "attributes", "media", "categories", "mainCategory", "keywords", "isNew"} {
+		assert.Contains(t, baseData, key)
+	}
+	assert.NotContains(t, baseData, "Title", "the domain field names are not exposed")
+
+	var saleableData map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(result.Product["saleableData"], &saleableData))
+	for _, key := range []string{"isSaleable", "activePrice", "availablePrices", "loyaltyPrices"} {
+		assert.Contains(t, saleableData, key)
+	}
+	var activePrice map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(saleableData["activePrice"], &activePrice))
+	for _, key := range []string{"default", "discounted", "finalPrice", "isDiscounted"} {
+		assert.Contains(t, activePrice, key)
+	}
+
+	var variantSelection map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(result.Product["variantSelection"], &variantSelection))
+	for _, key := range []string{"attributes", "variants", "preselectedVariant", "priceRange"} {
+		assert.Contains(t, variantSelection, key)
+	}
+
+	var variants []map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(result.Product["variants"], &variants))
+	if assert.Len(t, variants, 1) {
+		assert.JSONEq(t, `"configurable_1"`, string(variants[0]["marketplaceCode"]))
+	}
+}
+
+func TestAPIController_Visibility(t *testing.T) {
+	controller := new(APIController)
+	controller.Inject(new(web.Responder), new(notFoundProductService), new(application.URLService), newVariantSelectionService(), newVisibilityService(true, time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)), flamingo.NullLogger{})
+
+	request := web.CreateRequest(nil, nil)
+	request.Params = map[string]string{"marketplacecode": "scheduled"}
+
+	response := controller.GetAction(context.Background(), request).(*web.DataResponse)
+	assert.Equal(t, uint(http.StatusNotFound), response.Response.Status, "scheduled products are hidden before the launch")
+
+	controller.visibilityService = newVisibilityService(true, time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC))
+	response = controller.GetAction(context.Background(), request).(*web.DataResponse)
+	assert.Equal(t, uint(http.StatusOK), response.Response.Status)
+
+	controller.visibilityService = newVisibilityService(false, time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC))
+	response = controller.GetAction(context.Background(), request).(*web.DataResponse)
+	assert.Equal(t, uint(http.StatusOK), response.Response.Status, "visibility is not checked if not enforced")
+}
+
+func TestAPIController_VariantSelectionAction(t *testing.T) {
+	controller := new(APIController)
+	controller.Inject(new(web.Responder), new(notFoundProductService), new(application.URLService), newVariantSelectionService(), newVisibilityService(false, time.Now()), flamingo.NullLogger{})
+
+	tests := []struct {
+		name    string
+		params  map[string]string
+		status  uint
+		variant string
+	}{
+		{name: "configurable", params: map[string]string{"marketplacecode": "configurable"}, status: http.StatusOK},
+		{name: "active variant", params: map[string]string{"marketplacecode": "configurable", "variantcode": "configurable_1"}, status: http.StatusOK, variant: "configurable_1"},
+		{name: "unknown variant", params: map[string]string{"marketplacecode": "configurable", "variantcode": "unknown"}, status: http.StatusNotFound},
+		{name: "simple product", params: map[string]string{"marketplacecode": "simple"}, status: http.StatusBadRequest},
+		{name: "service error", params: map[string]string{"marketplacecode": "fail"}, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := web.CreateRequest(nil, nil)
+			request.Params = tt.params
+
+			response := controller.VariantSelectionAction(context.Background(), request).(*web.DataResponse)
+			assert.Equal(t, tt.status, response.Response.Status)
+
+			result := response.Data.(APIVariantSelectionResult)
+			assert.Equal(t, tt.status == http.StatusOK, result.Success)
+			if result.Success {
+				assert.Len(t, result.VariantSelection.Variants, 1)
+				assert.Equal(t, tt.variant, result.VariantSelection.PreselectedVariant)
+
+				body, err := json.Marshal(result)
+				assert.NoError(t, err)
+				var selection struct {
+					VariantSelection map[string]json.RawMessage `json:"variantSelection"`
+				}
+				assert.NoError(t, json.Unmarshal(body, &selection))
+				for _, key := range []string{"attributes", "variants", "preselectedVariant", "priceRange"} {
+					assert.Contains(t, selection.VariantSelection, key)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchHitMapper_MapHit(t *testing.T) {
+	controller := new(APIController)
+	controller.Inject(new(web.Responder), new(notFoundProductService), new(application.URLService), newVariantSelectionService(), newVisibilityService(false, time.Now()), flamingo.NullLogger{})
+	mapper := new(SearchHitMapper)
+	mapper.Inject(controller)
+
+	assert.Equal(t, "product", mapper.DocumentType())
+
+	hit, err := mapper.MapHit(context.Background(), domain.SimpleProduct{BasicProductData: domain.BasicProductData{MarketPlaceCode: "simple"}})
+	assert.NoError(t, err)
+	if product, ok := hit.(*APIProduct); assert.True(t, ok) {
+		assert.Equal(t, "simple", product.MarketplaceCode)
+		assert.Equal(t, domain.TypeSimple, product.Type)
+	}
+
+	_, err = mapper.MapHit(context.Background(), "no product")
+	assert.Error(t, err)
+}
@@ -3,10 +3,10 @@ package controller
 import (
 	"context"
 	"net/url"
-	"strings"
 
 	"flamingo.me/flamingo-commerce/v3/product/application"
 	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/flamingo/v3/framework/web"
 	"github.com/pkg/errors"
 )
@@ -16,10 +16,20 @@ type (
 	View struct {
 		Responder             *web.Responder `inject:""`
 		domain.ProductService `inject:""`
-		URLService            *application.URLService `inject:""`
+		URLService            *application.URLService      `inject:""`
+		RelationService       *application.RelationService `inject:""`
+		Logger                flamingo.Logger              `inject:""`
+
+		VariantSelectionService *application.VariantSelectionService `inject:""`
 
 		Template string      `inject:"config:commerce.product.view.template"`
 		Router   *web.Router `inject:""`
+
+		// VisibilityService hides products outside of their visibility window
+		VisibilityService *application.VisibilityService `inject:""`
+
+		// EventRouter is used to dispatch the domain.ProductViewedEvent
+		EventRouter flamingo.EventRouter `inject:",optional"`
 	}
 
 	// productViewData is used for product rendering
@@ -28,146 +38,13 @@ type (
 		RenderContext    string
 		Product          domain.BasicProduct
 		VariantSelected  bool
-		VariantSelection variantSelection
+		VariantSelection domain.VariantSelection
 		BackURL          string
-	}
-
-	// variantSelection for templating
-	variantSelection struct {
-		Attributes []viewVariantAttribute
-		Variants   []viewVariant
-	}
-
-	viewVariantAttribute struct {
-		Key     string
-		Title   string
-		Options []viewVariantOption
-	}
-
-	viewVariantOption struct {
-		Key          string
-		Title        string
-		Combinations map[string][]string
-		Selected     bool
-	}
-
-	viewVariant struct {
-		Attributes      map[string]string
-		Marketplacecode string
-		Title           string
-		URL             string
-		InStock         bool
+		// RelatedProducts contains the related products (cross-sell, up-sell, accessories) indexed by relation type
+		RelatedProducts map[string][]domain.BasicProduct
 	}
 )
 
-func (vc *View) variantSelection(configurable domain.ConfigurableProduct, activeVariant *domain.Variant) variantSelection {
-	var variants variantSelection
-	combinations := make(map[string]map[string]map[string]map[string]bool)
-	titles := make(map[string]map[string]string)
-
-	combinationsOrder := make(map[string][]string)
-
-	for _, attribute := range configurable.VariantVariationAttributes {
-		// attribute -> value -> combinableAttribute -> combinableValue -> true
-		combinations[attribute] = make(map[string]map[string]map[string]bool)
-		titles[attribute] = make(map[string]string)
-
-		for _, variant := range configurable.Variants {
-			titles[attribute][variant.Attributes[attribute].Value()] = variant.Attributes[attribute].Label
-
-			for _, subattribute := range configurable.VariantVariationAttributes {
-				if _, ok := variant.Attributes[attribute]; !ok {
-					continue
-				}
-				if combinations[attribute][variant.Attributes[attribute].Value()] == nil {
-					combinations[attribute][variant.Attributes[attribute].Value()] = make(map[string]map[string]bool)
-					combinationsOrder[attribute] = append(combinationsOrder[attribute], variant.Attributes[attribute].Value())
-				}
-
-				//titles[variant.Attributes[subattribute].Value()] = variant.Attributes[subattribute].Label
-				if subattribute != attribute {
-					if _, ok := variant.Attributes[subattribute]; ok {
-						if combinations[attribute][variant.Attributes[attribute].Value()][subattribute] == nil {
-							combinations[attribute][variant.Attributes[attribute].Value()][subattribute] = make(map[string]bool)
-						}
-						combinations[attribute][variant.Attributes[attribute].Value()][subattribute][variant.Attributes[subattribute].Value()] = true
-					}
-				}
-			}
-		}
-	}
-
-	for _, code := range configurable.VariantVariationAttributes {
-		attribute := combinations[code]
-
-		viewVariantAttribute := viewVariantAttribute{
-			Key:   code,
-			Title: strings.Title(code),
-		}
-
-		options := append([]string{}, configurable.VariantVariationAttributesSorting[viewVariantAttribute.Key]...)
-		options = append(options, combinationsOrder[viewVariantAttribute.Key]...)
-		knownOption := make(map[string]struct{}, len(options))
-
-		for _, optionCode := range options {
-			option, ok := attribute[optionCode]
-			if _, known := knownOption[optionCode]; !ok || known {
-				continue
-			}
-			knownOption[optionCode] = struct{}{}
-
-			combinations := make(map[string][]string)
-			for cattr, cvalues := range option {
-				for cvalue := range cvalues {
-					combinations[cattr] = append(combinations[cattr], cvalue)
-				}
-			}
-
-			var selected bool
-			if activeVariant != nil && activeVariant.Attributes[code].Value() == optionCode {
-				selected = true
-			}
-
-			label, ok := titles[code][optionCode]
-			if !ok || label == "" {
-				label = strings.Title(optionCode)
-			}
-			viewVariantAttribute.Options = append(viewVariantAttribute.Options, viewVariantOption{
-				Key:          optionCode,
-				Title:        label,
-				Selected:     selected,
-				Combinations: combinations,
-			})
-		}
-
-		variants.Attributes = append(variants.Attributes, viewVariantAttribute)
-	}
-
-	for _, variant := range configurable.Variants {
-		urlName := web.URLTitle(variant.BasicProductData.Title)
-		variantURL, _ := vc.Router.URL("product.view", map[string]string{"marketplacecode": configurable.MarketPlaceCode, "variantcode": variant.MarketPlaceCode, "name": urlName})
-
-		attributes := make(map[string]string)
-
-		for _, attr := range variants.Attributes {
-			if !variant.HasAttribute(attr.Key) {
-				continue
-			}
-			attributes[attr.Key] = variant.Attributes[attr.Key].Value()
-		}
-
-		variants.Variants = append(variants.Variants, viewVariant{
-			Title:           variant.Title,
-			Marketplacecode: variant.MarketPlaceCode,
-			URL:             variantURL.String(),
-			Attributes:      attributes,
-			InStock:         variant.IsInStock(),
-		})
-	}
-
-	return variants
-}
-
 // Get Response for Product matching sku param
 func (vc *View) Get(c context.Context, r *web.Request) web.Result {
 	product, err := vc.ProductService.Get(c, r.Params["marketplacecode"])
@@ -184,12 +61,16 @@ func (vc *View) Get(c context.Context, r *web.Request) web.Result {
 		}
 	}
 
+	if !vc.VisibilityService.IsVisible(product) {
+		return vc.Responder.NotFound(errors.Wrap(domain.ProductNotFound{MarketplaceCode: product.BaseData().MarketPlaceCode}, "product is not visible"))
+	}
+
 	var viewData productViewData
 
 	// 1. Handle Configurables
 	if product.Type() == domain.TypeConfigurable {
 		configurableProduct := product.(domain.ConfigurableProduct)
-		var activeVariant *domain.Variant
+		var activeVariantCode string
 
 		viewData = productViewData{}
 		variantCode, ok := r.Params["variantcode"]
@@ -209,7 +90,10 @@ func (vc *View) Get(c context.Context, r *web.Request) web.Result {
 			if err != nil {
 				return vc.Responder.NotFound(err)
 			}
-			activeVariant = &configurableProductWithActiveVariant.ActiveVariant
+			if !vc.VisibilityService.IsVisible(configurableProductWithActiveVariant) {
+				return vc.Responder.NotFound(errors.Wrap(domain.ProductNotFound{MarketplaceCode: variantCode}, "variant is not visible"))
+			}
+			activeVariantCode = configurableProductWithActiveVariant.ActiveVariant.MarketPlaceCode
 			//Redirect if url is not canonical
 			redirect := vc.getRedirectIfRequired(configurableProductWithActiveVariant, r, skipnamecheck)
 			if redirect != nil {
@@ -219,7 +103,7 @@ func (vc *View) Get(c context.Context, r *web.Request) web.Result {
 			viewData.RenderContext = "configurable_with_activevariant"
 			viewData.Product = configurableProductWithActiveVariant
 		}
-		viewData.VariantSelection = vc.variantSelection(configurableProduct, activeVariant)
+		viewData.VariantSelection = vc.VariantSelectionService.Get(configurableProduct, activeVariantCode)
 
 	} else {
 		//Redirect if url is not canonical
@@ -228,9 +112,16 @@ func (vc *View) Get(c context.Context, r *web.Request) web.Result {
 			return redirect
 		}
 
-		// 2. Handle Simples
-		simpleProduct := product.(domain.SimpleProduct)
-		viewData = productViewData{Product: simpleProduct, RenderContext: "simple"}
+		// 2. Handle Simples (and other product types like bundles)
+		viewData = productViewData{Product: product, RenderContext: product.Type()}
+	}
+
+	if vc.RelationService != nil {
+		relatedProducts, err := vc.RelationService.GetRelatedProductsByType(c, product.BaseData().MarketPlaceCode, 0)
+		if err != nil {
+			vc.Logger.WithContext(c).Error("product.controller.View: related products could not be loaded ", err)
+		}
+		viewData.RelatedProducts = relatedProducts
 	}
 
 	backURL, err := r.Query1("backurl")
@@ -238,6 +129,14 @@ func (vc *View) Get(c context.Context, r *web.Request) web.Result {
 		viewData.BackURL = backURL
 	}
 
+	if vc.EventRouter != nil {
+		vc.EventRouter.Dispatch(c, &domain.ProductViewedEvent{
+			MarketplaceCode:        product.BaseData().MarketPlaceCode,
+			VariantMarketplaceCode: r.Params["variantcode"],
+			Product:                viewData.Product,
+		})
+	}
+
 	return vc.Responder.Render(vc.Template, viewData)
 }
 
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	searchApplication "flamingo.me/flamingo-commerce/v3/search/application"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type (
+	// SearchHitMapper returns the product hits of the search api as APIProduct
+	SearchHitMapper struct {
+		apiController *APIController
+	}
+)
+
+// SearchDocumentTypeProduct is the document type of the product search results
+const SearchDocumentTypeProduct = "product"
+
+var _ searchApplication.APIHitMapper = (*SearchHitMapper)(nil)
+
+// Inject dependencies
+func (m *SearchHitMapper) Inject(apiController *APIController) {
+	m.apiController = apiController
+}
+
+// DocumentType returns the product document type
+func (m *SearchHitMapper) DocumentType() string {
+	return SearchDocumentTypeProduct
+}
+
+// MapHit maps the product to the APIProduct
+func (m *SearchHitMapper) MapHit(_ context.Context, document searchDomain.Document) (interface{}, error) {
+	product, ok := document.(domain.BasicProduct)
+	if !ok {
+		return nil, errors.Errorf("search hit of type %T is no product", document)
+	}
+	return m.apiController.MapProduct(product), nil
+}
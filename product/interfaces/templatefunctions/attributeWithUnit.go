@@ -0,0 +1,24 @@
+package templatefunctions
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// AttributeWithUnit is exported as a template function
+	AttributeWithUnit struct{}
+)
+
+// Func returns the value of an attribute together with the symbol of its unit (e.g. "1.5 kg").
+// Without a unit code the value is normalized into a human readable unit of its family (e.g. 1500 GRAM to 1.5 kg),
+// otherwise it is converted into the given unit. Attributes without a numeric value or unit are returned as they are
+func (tf *AttributeWithUnit) Func(ctx context.Context) interface{} {
+	return func(attribute domain.Attribute, unitCode ...string) string {
+		if len(unitCode) > 0 {
+			return attribute.FormatWithUnit(unitCode[0])
+		}
+		return attribute.FormatWithUnit("")
+	}
+}
@@ -0,0 +1,19 @@
+package templatefunctions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+func TestAttributeWithUnit_Func(t *testing.T) {
+	attributeWithUnit := new(AttributeWithUnit).Func(context.Background()).(func(domain.Attribute, ...string) string)
+
+	assert.Equal(t, "1.5 kg", attributeWithUnit(domain.Attribute{RawValue: 1500.0, UnitCode: domain.GRAM}))
+	assert.Equal(t, "1500 g", attributeWithUnit(domain.Attribute{RawValue: "1.5", UnitCode: domain.KILOGRAM}, domain.GRAM))
+	assert.Equal(t, "12 in", attributeWithUnit(domain.Attribute{RawValue: 12, UnitCode: domain.INCH}, "UNKNOWN"))
+	assert.Equal(t, "red", attributeWithUnit(domain.Attribute{RawValue: "red"}))
+}
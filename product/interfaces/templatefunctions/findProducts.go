@@ -3,12 +3,14 @@ package templatefunctions
 import (
 	"context"
 	"log"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"flamingo.me/flamingo/v3/framework/web"
 
 	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/utils"
 
 	"flamingo.me/flamingo-commerce/v3/product/application"
 	searchApplication "flamingo.me/flamingo-commerce/v3/search/application"
@@ -25,9 +27,15 @@ type (
 		buildSearchRequest searchApplication.SearchRequest
 		whiteList          []string
 		blackList          []string
+		namespace          string
+		cursorMode         bool
+		requestURL         *url.URL
 	}
 )
 
+// paginationModeCursor replaces the page navigation with the cursor links for "load more" buttons
+const paginationModeCursor = "cursor"
+
 // Func defines the find products function
 func (tf *FindProducts) Func(ctx context.Context) interface{} {
 
@@ -84,6 +92,14 @@ func newFilterProcessing(request *web.Request, namespace string, searchConfig, k
 	if err == nil {
 		searchRequest.PageSize = pageSize
 	}
+	filterProcessing.namespace = namespace
+	filterProcessing.cursorMode = searchConfig["paginationMode"] == paginationModeCursor
+	if filterProcessing.cursorMode {
+		searchRequest.Cursor = searchConfig["cursor"]
+	}
+	if request != nil {
+		filterProcessing.requestURL = request.Request().URL
+	}
 
 	for k, v := range keyValueFilters {
 		searchRequest.AddAdditionalFilter(domain.NewKeyValueFilter(k, []string{v}))
@@ -122,7 +138,15 @@ func newFilterProcessing(request *web.Request, namespace string, searchConfig, k
 			filterKey = splitted[0]
 		}
 
-		if filterProcessing.isAllowed(filterKey) {
+		if !filterProcessing.isAllowed(filterKey) {
+			continue
+		}
+		switch {
+		case filterKey == domain.CursorParameter:
+			searchRequest.Cursor = v[0]
+		case filterKey == "page" && filterProcessing.cursorMode:
+			continue
+		default:
 			searchRequest.SetAdditionalFilter(domain.NewKeyValueFilter(filterKey, v))
 		}
 	}
@@ -149,9 +173,36 @@ func (f *filterProcessing) modifyResult(result *application.SearchResult) *appli
 	}
 	result.SearchMeta.SelectedFacets = newSelectedFacets
 
+	if f.cursorMode {
+		result.PaginationInfo = utils.PaginationInfo{
+			TotalHits:    result.PaginationInfo.TotalHits,
+			LoadMore:     f.cursorPage(result.SearchMeta.NextCursor),
+			LoadPrevious: f.cursorPage(result.SearchMeta.PreviousCursor),
+		}
+	}
+
 	return result
 }
 
+// cursorPage returns the link to the cursor with the namespaced url parameter
+func (f *filterProcessing) cursorPage(cursor string) utils.CursorPage {
+	if cursor == "" {
+		return utils.CursorPage{}
+	}
+
+	prefix := ""
+	if f.namespace != "" {
+		prefix = f.namespace + "."
+	}
+	query := url.Values{}
+	if f.requestURL != nil {
+		query = f.requestURL.Query()
+	}
+	query.Del(prefix + "page")
+	query.Set(prefix+domain.CursorParameter, cursor)
+	return utils.CursorPage{Cursor: cursor, URL: (&url.URL{RawQuery: query.Encode()}).String()}
+}
+
 // isAllowed - checks the given key against the defined whitelist and blacklist (whitelist prefered)
 func (f *filterProcessing) isAllowed(key string) bool {
 	if len(f.whiteList) > 0 {
@@ -3,6 +3,7 @@ package templatefunctions
 import (
 	"flamingo.me/flamingo-commerce/v3/product/application"
 	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/utils"
 	"flamingo.me/flamingo/v3/framework/web"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -82,6 +83,25 @@ func TestFilterProcessingModifyResultOnlyKeepWhitelisted(t *testing.T) {
 	assert.Equal(t, newResult.Facets["allowed"].Name, "allowed")
 }
 
+// TestFilterProcessingCursorMode - Test the cursor of the url and the load more links
+func TestFilterProcessingCursorMode(t *testing.T) {
+	requestURL, _ := url.Parse("/list?list.cursor=abc&list.page=2&list.color=red")
+	webRequest := web.CreateRequest(&http.Request{URL: requestURL}, nil)
+
+	filterProcessing := newFilterProcessing(webRequest, "list", map[string]string{"paginationMode": "cursor"}, nil, map[string]string{})
+	assert.Equal(t, "abc", filterProcessing.buildSearchRequest.Cursor)
+	assert.Equal(t, []domain.Filter{domain.NewKeyValueFilter("color", []string{"red"})}, filterProcessing.buildSearchRequest.AdditionalFilter, "the page is ignored in cursor mode")
+
+	searchResult := buildSearchResult()
+	searchResult.SearchMeta.NextCursor = "def"
+	searchResult.PaginationInfo = utils.PaginationInfo{TotalHits: 5, PageNavigation: []utils.Page{{Page: 1}}}
+	newResult := filterProcessing.modifyResult(searchResult)
+	assert.Equal(t, utils.PaginationInfo{
+		TotalHits: 5,
+		LoadMore:  utils.CursorPage{Cursor: "def", URL: "?list.color=red&list.cursor=def"},
+	}, newResult.PaginationInfo)
+}
+
 // helper function for test cases
 func buildSearchResult() *application.SearchResult {
 	searchResult := application.SearchResult{}
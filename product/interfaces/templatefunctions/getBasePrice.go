@@ -0,0 +1,41 @@
+package templatefunctions
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// GetBasePrice is exported as a template function
+	GetBasePrice struct {
+		contentAttribute string
+	}
+)
+
+// Inject dependencies
+func (tf *GetBasePrice) Inject(
+	config *struct {
+		ContentAttribute string `inject:"config:commerce.product.basePrice.contentAttribute,optional"`
+	},
+) {
+	if config != nil {
+		tf.contentAttribute = config.ContentAttribute
+	}
+}
+
+// Func returns the base price (e.g. "1.99 € / 100 g") of the active price of a product - or nil if the product has none.
+// The content is read from the configured content attribute (e.g. "netContent" with the value 250 and unit GRAM)
+func (tf *GetBasePrice) Func(ctx context.Context) interface{} {
+	return func(product domain.BasicProduct) *domain.BasePrice {
+		if product == nil {
+			return nil
+		}
+		content := product.BaseData().Attributes[tf.contentAttribute]
+		basePrice, err := product.SaleableData().ActivePrice.BasePrice(content)
+		if err != nil {
+			return nil
+		}
+		return basePrice
+	}
+}
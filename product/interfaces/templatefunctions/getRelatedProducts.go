@@ -0,0 +1,32 @@
+package templatefunctions
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// GetRelatedProducts is exported as a template function
+	GetRelatedProducts struct {
+		RelationService *application.RelationService `inject:""`
+		Logger          flamingo.Logger              `inject:""`
+	}
+)
+
+// Func returns the related products of a product - optionally restricted to one relation type (e.g. "accessory")
+func (tf *GetRelatedProducts) Func(ctx context.Context) interface{} {
+	return func(marketplaceCode string, relationType ...string) []domain.BasicProduct {
+		var relation string
+		if len(relationType) > 0 {
+			relation = relationType[0]
+		}
+		products, err := tf.RelationService.GetRelatedProducts(ctx, marketplaceCode, relation, 0)
+		if err != nil {
+			tf.Logger.WithContext(ctx).WithField("category", "product").Error(err)
+		}
+		return products
+	}
+}
@@ -0,0 +1,34 @@
+package templatefunctions
+
+import (
+	"context"
+	"encoding/json"
+
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// ProductJSONLD is exported as a template function
+	ProductJSONLD struct {
+		StructuredDataBuilder application.StructuredDataBuilder `inject:""`
+		Logger                flamingo.Logger                   `inject:""`
+	}
+)
+
+// Func returns the schema.org structured data of the product as JSON-LD string - to be used in a script tag of type "application/ld+json"
+func (tf *ProductJSONLD) Func(ctx context.Context) interface{} {
+	return func(product domain.BasicProduct) string {
+		data := tf.StructuredDataBuilder.BuildProduct(ctx, product)
+		if data == nil {
+			return ""
+		}
+		result, err := json.Marshal(data)
+		if err != nil {
+			tf.Logger.WithContext(ctx).WithField("category", "product").Error(err)
+			return ""
+		}
+		return string(result)
+	}
+}
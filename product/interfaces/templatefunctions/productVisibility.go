@@ -0,0 +1,43 @@
+package templatefunctions
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// IsProductVisible is exported as a template function
+	IsProductVisible struct {
+		clock domain.Clock
+	}
+
+	// IsProductSaleable is exported as a template function
+	IsProductSaleable struct {
+		clock domain.Clock
+	}
+)
+
+// Inject dependencies
+func (tf *IsProductVisible) Inject(clock domain.Clock) {
+	tf.clock = clock
+}
+
+// Func checks the visibility window of the product (and of the active variant of configurables)
+func (tf *IsProductVisible) Func(ctx context.Context) interface{} {
+	return func(product domain.BasicProduct) bool {
+		return domain.IsProductVisibleAt(product, tf.clock.Now())
+	}
+}
+
+// Inject dependencies
+func (tf *IsProductSaleable) Inject(clock domain.Clock) {
+	tf.clock = clock
+}
+
+// Func checks the saleable flag and the saleable window of the product (for configurables of the active variant)
+func (tf *IsProductSaleable) Func(ctx context.Context) interface{} {
+	return func(product domain.BasicProduct) bool {
+		return domain.IsProductSaleableAt(product, tf.clock.Now())
+	}
+}
@@ -0,0 +1,31 @@
+package templatefunctions
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+type (
+	// VariantSelection is exported as a template function
+	VariantSelection struct {
+		variantSelectionService *application.VariantSelectionService
+	}
+)
+
+// Inject dependencies
+func (tf *VariantSelection) Inject(variantSelectionService *application.VariantSelectionService) {
+	tf.variantSelectionService = variantSelectionService
+}
+
+// Func returns the variant selection of configurables (e.g. for swatches on teasers) - nil for other product types
+func (tf *VariantSelection) Func(ctx context.Context) interface{} {
+	return func(product domain.BasicProduct) *domain.VariantSelection {
+		selection, ok := tf.variantSelectionService.GetForProduct(product)
+		if !ok {
+			return nil
+		}
+		return &selection
+	}
+}
@@ -2,21 +2,81 @@ package product
 
 import (
 	"flamingo.me/dingo"
+	"flamingo.me/flamingo-commerce/v3/product/application"
+	"flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/product/infrastructure/cache"
+	"flamingo.me/flamingo-commerce/v3/product/infrastructure/fake"
+	"flamingo.me/flamingo-commerce/v3/product/infrastructure/relations"
 	"flamingo.me/flamingo-commerce/v3/product/interfaces/controller"
 	"flamingo.me/flamingo-commerce/v3/product/interfaces/templatefunctions"
+	"flamingo.me/flamingo-commerce/v3/search"
+	searchApplication "flamingo.me/flamingo-commerce/v3/search/application"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
 	"flamingo.me/flamingo/v3/framework/config"
 	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/flamingo/v3/framework/web"
 )
 
 // Module registers our profiler
-type Module struct{}
+type Module struct {
+	useFakeService bool
+	useCache       bool
+	useSearchCache bool
+	useRelations   bool
+}
+
+// Inject dependencies
+func (m *Module) Inject(
+	config *struct {
+		UseFakeService bool `inject:"config:commerce.product.fakeservice.enabled,optional"`
+		UseCache       bool `inject:"config:commerce.product.cache.enabled,optional"`
+		UseRelations   bool `inject:"config:commerce.product.relations.configAdapter.enabled,optional"`
+		UseSearchCache bool `inject:"config:commerce.search.cache.enabled,optional"`
+	},
+) {
+	if config != nil {
+		m.useFakeService = config.UseFakeService
+		m.useCache = config.UseCache
+		m.useRelations = config.UseRelations
+		m.useSearchCache = config.UseSearchCache
+	}
+}
 
 // Configure the product URL
 func (m *Module) Configure(injector *dingo.Injector) {
+	if m.useFakeService {
+		injector.Bind(new(fake.ProductRepository)).AsEagerSingleton()
+		injector.Bind((*domain.ProductService)(nil)).To(fake.ProductService{})
+		injector.Bind((*domain.SearchService)(nil)).To(fake.SearchService{})
+		injector.BindMulti((*searchDomain.DocumentSource)(nil)).To(fake.SearchDocumentSource{})
+	}
+	if m.useCache {
+		injector.Bind(new(cache.ProductCache)).AsEagerSingleton()
+		injector.BindInterceptor((*domain.ProductService)(nil), cache.CachingProductService{})
+	}
+	if m.useSearchCache {
+		// the SearchCache and its config are provided by the search module, see Depends
+		injector.BindInterceptor((*domain.SearchService)(nil), cache.CachingSearchService{})
+		injector.BindMulti((*searchDomain.DocumentTagger)(nil)).To(cache.ProductDocumentTagger{})
+	}
+	if m.useRelations {
+		injector.Bind((*domain.ProductRelationService)(nil)).To(relations.ConfigRelationService{})
+	}
+	injector.Bind((*application.StructuredDataBuilder)(nil)).To(application.DefaultStructuredDataBuilder{})
+	injector.Bind((*domain.Clock)(nil)).To(domain.SystemClock{})
+	injector.BindMulti((*searchApplication.APIHitMapper)(nil)).To(controller.SearchHitMapper{})
+	injector.BindMulti((*searchApplication.DocumentIdentifier)(nil)).To(application.SearchDocumentIdentifier{})
+
 	flamingo.BindTemplateFunc(injector, "getProduct", new(templatefunctions.GetProduct))
 	flamingo.BindTemplateFunc(injector, "getProductUrl", new(templatefunctions.GetProductURL))
 	flamingo.BindTemplateFunc(injector, "findProducts", new(templatefunctions.FindProducts))
+	flamingo.BindTemplateFunc(injector, "getRelatedProducts", new(templatefunctions.GetRelatedProducts))
+	flamingo.BindTemplateFunc(injector, "getBasePrice", new(templatefunctions.GetBasePrice))
+	flamingo.BindTemplateFunc(injector, "attributeWithUnit", new(templatefunctions.AttributeWithUnit))
+	flamingo.BindTemplateFunc(injector, "productJsonLd", new(templatefunctions.ProductJSONLD))
+	flamingo.BindTemplateFunc(injector, "isProductVisible", new(templatefunctions.IsProductVisible))
+	flamingo.BindTemplateFunc(injector, "isProductSaleable", new(templatefunctions.IsProductSaleable))
+	flamingo.BindTemplateFunc(injector, "variantSelection", new(templatefunctions.VariantSelection))
 
 	web.BindRoutes(injector, new(routes))
 }
@@ -26,6 +86,36 @@ func (m *Module) DefaultConfig() config.Map {
 	return config.Map{
 		"commerce.product.view.template": "product/product",
 		"commerce.product.priceIsGross":  true,
+		"commerce.product.fakeservice": config.Map{
+			"enabled":         false,
+			"fixtures":        "",
+			"defaultPageSize": float64(20),
+		},
+		"commerce.product.cache": config.Map{
+			"enabled":     false,
+			"size":        float64(1000),
+			"ttl":         "5m",
+			"notFoundTTL": "0s",
+		},
+		"commerce.product.relations": config.Map{
+			"limit": float64(10),
+			"configAdapter": config.Map{
+				"enabled":   false,
+				"relations": config.Map{},
+			},
+		},
+		"commerce.product.basePrice": config.Map{
+			"contentAttribute": "netContent",
+		},
+		"commerce.product.visibility": config.Map{
+			"enforce": true,
+		},
+		"commerce.product.structuredData": config.Map{
+			"mediaBaseUrl":   "",
+			"brandAttribute": "brand",
+			"gtinAttribute":  "gtin",
+			"mpnAttribute":   "",
+		},
 		"templating": config.Map{
 			"product": config.Map{
 				"attributeRenderer": config.Map{},
@@ -34,12 +124,21 @@ func (m *Module) DefaultConfig() config.Map {
 	}
 }
 
+// Depends on other modules
+func (m *Module) Depends() []dingo.Module {
+	return []dingo.Module{
+		new(search.Module),
+	}
+}
+
 type routes struct {
-	controller *controller.View
+	controller    *controller.View
+	apiController *controller.APIController
 }
 
-func (r *routes) Inject(controller *controller.View) {
+func (r *routes) Inject(controller *controller.View, apiController *controller.APIController) {
 	r.controller = controller
+	r.apiController = apiController
 }
 
 func (r *routes) Routes(registry *web.RouterRegistry) {
@@ -48,4 +147,12 @@ func (r *routes) Routes(registry *web.RouterRegistry) {
 	h.Normalize("name")
 	h, _ = registry.Route("/product/:marketplacecode/:variantcode/:name.html", `product.view(marketplacecode, variantcode, name, backurl?="")`)
 	h.Normalize("name")
+
+	registry.HandleGet("product.api.get", r.apiController.GetAction)
+	registry.Route("/api/product/:marketplacecode", `product.api.get(marketplacecode)`)
+	registry.Route("/api/product/:marketplacecode/:variantcode", `product.api.get(marketplacecode, variantcode)`)
+	registry.HandleGet("product.api.variantselection", r.apiController.VariantSelectionAction)
+	registry.Route("/api/variantselection/:marketplacecode", `product.api.variantselection(marketplacecode)`)
+	registry.Route("/api/variantselection/:marketplacecode/:variantcode", `product.api.variantselection(marketplacecode, variantcode)`)
+	registry.HandleData("product", r.apiController.Data)
 }
@@ -0,0 +1,237 @@
+package application
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	productApplication "flamingo.me/flamingo-commerce/v3/product/application"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/productfeed/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// Exporter maps the products of the SearchService into feeds
+	Exporter struct {
+		searchService   productDomain.SearchService
+		urlService      productURLService
+		encoderProvider domain.FeedEncoderProvider
+		logger          flamingo.Logger
+		baseURL         string
+		mediaBaseURL    string
+		pageSize        int
+		title           string
+		description     string
+		brandAttribute  string
+		gtinAttribute   string
+		mpnAttribute    string
+	}
+
+	productURLService interface {
+		Get(product productDomain.BasicProduct, variantCode string) (string, error)
+	}
+)
+
+const defaultPageSize = 100
+
+// ErrUnknownFormat is returned if no FeedEncoder is bound for the format
+var ErrUnknownFormat = errors.New("unknown feed format")
+
+// Inject dependencies
+func (e *Exporter) Inject(
+	searchService productDomain.SearchService,
+	urlService *productApplication.URLService,
+	encoderProvider domain.FeedEncoderProvider,
+	logger flamingo.Logger,
+	config *struct {
+		BaseURL        string  `inject:"config:commerce.productfeed.baseUrl,optional"`
+		MediaBaseURL   string  `inject:"config:commerce.productfeed.mediaBaseUrl,optional"`
+		PageSize       float64 `inject:"config:commerce.productfeed.pageSize,optional"`
+		Title          string  `inject:"config:commerce.productfeed.title,optional"`
+		Description    string  `inject:"config:commerce.productfeed.description,optional"`
+		BrandAttribute string  `inject:"config:commerce.productfeed.brandAttribute,optional"`
+		GTINAttribute  string  `inject:"config:commerce.productfeed.gtinAttribute,optional"`
+		MPNAttribute   string  `inject:"config:commerce.productfeed.mpnAttribute,optional"`
+	},
+) {
+	e.searchService = searchService
+	e.urlService = urlService
+	e.encoderProvider = encoderProvider
+	e.logger = logger.WithField(flamingo.LogKeyModule, "productfeed").WithField(flamingo.LogKeyCategory, "application.Exporter")
+	e.pageSize = defaultPageSize
+	if config != nil {
+		e.baseURL = strings.TrimRight(config.BaseURL, "/")
+		e.mediaBaseURL = config.MediaBaseURL
+		if config.PageSize > 0 {
+			e.pageSize = int(config.PageSize)
+		}
+		e.title = config.Title
+		e.description = config.Description
+		e.brandAttribute = config.BrandAttribute
+		e.gtinAttribute = config.GTINAttribute
+		e.mpnAttribute = config.MPNAttribute
+	}
+}
+
+// Export writes the feed of all products in the given format (e.g. "google" or "csv")
+func (e *Exporter) Export(ctx context.Context, format string, w io.Writer) (int, error) {
+	var encoder domain.FeedEncoder
+	if e.encoderProvider != nil {
+		encoder = e.encoderProvider()[format]
+	}
+	if encoder == nil {
+		return 0, errors.Wrapf(ErrUnknownFormat, "format %q", format)
+	}
+
+	feed, err := e.Feed(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(feed.Items), encoder.Encode(w, *feed)
+}
+
+// Feed returns the feed of all saleable products - configurables are mapped to one item per variant
+func (e *Exporter) Feed(ctx context.Context) (*domain.Feed, error) {
+	feed := &domain.Feed{
+		Title:       e.title,
+		Link:        e.baseURL + "/",
+		Description: e.description,
+	}
+
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		result, err := e.searchService.Search(ctx, searchDomain.NewPaginationPageFilter(page), searchDomain.NewPaginationPageSizeFilter(e.pageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range result.Hits {
+			for _, item := range e.Items(product) {
+				if seen[item.ID] {
+					continue
+				}
+				seen[item.ID] = true
+				feed.Items = append(feed.Items, item)
+			}
+		}
+
+		if len(result.Hits) == 0 || page >= result.SearchMeta.NumPages {
+			return feed, nil
+		}
+	}
+}
+
+// Items maps a product to the feed items - products that are not saleable are skipped
+func (e *Exporter) Items(product productDomain.BasicProduct) []domain.Item {
+	switch p := product.(type) {
+	case productDomain.ConfigurableProduct:
+		var items []domain.Item
+		for _, variant := range p.Variants {
+			if !variant.IsSaleable {
+				continue
+			}
+			item := e.item(variant.BasicProductData, variant.Saleable, e.link(p, variant.MarketPlaceCode), variant.IsInStock())
+			item.ItemGroupID = p.MarketPlaceCode
+			item.VariationAttributes = make(map[string]string, len(p.VariantVariationAttributes))
+			for _, attribute := range p.VariantVariationAttributes {
+				if variant.HasAttribute(attribute) {
+					item.VariationAttributes[attribute] = variant.Attributes[attribute].Value()
+				}
+			}
+			if item.Brand == "" {
+				item.Brand = attributeValue(p.BasicProductData, e.brandAttribute)
+			}
+			items = append(items, item)
+		}
+		return items
+	case productDomain.BundleProduct:
+		if !p.IsSaleable() {
+			return nil
+		}
+		return []domain.Item{e.item(p.BaseData(), p.SaleableData(), e.link(p, ""), p.IsInStock())}
+	}
+
+	if !product.IsSaleable() || !product.SaleableData().IsSaleable {
+		return nil
+	}
+	return []domain.Item{e.item(product.BaseData(), product.SaleableData(), e.link(product, ""), product.BaseData().IsInStock())}
+}
+
+func (e *Exporter) item(baseData productDomain.BasicProductData, saleable productDomain.Saleable, link string, inStock bool) domain.Item {
+	item := domain.Item{
+		ID:           baseData.MarketPlaceCode,
+		Title:        baseData.Title,
+		Description:  baseData.Description,
+		Link:         link,
+		Availability: domain.AvailabilityOutOfStock,
+		Price:        saleable.ActivePrice.Default,
+		Brand:        attributeValue(baseData, e.brandAttribute),
+		GTIN:         attributeValue(baseData, e.gtinAttribute),
+		MPN:          attributeValue(baseData, e.mpnAttribute),
+		ProductType:  strings.Replace(strings.Trim(baseData.MainCategory.Path, "/"), "/", " > ", -1),
+		Attributes:   baseData.Attributes,
+	}
+	if item.Description == "" {
+		item.Description = baseData.ShortDescription
+	}
+	if inStock {
+		item.Availability = domain.AvailabilityInStock
+	}
+	if saleable.ActivePrice.IsDiscounted {
+		salePrice := saleable.ActivePrice.Discounted
+		item.SalePrice = &salePrice
+	}
+	for _, category := range baseData.Categories {
+		item.Categories = append(item.Categories, category.Name)
+	}
+
+	images := e.images(baseData)
+	if len(images) > 0 {
+		item.ImageLink = images[0]
+		item.AdditionalImageLinks = images[1:]
+	}
+	return item
+}
+
+// images returns the detail images (or the list image if there is no detail image) as absolute urls
+func (e *Exporter) images(baseData productDomain.BasicProductData) []string {
+	var images []string
+	for _, media := range baseData.Media {
+		if media.Usage == productDomain.MediaUsageDetail && media.Reference != "" {
+			images = append(images, e.mediaURL(media.Reference))
+		}
+	}
+	if len(images) == 0 {
+		if media := baseData.GetListMedia(); media.Reference != "" {
+			images = append(images, e.mediaURL(media.Reference))
+		}
+	}
+	return images
+}
+
+func (e *Exporter) mediaURL(reference string) string {
+	if strings.HasPrefix(reference, "http://") || strings.HasPrefix(reference, "https://") {
+		return reference
+	}
+	return e.mediaBaseURL + reference
+}
+
+func (e *Exporter) link(product productDomain.BasicProduct, variantCode string) string {
+	url, err := e.urlService.Get(product, variantCode)
+	if err != nil {
+		e.logger.Warn("product url could not be built: ", err)
+		return ""
+	}
+	return e.baseURL + url
+}
+
+func attributeValue(baseData productDomain.BasicProductData, code string) string {
+	if code == "" || !baseData.HasAttribute(code) {
+		return ""
+	}
+	return baseData.Attributes[code].Value()
+}
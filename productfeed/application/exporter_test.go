@@ -0,0 +1,144 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/productfeed/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	searchServiceStub struct {
+		products []productDomain.BasicProduct
+		pageSize int
+	}
+
+	urlServiceStub struct{}
+
+	encoderStub struct {
+		feed domain.Feed
+	}
+)
+
+func (s *searchServiceStub) Search(_ context.Context, filters ...searchDomain.Filter) (*productDomain.SearchResult, error) {
+	page := 1
+	for _, filter := range filters {
+		if pageFilter, ok := filter.(*searchDomain.PaginationPage); ok {
+			_, values := pageFilter.Value()
+			page, _ = strconv.Atoi(values[0])
+		}
+	}
+	start, end := (page-1)*s.pageSize, page*s.pageSize
+	if end > len(s.products) {
+		end = len(s.products)
+	}
+	result := &productDomain.SearchResult{Hits: s.products[start:end]}
+	result.SearchMeta.NumPages = (len(s.products) + s.pageSize - 1) / s.pageSize
+	return result, nil
+}
+
+func (s *searchServiceStub) SearchBy(ctx context.Context, _ string, _ []string, filters ...searchDomain.Filter) (*productDomain.SearchResult, error) {
+	return s.Search(ctx, filters...)
+}
+
+func (urlServiceStub) Get(product productDomain.BasicProduct, variantCode string) (string, error) {
+	if variantCode != "" {
+		return "/product/" + product.BaseData().MarketPlaceCode + "/" + variantCode + ".html", nil
+	}
+	return "/product/" + product.BaseData().MarketPlaceCode + ".html", nil
+}
+
+func (e *encoderStub) Encode(_ io.Writer, feed domain.Feed) error {
+	e.feed = feed
+	return nil
+}
+
+func saleable(price float64) productDomain.Saleable {
+	return productDomain.Saleable{IsSaleable: true, ActivePrice: productDomain.PriceInfo{Default: priceDomain.NewFromFloat(price, "EUR")}}
+}
+
+func exporter(encoder domain.FeedEncoder, products ...productDomain.BasicProduct) *Exporter {
+	return &Exporter{
+		searchService:   &searchServiceStub{products: products, pageSize: 2},
+		urlService:      urlServiceStub{},
+		encoderProvider: func() map[string]domain.FeedEncoder { return map[string]domain.FeedEncoder{"stub": encoder} },
+		logger:          flamingo.NullLogger{},
+		baseURL:         "https://example.com",
+		mediaBaseURL:    "https://media.example.com/",
+		pageSize:        2,
+		brandAttribute:  "brand",
+		gtinAttribute:   "gtin",
+	}
+}
+
+func TestExporter_Export(t *testing.T) {
+	discounted := saleable(20)
+	discounted.ActivePrice.IsDiscounted = true
+	discounted.ActivePrice.Discounted = priceDomain.NewFromFloat(15, "EUR")
+
+	mug := productDomain.SimpleProduct{
+		BasicProductData: productDomain.BasicProductData{
+			MarketPlaceCode:  "mug",
+			Title:            "Mug",
+			ShortDescription: "A mug",
+			StockLevel:       "in",
+			MainCategory:     productDomain.CategoryTeaser{Path: "kitchen/mugs"},
+			Categories:       []productDomain.CategoryTeaser{{Name: "Mugs"}},
+			Media:            []productDomain.Media{{Usage: productDomain.MediaUsageDetail, Reference: "mug.jpg"}, {Usage: productDomain.MediaUsageDetail, Reference: "https://cdn.example.com/mug-2.jpg"}},
+			Attributes:       productDomain.Attributes{"gtin": {Code: "gtin", RawValue: "4000000000001"}},
+		},
+		Saleable: discounted,
+	}
+	notSaleable := productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "old"}}
+	shirt := productDomain.ConfigurableProduct{
+		BasicProductData:           productDomain.BasicProductData{MarketPlaceCode: "shirt", Attributes: productDomain.Attributes{"brand": {Code: "brand", RawValue: "Flamingo"}}},
+		VariantVariationAttributes: []string{"color"},
+		Variants: []productDomain.Variant{
+			{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt-red", Title: "Red Shirt", StockLevel: "out", Attributes: productDomain.Attributes{"color": {Code: "color", RawValue: "red"}}}, Saleable: saleable(10)},
+			{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt-blue", Title: "Blue Shirt"}},
+		},
+	}
+
+	encoder := new(encoderStub)
+	count, err := exporter(encoder, mug, notSaleable, shirt, mug).Export(context.Background(), "stub", new(bytes.Buffer))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count, "not saleable products and duplicates are skipped")
+
+	salePrice := priceDomain.NewFromFloat(15, "EUR")
+	assert.Equal(t, domain.Item{
+		ID:                   "mug",
+		Title:                "Mug",
+		Description:          "A mug",
+		Link:                 "https://example.com/product/mug.html",
+		ImageLink:            "https://media.example.com/mug.jpg",
+		AdditionalImageLinks: []string{"https://cdn.example.com/mug-2.jpg"},
+		Availability:         domain.AvailabilityInStock,
+		Price:                priceDomain.NewFromFloat(20, "EUR"),
+		SalePrice:            &salePrice,
+		GTIN:                 "4000000000001",
+		ProductType:          "kitchen > mugs",
+		Categories:           []string{"Mugs"},
+		Attributes:           mug.Attributes,
+	}, encoder.feed.Items[0])
+
+	variant := encoder.feed.Items[1]
+	assert.Equal(t, "shirt-red", variant.ID)
+	assert.Equal(t, "shirt", variant.ItemGroupID)
+	assert.Equal(t, "https://example.com/product/shirt/shirt-red.html", variant.Link)
+	assert.Equal(t, domain.AvailabilityOutOfStock, variant.Availability)
+	assert.Equal(t, "Flamingo", variant.Brand, "the brand of the configurable is used for variants")
+	assert.Equal(t, map[string]string{"color": "red"}, variant.VariationAttributes)
+
+	_, err = exporter(encoder).Export(context.Background(), "unknown", new(bytes.Buffer))
+	assert.Equal(t, ErrUnknownFormat, errors.Cause(err))
+}
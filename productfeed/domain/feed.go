@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"io"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+)
+
+// Availability values of feed items
+const (
+	AvailabilityInStock    = "in_stock"
+	AvailabilityOutOfStock = "out_of_stock"
+)
+
+type (
+	// Feed is a list of items that can be encoded by a FeedEncoder
+	Feed struct {
+		Title       string
+		Link        string
+		Description string
+		Items       []Item
+	}
+
+	// Item is a sellable article of the feed - configurables are exported with one item per variant
+	Item struct {
+		ID string
+		// ItemGroupID is the marketplace code of the configurable for variants
+		ItemGroupID          string
+		Title                string
+		Description          string
+		Link                 string
+		ImageLink            string
+		AdditionalImageLinks []string
+		Availability         string
+		// Price is the default price
+		Price priceDomain.Price
+		// SalePrice is set if the product is discounted
+		SalePrice   *priceDomain.Price
+		Brand       string
+		GTIN        string
+		MPN         string
+		ProductType string
+		Categories  []string
+		// VariationAttributes are the values of the variation attributes (e.g. color and size) of variants
+		VariationAttributes map[string]string
+		Attributes          productDomain.Attributes
+	}
+
+	// FeedEncoder writes a feed in a specific format
+	FeedEncoder interface {
+		Encode(w io.Writer, feed Feed) error
+	}
+
+	// FeedEncoderProvider returns the bound feed encoders by format
+	FeedEncoderProvider func() map[string]FeedEncoder
+)
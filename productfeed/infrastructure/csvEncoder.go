@@ -0,0 +1,115 @@
+package infrastructure
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"flamingo.me/flamingo-commerce/v3/productfeed/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+)
+
+type (
+	// CSVEncoder writes the feed as CSV with the configured columns
+	CSVEncoder struct {
+		columns   []string
+		separator rune
+	}
+)
+
+const attributeColumnPrefix = "attribute."
+
+var (
+	_ domain.FeedEncoder = new(CSVEncoder)
+
+	defaultCSVColumns = []string{"id", "itemGroupId", "title", "description", "link", "imageLink", "availability", "price", "salePrice", "brand", "gtin", "mpn", "productType"}
+
+	csvColumns = map[string]func(item domain.Item) string{
+		"id":           func(item domain.Item) string { return item.ID },
+		"itemGroupId":  func(item domain.Item) string { return item.ItemGroupID },
+		"title":        func(item domain.Item) string { return item.Title },
+		"description":  func(item domain.Item) string { return item.Description },
+		"link":         func(item domain.Item) string { return item.Link },
+		"imageLink":    func(item domain.Item) string { return item.ImageLink },
+		"availability": func(item domain.Item) string { return item.Availability },
+		"price":        func(item domain.Item) string { return formatPrice(item.Price) },
+		"salePrice": func(item domain.Item) string {
+			if item.SalePrice == nil {
+				return ""
+			}
+			return formatPrice(*item.SalePrice)
+		},
+		"brand":       func(item domain.Item) string { return item.Brand },
+		"gtin":        func(item domain.Item) string { return item.GTIN },
+		"mpn":         func(item domain.Item) string { return item.MPN },
+		"productType": func(item domain.Item) string { return item.ProductType },
+		"categories":  func(item domain.Item) string { return strings.Join(item.Categories, "|") },
+	}
+)
+
+// Inject dependencies
+func (e *CSVEncoder) Inject(
+	config *struct {
+		Columns   config.Slice `inject:"config:commerce.productfeed.csv.columns,optional"`
+		Separator string       `inject:"config:commerce.productfeed.csv.separator,optional"`
+	},
+) {
+	e.columns = defaultCSVColumns
+	e.separator = ','
+	if config == nil {
+		return
+	}
+	var columns []string
+	if err := config.Columns.MapInto(&columns); err == nil && len(columns) > 0 {
+		e.columns = columns
+	}
+	if separator, _ := utf8.DecodeRuneInString(config.Separator); separator != utf8.RuneError {
+		e.separator = separator
+	}
+}
+
+// Encode writes the header and one line per item. Columns are the item fields (e.g. "id", "price") or "attribute.<code>" for product attributes
+func (e *CSVEncoder) Encode(w io.Writer, feed domain.Feed) error {
+	columns := e.columns
+	if len(columns) == 0 {
+		columns = defaultCSVColumns
+	}
+
+	writer := csv.NewWriter(w)
+	if e.separator != 0 {
+		writer.Comma = e.separator
+	}
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for _, item := range feed.Items {
+		for i, column := range columns {
+			record[i] = columnValue(item, column)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func columnValue(item domain.Item, column string) string {
+	if value, ok := csvColumns[column]; ok {
+		return value(item)
+	}
+	if strings.HasPrefix(column, attributeColumnPrefix) {
+		code := strings.TrimPrefix(column, attributeColumnPrefix)
+		if value, ok := item.VariationAttributes[code]; ok {
+			return value
+		}
+		if attribute, ok := item.Attributes[code]; ok {
+			return attribute.Value()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/productfeed/domain"
+	"flamingo.me/flamingo-commerce/v3/productfeed/infrastructure"
+	"flamingo.me/flamingo/v3/framework/config"
+)
+
+func testFeed() domain.Feed {
+	salePrice := priceDomain.NewFromFloat(15, "EUR")
+	return domain.Feed{
+		Title: "Shop",
+		Link:  "https://example.com/",
+		Items: []domain.Item{
+			{
+				ID:           "mug",
+				Title:        "Mug, large",
+				Link:         "https://example.com/product/mug.html",
+				Availability: domain.AvailabilityInStock,
+				Price:        priceDomain.NewFromFloat(20, "EUR"),
+				SalePrice:    &salePrice,
+				GTIN:         "4000000000001",
+				Attributes:   productDomain.Attributes{"material": {Code: "material", RawValue: "ceramic"}},
+			},
+			{
+				ID:                  "shirt-red",
+				ItemGroupID:         "shirt",
+				Title:               "Red Shirt",
+				Availability:        domain.AvailabilityOutOfStock,
+				Price:               priceDomain.NewFromFloat(9.5, "EUR"),
+				VariationAttributes: map[string]string{"color": "red"},
+			},
+		},
+	}
+}
+
+func TestGoogleMerchantEncoder_Encode(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, new(infrastructure.GoogleMerchantEncoder).Encode(buffer, testFeed()))
+
+	result := buffer.String()
+	assert.Contains(t, result, `<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">`)
+	assert.Contains(t, result, "<g:id>mug</g:id>")
+	assert.Contains(t, result, "<g:price>20.00 EUR</g:price>")
+	assert.Contains(t, result, "<g:sale_price>15.00 EUR</g:sale_price>")
+	assert.Contains(t, result, "<g:gtin>4000000000001</g:gtin>")
+	assert.Contains(t, result, "<g:item_group_id>shirt</g:item_group_id>")
+	assert.Contains(t, result, "<g:color>red</g:color>")
+	assert.Contains(t, result, "<g:identifier_exists>no</g:identifier_exists>")
+}
+
+func TestGoogleMerchantEncoder_EncodeConfiguredAttributes(t *testing.T) {
+	feed := testFeed()
+	feed.Items[1].VariationAttributes = map[string]string{"color": "red", "shirtColor": "crimson", "shirtSize": "XL"}
+
+	encoder := new(infrastructure.GoogleMerchantEncoder)
+	encoder.Inject(&struct {
+		ColorAttribute string `inject:"config:commerce.productfeed.google.attributes.color,optional"`
+		SizeAttribute  string `inject:"config:commerce.productfeed.google.attributes.size,optional"`
+	}{ColorAttribute: "shirtColor", SizeAttribute: "shirtSize"})
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, encoder.Encode(buffer, feed))
+
+	result := buffer.String()
+	assert.Contains(t, result, "<g:color>crimson</g:color>")
+	assert.Contains(t, result, "<g:size>XL</g:size>")
+	assert.NotContains(t, result, "<g:color>red</g:color>")
+}
+
+func TestCSVEncoder_Encode(t *testing.T) {
+	encoder := new(infrastructure.CSVEncoder)
+	encoder.Inject(&struct {
+		Columns   config.Slice `inject:"config:commerce.productfeed.csv.columns,optional"`
+		Separator string       `inject:"config:commerce.productfeed.csv.separator,optional"`
+	}{Columns: config.Slice{"id", "title", "price", "salePrice", "attribute.color", "attribute.material"}, Separator: ";"})
+
+	buffer := new(bytes.Buffer)
+	assert.NoError(t, encoder.Encode(buffer, testFeed()))
+	assert.Equal(t, "id;title;price;salePrice;attribute.color;attribute.material\n"+
+		"mug;Mug, large;20.00 EUR;15.00 EUR;;ceramic\n"+
+		"shirt-red;Red Shirt;9.50 EUR;;red;\n", buffer.String())
+}
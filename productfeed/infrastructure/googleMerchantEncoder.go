@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	priceDomain "flamingo.me/flamingo-commerce/v3/price/domain"
+	"flamingo.me/flamingo-commerce/v3/productfeed/domain"
+)
+
+type (
+	// GoogleMerchantEncoder writes the feed as Google Merchant Center RSS 2.0 feed
+	GoogleMerchantEncoder struct {
+		colorAttribute string
+		sizeAttribute  string
+	}
+
+	googleRSS struct {
+		XMLName xml.Name      `xml:"rss"`
+		Version string        `xml:"version,attr"`
+		XmlnsG  string        `xml:"xmlns:g,attr"`
+		Channel googleChannel `xml:"channel"`
+	}
+
+	googleChannel struct {
+		Title       string       `xml:"title"`
+		Link        string       `xml:"link"`
+		Description string       `xml:"description"`
+		Items       []googleItem `xml:"item"`
+	}
+
+	googleItem struct {
+		ID                   string   `xml:"g:id"`
+		ItemGroupID          string   `xml:"g:item_group_id,omitempty"`
+		Title                string   `xml:"g:title"`
+		Description          string   `xml:"g:description"`
+		Link                 string   `xml:"g:link"`
+		ImageLink            string   `xml:"g:image_link,omitempty"`
+		AdditionalImageLinks []string `xml:"g:additional_image_link,omitempty"`
+		Availability         string   `xml:"g:availability"`
+		Price                string   `xml:"g:price"`
+		SalePrice            string   `xml:"g:sale_price,omitempty"`
+		Brand                string   `xml:"g:brand,omitempty"`
+		GTIN                 string   `xml:"g:gtin,omitempty"`
+		MPN                  string   `xml:"g:mpn,omitempty"`
+		IdentifierExists     string   `xml:"g:identifier_exists,omitempty"`
+		ProductType          string   `xml:"g:product_type,omitempty"`
+		Color                string   `xml:"g:color,omitempty"`
+		Size                 string   `xml:"g:size,omitempty"`
+	}
+)
+
+const (
+	googleNamespace             = "http://base.google.com/ns/1.0"
+	defaultGoogleColorAttribute = "color"
+	defaultGoogleSizeAttribute  = "size"
+)
+
+var _ domain.FeedEncoder = new(GoogleMerchantEncoder)
+
+// Inject dependencies
+func (e *GoogleMerchantEncoder) Inject(
+	config *struct {
+		ColorAttribute string `inject:"config:commerce.productfeed.google.attributes.color,optional"`
+		SizeAttribute  string `inject:"config:commerce.productfeed.google.attributes.size,optional"`
+	},
+) {
+	if config != nil {
+		e.colorAttribute = config.ColorAttribute
+		e.sizeAttribute = config.SizeAttribute
+	}
+}
+
+// Encode writes the feed - g:color and g:size are the values of the configured variation attributes
+func (e *GoogleMerchantEncoder) Encode(w io.Writer, feed domain.Feed) error {
+	colorAttribute := e.colorAttribute
+	if colorAttribute == "" {
+		colorAttribute = defaultGoogleColorAttribute
+	}
+	sizeAttribute := e.sizeAttribute
+	if sizeAttribute == "" {
+		sizeAttribute = defaultGoogleSizeAttribute
+	}
+
+	rss := googleRSS{
+		Version: "2.0",
+		XmlnsG:  googleNamespace,
+		Channel: googleChannel{
+			Title:       feed.Title,
+			Link:        feed.Link,
+			Description: feed.Description,
+			Items:       make([]googleItem, 0, len(feed.Items)),
+		},
+	}
+
+	for _, item := range feed.Items {
+		googleItem := googleItem{
+			ID:                   item.ID,
+			ItemGroupID:          item.ItemGroupID,
+			Title:                item.Title,
+			Description:          item.Description,
+			Link:                 item.Link,
+			ImageLink:            item.ImageLink,
+			AdditionalImageLinks: item.AdditionalImageLinks,
+			Availability:         item.Availability,
+			Price:                formatPrice(item.Price),
+			Brand:                item.Brand,
+			GTIN:                 item.GTIN,
+			MPN:                  item.MPN,
+			ProductType:          item.ProductType,
+			Color:                item.VariationAttributes[colorAttribute],
+			Size:                 item.VariationAttributes[sizeAttribute],
+		}
+		if item.SalePrice != nil {
+			googleItem.SalePrice = formatPrice(*item.SalePrice)
+		}
+		if item.GTIN == "" && item.MPN == "" {
+			googleItem.IdentifierExists = "no"
+		}
+		rss.Channel.Items = append(rss.Channel.Items, googleItem)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(rss)
+}
+
+// formatPrice returns the payable price in the format "12.99 EUR"
+func formatPrice(price priceDomain.Price) string {
+	payable := price.GetPayable()
+	return strings.TrimSpace(payable.Amount().Text('f', 2) + " " + payable.Currency())
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"flamingo.me/flamingo-commerce/v3/internal/atomicfile"
+	"flamingo.me/flamingo-commerce/v3/productfeed/application"
+)
+
+type (
+	// Export is the command that writes a product feed to a file
+	Export struct {
+		exporter *application.Exporter
+	}
+)
+
+// Inject dependencies
+func (e *Export) Inject(exporter *application.Exporter) {
+	e.exporter = exporter
+}
+
+// Command returns the cobra command "productfeed"
+func (e *Export) Command() *cobra.Command {
+	var format, output string
+	command := &cobra.Command{
+		Use:   "productfeed",
+		Short: "Export the product feed (google or csv) to a file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count, err := e.export(format, output)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("%d items written to %s\n", count, output)
+			return nil
+		},
+	}
+	command.Flags().StringVarP(&format, "format", "f", "google", "feed format: google or csv")
+	command.Flags().StringVarP(&output, "output", "o", "productfeed.xml", "output file")
+	return command
+}
+
+// export writes the feed to a temporary file in the directory of the output, which replaces the output only if the export succeeded
+func (e *Export) export(format string, output string) (int, error) {
+	var count int
+	err := atomicfile.Write(output, 0644, func(w io.Writer) error {
+		var err error
+		count, err = e.exporter.Export(context.Background(), format, w)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/productfeed/application"
+)
+
+func TestExport_FailedExportKeepsOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "productfeed")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "productfeed.xml")
+	assert.NoError(t, ioutil.WriteFile(output, []byte("previous feed"), 0644))
+
+	command := new(Export)
+	command.Inject(new(application.Exporter))
+
+	_, err = command.export("unknown", output)
+	assert.Error(t, err)
+
+	content, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous feed", string(content), "the previous feed is kept")
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1, "the temporary file is removed")
+}
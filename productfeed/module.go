@@ -0,0 +1,58 @@
+package productfeed
+
+import (
+	"flamingo.me/dingo"
+	"github.com/spf13/cobra"
+
+	"flamingo.me/flamingo-commerce/v3/product"
+	"flamingo.me/flamingo-commerce/v3/productfeed/domain"
+	"flamingo.me/flamingo-commerce/v3/productfeed/infrastructure"
+	"flamingo.me/flamingo-commerce/v3/productfeed/interfaces/commands"
+	"flamingo.me/flamingo/v3/framework/config"
+)
+
+// Module for product feeds
+type Module struct{}
+
+// Configure module
+func (m *Module) Configure(injector *dingo.Injector) {
+	injector.BindMap((*domain.FeedEncoder)(nil), "google").To(infrastructure.GoogleMerchantEncoder{})
+	injector.BindMap((*domain.FeedEncoder)(nil), "csv").To(infrastructure.CSVEncoder{})
+
+	injector.BindMulti(new(cobra.Command)).ToProvider(func(export *commands.Export) *cobra.Command {
+		return export.Command()
+	})
+}
+
+// DefaultConfig for this module
+func (m *Module) DefaultConfig() config.Map {
+	return config.Map{
+		"commerce.productfeed": config.Map{
+			"baseUrl":        "",
+			"mediaBaseUrl":   "",
+			"pageSize":       float64(100),
+			"title":          "",
+			"description":    "",
+			"brandAttribute": "brand",
+			"gtinAttribute":  "gtin",
+			"mpnAttribute":   "",
+			"google": config.Map{
+				"attributes": config.Map{
+					"color": "color",
+					"size":  "size",
+				},
+			},
+			"csv": config.Map{
+				"separator": ",",
+				"columns":   config.Slice{"id", "itemGroupId", "title", "description", "link", "imageLink", "availability", "price", "salePrice", "brand", "gtin", "mpn", "productType"},
+			},
+		},
+	}
+}
+
+// Depends on other modules
+func (m *Module) Depends() []dingo.Module {
+	return []dingo.Module{
+		new(product.Module),
+	}
+}
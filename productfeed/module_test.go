@@ -0,0 +1,14 @@
+package productfeed_test
+
+import (
+	"testing"
+
+	"flamingo.me/dingo"
+	"flamingo.me/flamingo-commerce/v3/productfeed"
+)
+
+func TestModule_Configure(t *testing.T) {
+	if err := dingo.TryModule(new(productfeed.Module)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	authDomain "flamingo.me/flamingo/v3/core/oauth/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// EventReceiver adds viewed products to the list and merges the guest list on login
+	EventReceiver struct {
+		service *Service
+		logger  flamingo.Logger
+	}
+)
+
+// Inject dependencies
+func (e *EventReceiver) Inject(service *Service, logger flamingo.Logger) {
+	e.service = service
+	e.logger = logger.WithField(flamingo.LogKeyModule, "recentlyviewed").WithField(flamingo.LogKeyCategory, "application.EventReceiver")
+}
+
+// Notify should get called by flamingo Eventlogic
+func (e *EventReceiver) Notify(ctx context.Context, event flamingo.Event) {
+	switch currentEvent := event.(type) {
+	case *productDomain.ProductViewedEvent:
+		session := web.SessionFromContext(ctx)
+		if session == nil {
+			return
+		}
+		if err := e.service.Add(ctx, session, currentEvent.MarketplaceCode, currentEvent.VariantMarketplaceCode); err != nil {
+			e.logger.WithContext(ctx).Error("viewed product could not be added: ", err)
+		}
+	case *authDomain.LoginEvent:
+		if currentEvent == nil || currentEvent.Session == nil {
+			return
+		}
+		if err := e.service.MergeGuestList(ctx, currentEvent.Session); err != nil {
+			e.logger.WithContext(ctx).Error("guest list could not be merged: ", err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/domain"
+	authApplication "flamingo.me/flamingo/v3/core/oauth/application"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// Service manages the recently viewed products of the session or the logged in customer
+	Service struct {
+		productService  productDomain.ProductService
+		userService     authApplication.UserServiceInterface
+		logger          flamingo.Logger
+		clock           productDomain.Clock
+		customerStorage domain.CustomerStorage
+		size            int
+	}
+)
+
+const (
+	// SessionKey is used to store the list of guests in the session
+	SessionKey = "recentlyviewed.list"
+
+	defaultSize = 10
+)
+
+// Inject dependencies
+func (s *Service) Inject(
+	productService productDomain.ProductService,
+	userService authApplication.UserServiceInterface,
+	logger flamingo.Logger,
+	clock productDomain.Clock,
+	config *struct {
+		Size float64 `inject:"config:commerce.recentlyviewed.size,optional"`
+	},
+	optionals *struct {
+		CustomerStorage domain.CustomerStorage `inject:",optional"`
+	},
+) {
+	s.productService = productService
+	s.userService = userService
+	s.logger = logger.WithField(flamingo.LogKeyModule, "recentlyviewed").WithField(flamingo.LogKeyCategory, "application.Service")
+	s.clock = clock
+	s.size = defaultSize
+	if config != nil && config.Size > 0 {
+		s.size = int(config.Size)
+	}
+	if optionals != nil {
+		s.customerStorage = optionals.CustomerStorage
+	}
+}
+
+// Add puts the product on top of the list
+func (s *Service) Add(ctx context.Context, session *web.Session, marketplaceCode string, variantMarketplaceCode string) error {
+	if marketplaceCode == "" {
+		return nil
+	}
+	list, err := s.List(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	list = list.Add(domain.Entry{
+		MarketplaceCode:        marketplaceCode,
+		VariantMarketplaceCode: variantMarketplaceCode,
+		ViewedAt:               s.clock.Now(),
+	}, s.size)
+	return s.store(ctx, session, list)
+}
+
+// List returns the entries of the customer (if logged in and a CustomerStorage is bound) or of the session
+func (s *Service) List(ctx context.Context, session *web.Session) (domain.List, error) {
+	if customerID, ok := s.customerID(ctx, session); ok {
+		return s.customerStorage.Load(ctx, customerID)
+	}
+	return s.sessionList(session), nil
+}
+
+// Products returns the recently viewed products (configurables with their active variant) without the excluded marketplace codes.
+// Products that are not found anymore or not visible are skipped
+func (s *Service) Products(ctx context.Context, session *web.Session, exclude ...string) ([]productDomain.BasicProduct, error) {
+	list, err := s.List(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]bool, len(exclude))
+	for _, code := range exclude {
+		excluded[code] = true
+	}
+
+	loaded, err := productDomain.GetMany(ctx, s.productService, list.MarketplaceCodes()...)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("not all recently viewed products could be loaded: ", err)
+	}
+
+	now := s.clock.Now()
+	products := make([]productDomain.BasicProduct, 0, len(list))
+	for _, entry := range list {
+		product, ok := loaded[entry.MarketplaceCode]
+		if !ok || excluded[entry.MarketplaceCode] || excluded[entry.VariantMarketplaceCode] {
+			continue
+		}
+		if configurable, ok := product.(productDomain.ConfigurableProduct); ok && entry.VariantMarketplaceCode != "" {
+			withActiveVariant, err := configurable.GetConfigurableWithActiveVariant(entry.VariantMarketplaceCode)
+			if err == nil {
+				product = withActiveVariant
+			}
+		}
+		if !productDomain.IsProductVisibleAt(product, now) {
+			continue
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+// MergeGuestList moves the list of the session to the CustomerStorage of the logged in customer
+func (s *Service) MergeGuestList(ctx context.Context, session *web.Session) error {
+	customerID, ok := s.customerID(ctx, session)
+	if !ok {
+		return nil
+	}
+	guestList := s.sessionList(session)
+	if len(guestList) == 0 {
+		return nil
+	}
+
+	customerList, err := s.customerStorage.Load(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	if err := s.customerStorage.Store(ctx, customerID, customerList.Merge(guestList, s.size)); err != nil {
+		return err
+	}
+	session.Delete(SessionKey)
+	return nil
+}
+
+func (s *Service) store(ctx context.Context, session *web.Session, list domain.List) error {
+	if customerID, ok := s.customerID(ctx, session); ok {
+		return s.customerStorage.Store(ctx, customerID, list)
+	}
+	session.Store(SessionKey, list)
+	return nil
+}
+
+func (s *Service) sessionList(session *web.Session) domain.List {
+	if list, ok := session.Load(SessionKey); ok {
+		if list, ok := list.(domain.List); ok {
+			return list
+		}
+	}
+	return nil
+}
+
+// customerID returns the id of the logged in customer if a CustomerStorage is bound
+func (s *Service) customerID(ctx context.Context, session *web.Session) (string, bool) {
+	if s.customerStorage == nil || !s.userService.IsLoggedIn(ctx, session) {
+		return "", false
+	}
+	user := s.userService.GetUser(ctx, session)
+	if user == nil || user.Sub == "" {
+		return "", false
+	}
+	return user.Sub, true
+}
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/domain"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/infrastructure"
+	authDomain "flamingo.me/flamingo/v3/core/oauth/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	productServiceStub struct{}
+
+	userServiceStub struct{}
+)
+
+func (productServiceStub) Get(_ context.Context, marketplaceCode string) (productDomain.BasicProduct, error) {
+	switch marketplaceCode {
+	case "shirt":
+		return productDomain.ConfigurableProduct{
+			BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt"},
+			Variants: []productDomain.Variant{
+				{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt-red"}},
+			},
+		}, nil
+	case "hidden":
+		return productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "hidden", VisibleTo: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)}}, nil
+	case "deleted":
+		return nil, productDomain.ProductNotFound{MarketplaceCode: marketplaceCode}
+	}
+	return productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: marketplaceCode}}, nil
+}
+
+func (userServiceStub) GetUser(_ context.Context, session *web.Session) *authDomain.User {
+	if _, ok := session.Load("customer"); ok {
+		return &authDomain.User{Sub: "customer-1"}
+	}
+	return nil
+}
+
+func (u userServiceStub) IsLoggedIn(ctx context.Context, session *web.Session) bool {
+	return u.GetUser(ctx, session) != nil
+}
+
+type tickingClock struct {
+	now time.Time
+}
+
+func (c *tickingClock) Now() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func service(customerStorage domain.CustomerStorage) *Service {
+	s := new(Service)
+	s.Inject(
+		productServiceStub{},
+		userServiceStub{},
+		flamingo.NullLogger{},
+		&tickingClock{now: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)},
+		&struct {
+			Size float64 `inject:"config:commerce.recentlyviewed.size,optional"`
+		}{Size: 3},
+		&struct {
+			CustomerStorage domain.CustomerStorage `inject:",optional"`
+		}{CustomerStorage: customerStorage},
+	)
+	return s
+}
+
+func TestService_Products(t *testing.T) {
+	s := service(nil)
+	ctx := context.Background()
+	session := web.EmptySession()
+
+	for _, code := range []string{"deleted", "hidden", "mug", "cup"} {
+		assert.NoError(t, s.Add(ctx, session, code, ""))
+	}
+	assert.NoError(t, s.Add(ctx, session, "shirt", "shirt-red"))
+
+	list, err := s.List(ctx, session)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shirt", "cup", "mug"}, list.MarketplaceCodes())
+
+	products, err := s.Products(ctx, session, "cup")
+	assert.NoError(t, err)
+	if assert.Len(t, products, 2) {
+		assert.Equal(t, productDomain.TypeConfigurableWithActiveVariant, products[0].Type())
+		assert.Equal(t, "shirt-red", products[0].BaseData().MarketPlaceCode)
+		assert.Equal(t, "mug", products[1].BaseData().MarketPlaceCode)
+	}
+
+	assert.NoError(t, s.Add(ctx, session, "hidden", ""))
+	products, err = s.Products(ctx, session)
+	assert.NoError(t, err)
+	assert.Len(t, products, 2, "products outside of their visibility window are skipped")
+}
+
+func TestService_MergeGuestList(t *testing.T) {
+	storage := new(infrastructure.InMemoryCustomerStorage)
+	s := service(storage)
+	ctx := context.Background()
+	session := web.EmptySession()
+
+	assert.NoError(t, storage.Store(ctx, "customer-1", domain.List{{MarketplaceCode: "plate", ViewedAt: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)}}))
+	assert.NoError(t, s.Add(ctx, session, "mug", ""))
+	assert.NoError(t, s.Add(ctx, session, "cup", ""))
+
+	session.Store("customer", true)
+	receiver := new(EventReceiver)
+	receiver.Inject(s, flamingo.NullLogger{})
+	receiver.Notify(ctx, &authDomain.LoginEvent{Session: session})
+
+	_, ok := session.Load(SessionKey)
+	assert.False(t, ok, "the guest list is removed from the session")
+	list, err := storage.Load(ctx, "customer-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cup", "mug", "plate"}, list.MarketplaceCodes())
+
+	receiver.Notify(web.ContextWithSession(ctx, session), &productDomain.ProductViewedEvent{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-red"})
+	list, err = s.List(ctx, session)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shirt", "cup", "mug"}, list.MarketplaceCodes(), "logged in customers use the customer storage")
+}
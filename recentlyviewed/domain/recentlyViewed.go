@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"encoding/gob"
+	"sort"
+	"time"
+)
+
+type (
+	// Entry is a viewed product - variants are stored with the marketplace code of their configurable
+	Entry struct {
+		MarketplaceCode        string
+		VariantMarketplaceCode string
+		ViewedAt               time.Time
+	}
+
+	// List of viewed products, the most recently viewed product first
+	List []Entry
+
+	// CustomerStorage is an optional port to keep the list of logged in customers beyond the session
+	CustomerStorage interface {
+		Load(ctx context.Context, customerID string) (List, error)
+		Store(ctx context.Context, customerID string, list List) error
+	}
+)
+
+func init() {
+	gob.Register(List{})
+}
+
+// Add puts the entry at the top of the list. Former entries of the same product (or other variants of the same configurable)
+// are removed and the list is truncated to size (if size is greater than 0)
+func (l List) Add(entry Entry, size int) List {
+	result := make(List, 0, len(l)+1)
+	result = append(result, entry)
+	for _, existing := range l {
+		if existing.MarketplaceCode != entry.MarketplaceCode {
+			result = append(result, existing)
+		}
+	}
+	return result.truncate(size)
+}
+
+// Merge combines both lists ordered by ViewedAt - for the same product the most recent entry wins
+func (l List) Merge(other List, size int) List {
+	all := make(List, 0, len(l)+len(other))
+	all = append(all, l...)
+	all = append(all, other...)
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].ViewedAt.After(all[j].ViewedAt)
+	})
+
+	result := make(List, 0, len(all))
+	seen := make(map[string]bool, len(all))
+	for _, entry := range all {
+		if seen[entry.MarketplaceCode] {
+			continue
+		}
+		seen[entry.MarketplaceCode] = true
+		result = append(result, entry)
+	}
+	return result.truncate(size)
+}
+
+// MarketplaceCodes of all entries
+func (l List) MarketplaceCodes() []string {
+	codes := make([]string, len(l))
+	for i, entry := range l {
+		codes[i] = entry.MarketplaceCode
+	}
+	return codes
+}
+
+func (l List) truncate(size int) List {
+	if size > 0 && len(l) > size {
+		return l[:size]
+	}
+	return l
+}
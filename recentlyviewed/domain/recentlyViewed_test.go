@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/domain"
+)
+
+func TestList_Add(t *testing.T) {
+	now := time.Now()
+	var list domain.List
+	list = list.Add(domain.Entry{MarketplaceCode: "mug", ViewedAt: now}, 3)
+	list = list.Add(domain.Entry{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-red", ViewedAt: now.Add(time.Second)}, 3)
+	list = list.Add(domain.Entry{MarketplaceCode: "cup", ViewedAt: now.Add(2 * time.Second)}, 3)
+	assert.Equal(t, []string{"cup", "shirt", "mug"}, list.MarketplaceCodes())
+
+	list = list.Add(domain.Entry{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-blue", ViewedAt: now.Add(3 * time.Second)}, 3)
+	assert.Equal(t, []string{"shirt", "cup", "mug"}, list.MarketplaceCodes(), "variants of the same configurable are de-duplicated")
+	assert.Equal(t, "shirt-blue", list[0].VariantMarketplaceCode)
+
+	list = list.Add(domain.Entry{MarketplaceCode: "plate", ViewedAt: now.Add(4 * time.Second)}, 3)
+	assert.Equal(t, []string{"plate", "shirt", "cup"}, list.MarketplaceCodes(), "the list is truncated to the size")
+}
+
+func TestList_Merge(t *testing.T) {
+	now := time.Now()
+	customer := domain.List{
+		{MarketplaceCode: "mug", ViewedAt: now.Add(-time.Hour)},
+		{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-red", ViewedAt: now.Add(-2 * time.Hour)},
+	}
+	guest := domain.List{
+		{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-blue", ViewedAt: now},
+		{MarketplaceCode: "cup", ViewedAt: now.Add(-90 * time.Minute)},
+	}
+
+	merged := customer.Merge(guest, 10)
+	assert.Equal(t, []string{"shirt", "mug", "cup"}, merged.MarketplaceCodes())
+	assert.Equal(t, "shirt-blue", merged[0].VariantMarketplaceCode, "the most recent entry wins")
+
+	assert.Len(t, customer.Merge(guest, 2), 2)
+}
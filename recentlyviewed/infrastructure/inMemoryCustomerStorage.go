@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/domain"
+)
+
+type (
+	// InMemoryCustomerStorage keeps the lists of customers in memory (e.g. for development) - bind it as singleton
+	InMemoryCustomerStorage struct {
+		mutex sync.RWMutex
+		lists map[string]domain.List
+	}
+)
+
+var _ domain.CustomerStorage = new(InMemoryCustomerStorage)
+
+// Load the list of the customer
+func (s *InMemoryCustomerStorage) Load(_ context.Context, customerID string) (domain.List, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append(domain.List(nil), s.lists[customerID]...), nil
+}
+
+// Store the list of the customer
+func (s *InMemoryCustomerStorage) Store(_ context.Context, customerID string, list domain.List) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.lists == nil {
+		s.lists = make(map[string]domain.List)
+	}
+	s.lists[customerID] = append(domain.List(nil), list...)
+	return nil
+}
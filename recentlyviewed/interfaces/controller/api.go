@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+
+	productController "flamingo.me/flamingo-commerce/v3/product/interfaces/controller"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/application"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// APIController returns the recently viewed products as JSON
+	APIController struct {
+		responder  *web.Responder
+		service    *application.Service
+		productAPI *productController.APIController
+		logger     flamingo.Logger
+	}
+
+	// APIResult is the JSON result of the recently viewed api - the products have the representation of the product api
+	APIResult struct {
+		Success  bool                            `json:"success"`
+		Error    *APIError                       `json:"error,omitempty"`
+		Products []*productController.APIProduct `json:"products"`
+	}
+
+	// APIError contains details if success is false
+	APIError struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	}
+)
+
+// Inject dependencies
+func (c *APIController) Inject(responder *web.Responder, service *application.Service, productAPI *productController.APIController, logger flamingo.Logger) {
+	c.responder = responder
+	c.service = service
+	c.productAPI = productAPI
+	c.logger = logger.WithField(flamingo.LogKeyModule, "recentlyviewed").WithField(flamingo.LogKeyCategory, "controller.APIController")
+}
+
+// GetAction returns the recently viewed products - the optional query parameter "exclude" skips the given marketplace codes
+func (c *APIController) GetAction(ctx context.Context, r *web.Request) web.Result {
+	products, err := c.service.Products(ctx, r.Session(), r.QueryAll()["exclude"]...)
+	if err != nil {
+		c.logger.WithContext(ctx).Error("recently viewed products could not be loaded: ", err)
+		return c.responder.Data(APIResult{Error: &APIError{Message: err.Error(), Code: "get_error"}}).Status(500)
+	}
+
+	result := APIResult{Success: true, Products: make([]*productController.APIProduct, 0, len(products))}
+	for _, product := range products {
+		result.Products = append(result.Products, c.productAPI.MapProduct(product))
+	}
+	return c.responder.Data(result)
+}
@@ -0,0 +1,39 @@
+package templatefunctions
+
+import (
+	"context"
+
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/application"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// RecentlyViewedProducts is exported as a template function
+	RecentlyViewedProducts struct {
+		service *application.Service
+		logger  flamingo.Logger
+	}
+)
+
+// Inject dependencies
+func (tf *RecentlyViewedProducts) Inject(service *application.Service, logger flamingo.Logger) {
+	tf.service = service
+	tf.logger = logger.WithField(flamingo.LogKeyModule, "recentlyviewed").WithField(flamingo.LogKeyCategory, "templatefunctions.RecentlyViewedProducts")
+}
+
+// Func returns the recently viewed products without the given marketplace codes (e.g. the currently viewed product)
+func (tf *RecentlyViewedProducts) Func(ctx context.Context) interface{} {
+	return func(exclude ...string) []productDomain.BasicProduct {
+		session := web.SessionFromContext(ctx)
+		if session == nil {
+			return nil
+		}
+		products, err := tf.service.Products(ctx, session, exclude...)
+		if err != nil {
+			tf.logger.WithContext(ctx).Error(err)
+		}
+		return products
+	}
+}
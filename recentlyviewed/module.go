@@ -0,0 +1,76 @@
+package recentlyviewed
+
+import (
+	"flamingo.me/dingo"
+
+	"flamingo.me/flamingo-commerce/v3/product"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/application"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/domain"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/infrastructure"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/interfaces/controller"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed/interfaces/templatefunctions"
+	"flamingo.me/flamingo/v3/core/oauth"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+// Module for recently viewed products
+type Module struct {
+	useInMemoryCustomerStorage bool
+}
+
+// Inject dependencies
+func (m *Module) Inject(
+	config *struct {
+		UseInMemoryCustomerStorage bool `inject:"config:commerce.recentlyviewed.useInMemoryCustomerStorage,optional"`
+	},
+) {
+	if config != nil {
+		m.useInMemoryCustomerStorage = config.UseInMemoryCustomerStorage
+	}
+}
+
+// Configure module
+func (m *Module) Configure(injector *dingo.Injector) {
+	if m.useInMemoryCustomerStorage {
+		injector.Bind((*domain.CustomerStorage)(nil)).To(infrastructure.InMemoryCustomerStorage{}).AsEagerSingleton()
+	}
+
+	flamingo.BindEventSubscriber(injector).To(application.EventReceiver{})
+	flamingo.BindTemplateFunc(injector, "recentlyViewedProducts", new(templatefunctions.RecentlyViewedProducts))
+	web.BindRoutes(injector, new(routes))
+}
+
+// DefaultConfig for this module
+func (m *Module) DefaultConfig() config.Map {
+	return config.Map{
+		"commerce.recentlyviewed": config.Map{
+			"size":                       float64(10),
+			"useInMemoryCustomerStorage": false,
+		},
+	}
+}
+
+// Depends on other modules
+func (m *Module) Depends() []dingo.Module {
+	return []dingo.Module{
+		new(product.Module),
+		new(oauth.Module),
+	}
+}
+
+type routes struct {
+	apiController *controller.APIController
+}
+
+// Inject required dependencies
+func (r *routes) Inject(apiController *controller.APIController) {
+	r.apiController = apiController
+}
+
+// Routes of the recently viewed module
+func (r *routes) Routes(registry *web.RouterRegistry) {
+	registry.HandleGet("recentlyviewed.api.get", r.apiController.GetAction)
+	registry.Route("/api/recentlyviewed", "recentlyviewed.api.get")
+}
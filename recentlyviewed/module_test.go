@@ -0,0 +1,14 @@
+package recentlyviewed_test
+
+import (
+	"testing"
+
+	"flamingo.me/dingo"
+	"flamingo.me/flamingo-commerce/v3/recentlyviewed"
+)
+
+func TestModule_Configure(t *testing.T) {
+	if err := dingo.TryModule(new(recentlyviewed.Module)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// AnalyticsEventReceiver records the search and click events in the bound domain.SearchAnalytics
+	AnalyticsEventReceiver struct {
+		analytics domain.SearchAnalytics
+		logger    flamingo.Logger
+	}
+)
+
+// Inject dependencies
+func (r *AnalyticsEventReceiver) Inject(analytics domain.SearchAnalytics, logger flamingo.Logger) {
+	r.analytics = analytics
+	r.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "application.AnalyticsEventReceiver")
+}
+
+// Notify should get called by flamingo Eventlogic
+func (r *AnalyticsEventReceiver) Notify(ctx context.Context, event flamingo.Event) {
+	var err error
+	switch currentEvent := event.(type) {
+	case *domain.SearchPerformedEvent:
+		err = r.analytics.RecordSearch(ctx, *currentEvent)
+	case *domain.SearchResultClickedEvent:
+		err = r.analytics.RecordClick(ctx, *currentEvent)
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Warn("search event could not be recorded: ", err)
+	}
+}
+
+// dispatchSearchPerformed dispatches the domain.SearchPerformedEvent of the results
+func (s *SearchService) dispatchSearchPerformed(ctx context.Context, documentType string, filters []domain.Filter, results map[string]domain.Result) {
+	if s.EventRouter == nil {
+		return
+	}
+
+	event := &domain.SearchPerformedEvent{
+		DocumentType:  documentType,
+		Filters:       domain.NormalizeFilters(filters...),
+		ResultsByType: make(map[string]int, len(results)),
+		Page:          1,
+	}
+	for _, filter := range filters {
+		// the query is given by the query filter or by the url parameter "q"
+		if key, values := filter.Value(); key == "q" && len(values) > 0 && event.Query == "" {
+			event.Query = domain.NormalizeQuery(values[0])
+		}
+	}
+	for resultType, result := range results {
+		event.NumResults += result.SearchMeta.NumResults
+		event.ResultsByType[resultType] = result.SearchMeta.NumResults
+		if result.SearchMeta.Page > event.Page {
+			event.Page = result.SearchMeta.Page
+		}
+	}
+
+	s.EventRouter.Dispatch(ctx, event)
+}
@@ -0,0 +1,100 @@
+package application_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/application"
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/utils"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	eventRouterStub struct {
+		events []flamingo.Event
+	}
+
+	analyticsStub struct {
+		searches []domain.SearchPerformedEvent
+		clicks   []domain.SearchResultClickedEvent
+	}
+)
+
+func (r *eventRouterStub) Dispatch(_ context.Context, event flamingo.Event) {
+	r.events = append(r.events, event)
+}
+
+func (a *analyticsStub) RecordSearch(_ context.Context, event domain.SearchPerformedEvent) error {
+	a.searches = append(a.searches, event)
+	return nil
+}
+
+func (a *analyticsStub) RecordClick(_ context.Context, event domain.SearchResultClickedEvent) error {
+	a.clicks = append(a.clicks, event)
+	return nil
+}
+
+func (a *analyticsStub) Report(context.Context, int) (*domain.AnalyticsReport, error) {
+	return &domain.AnalyticsReport{}, nil
+}
+
+func TestSearchService_SearchPerformedEvent(t *testing.T) {
+	router := new(eventRouterStub)
+	service := &application.SearchService{
+		SearchService: &federationSearchServiceStub{results: map[string]domain.Result{
+			"product": {SearchMeta: domain.SearchMeta{Page: 2, NumPages: 3, NumResults: 5}},
+			"page":    {SearchMeta: domain.SearchMeta{Page: 1, NumPages: 1, NumResults: 1}},
+		}},
+		PaginationInfoFactory: &utils.PaginationInfoFactory{DefaultConfig: &utils.PaginationConfig{ShowAroundActivePageAmount: 1}},
+		Logger:                flamingo.NullLogger{},
+		EventRouter:           router,
+	}
+	httpRequest, _ := http.NewRequest(http.MethodGet, "/search?q=shirt", nil)
+	ctx := web.ContextWithRequest(context.Background(), web.CreateRequest(httpRequest, nil))
+	searchRequest := application.SearchRequest{
+		Query:        " Red  Shirt",
+		Page:         2,
+		FilterParams: map[string][]string{"color": {"red"}},
+	}
+
+	_, err := service.Find(ctx, searchRequest)
+	assert.NoError(t, err)
+	_, err = service.FindBy(ctx, "product", searchRequest)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []flamingo.Event{
+		&domain.SearchPerformedEvent{
+			Query:         "red shirt",
+			Filters:       map[string][]string{"color": {"red"}},
+			NumResults:    6,
+			ResultsByType: map[string]int{"product": 5, "page": 1},
+			Page:          2,
+		},
+		&domain.SearchPerformedEvent{
+			Query:         "red shirt",
+			DocumentType:  "product",
+			Filters:       map[string][]string{"color": {"red"}},
+			NumResults:    5,
+			ResultsByType: map[string]int{"product": 5},
+			Page:          2,
+		},
+	}, router.events)
+}
+
+func TestAnalyticsEventReceiver_Notify(t *testing.T) {
+	store := new(analyticsStub)
+	receiver := new(application.AnalyticsEventReceiver)
+	receiver.Inject(store, flamingo.NullLogger{})
+
+	receiver.Notify(context.Background(), &domain.SearchPerformedEvent{Query: "shirt"})
+	receiver.Notify(context.Background(), &domain.SearchResultClickedEvent{Query: "shirt", DocumentID: "1"})
+	receiver.Notify(context.Background(), "other event")
+
+	assert.Equal(t, []domain.SearchPerformedEvent{{Query: "shirt"}}, store.searches)
+	assert.Equal(t, []domain.SearchResultClickedEvent{{Query: "shirt", DocumentID: "1"}}, store.clicks)
+}
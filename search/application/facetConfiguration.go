@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	localeApplication "flamingo.me/flamingo/v3/core/locale/application"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// FacetConfigurationService applies the configured domain.FacetConfig of the searched category to the facets of search results.
+	// The configuration "commerce.search.facets.default" is overwritten by "commerce.search.facets.categories.<code>" and the bound domain.FacetConfigProviders
+	FacetConfigurationService struct {
+		defaultConfig    domain.FacetConfig
+		categoryConfigs  map[string]domain.FacetConfig
+		providers        []domain.FacetConfigProvider
+		labelService     *localeApplication.LabelService
+		logger           flamingo.Logger
+		hasConfiguration bool
+	}
+)
+
+// Inject dependencies
+func (s *FacetConfigurationService) Inject(
+	logger flamingo.Logger,
+	cfg *struct {
+		Default    config.Map `inject:"config:commerce.search.facets.default,optional"`
+		Categories config.Map `inject:"config:commerce.search.facets.categories,optional"`
+	},
+	optionals *struct {
+		Providers    []domain.FacetConfigProvider    `inject:",optional"`
+		LabelService *localeApplication.LabelService `inject:",optional"`
+	},
+) {
+	s.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "application.FacetConfigurationService")
+	s.categoryConfigs = make(map[string]domain.FacetConfig)
+	if cfg != nil {
+		if err := DecodeFacetConfig(cfg.Default, &s.defaultConfig); err != nil {
+			s.logger.Error("invalid default facet configuration: ", err)
+		}
+		for code, categoryConfig := range cfg.Categories {
+			var facetConfig domain.FacetConfig
+			if err := DecodeFacetConfig(categoryConfig, &facetConfig); err != nil {
+				s.logger.Error("invalid facet configuration of category ", code, ": ", err)
+				continue
+			}
+			s.categoryConfigs[code] = facetConfig
+		}
+	}
+	if optionals != nil {
+		s.providers = optionals.Providers
+		s.labelService = optionals.LabelService
+	}
+	s.hasConfiguration = (cfg != nil && len(cfg.Default) > 0) || len(s.categoryConfigs) > 0 || len(s.providers) > 0
+}
+
+// DecodeFacetConfig decodes a facet configuration given as config map or as json string (e.g. of a category attribute)
+func DecodeFacetConfig(value interface{}, facetConfig *domain.FacetConfig) error {
+	if value == nil {
+		return nil
+	}
+	data, ok := value.(string)
+	if !ok {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		data = string(encoded)
+	}
+	return json.Unmarshal([]byte(data), facetConfig)
+}
+
+// Apply returns the facets configured for the category of the filters (the last code of the filter "category") - the facets are not changed
+func (s *FacetConfigurationService) Apply(ctx context.Context, filters []domain.Filter, facets domain.FacetCollection) domain.FacetCollection {
+	if s == nil || !s.hasConfiguration || len(facets) == 0 {
+		return facets
+	}
+
+	facetConfig := s.FacetConfig(ctx, categoryCode(filters))
+	return facetConfig.Apply(facets, s.translate)
+}
+
+// FacetConfig returns the merged facet configuration of the category
+func (s *FacetConfigurationService) FacetConfig(ctx context.Context, categoryCode string) domain.FacetConfig {
+	facetConfig := s.defaultConfig
+	if categoryConfig, ok := s.categoryConfigs[categoryCode]; ok {
+		facetConfig = facetConfig.Merge(categoryConfig)
+	}
+	for _, provider := range s.providers {
+		providedConfig, err := provider.FacetConfig(ctx, categoryCode)
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("facet configuration of category ", categoryCode, " could not be loaded: ", err)
+			continue
+		}
+		if providedConfig != nil {
+			facetConfig = facetConfig.Merge(*providedConfig)
+		}
+	}
+	return facetConfig
+}
+
+// translate returns the translation of the label - labels without translation are used as they are
+func (s *FacetConfigurationService) translate(label string) string {
+	if s.labelService == nil {
+		return label
+	}
+	return s.labelService.NewLabel(label).SetDefaultLabel(label).String()
+}
+
+// categoryCode returns the last category code of the filter "category"
+func categoryCode(filters []domain.Filter) string {
+	for _, filter := range filters {
+		key, values := filter.Value()
+		if key != "category" || len(values) == 0 {
+			continue
+		}
+		codes := strings.Split(values[0], domain.TreePathSeparator)
+		return codes[len(codes)-1]
+	}
+	return ""
+}
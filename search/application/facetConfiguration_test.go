@@ -0,0 +1,85 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/application"
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	localeApplication "flamingo.me/flamingo/v3/core/locale/application"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type facetConfigProviderStub struct {
+	categoryCode string
+}
+
+func (p *facetConfigProviderStub) FacetConfig(_ context.Context, categoryCode string) (*domain.FacetConfig, error) {
+	p.categoryCode = categoryCode
+	return &domain.FacetConfig{Facets: map[string]domain.FacetSettings{"color": {MaxItems: 1}}}, nil
+}
+
+func TestFacetConfigurationService_Apply(t *testing.T) {
+	provider := new(facetConfigProviderStub)
+	service := new(application.FacetConfigurationService)
+	service.Inject(flamingo.NullLogger{}, &struct {
+		Default    config.Map `inject:"config:commerce.search.facets.default,optional"`
+		Categories config.Map `inject:"config:commerce.search.facets.categories,optional"`
+	}{
+		Default: config.Map{"exclude": config.Slice{"brand"}},
+		Categories: config.Map{
+			"shirts": config.Map{"facets": config.Map{"color": config.Map{"label": "Shirt color", "sort": "alpha"}}},
+			"shoes":  `{"include": ["size"]}`,
+		},
+	}, &struct {
+		Providers    []domain.FacetConfigProvider    `inject:",optional"`
+		LabelService *localeApplication.LabelService `inject:",optional"`
+	}{Providers: []domain.FacetConfigProvider{provider}})
+
+	facets := domain.FacetCollection{
+		"color": {Type: string(domain.ListFacet), Name: "color", Label: "Color", Items: []*domain.FacetItem{{Label: "Red", Value: "red"}, {Label: "Blue", Value: "blue"}}},
+		"brand": {Type: string(domain.ListFacet), Name: "brand"},
+		"size":  {Type: string(domain.ListFacet), Name: "size"},
+	}
+
+	configured := service.Apply(context.Background(), []domain.Filter{domain.NewTreeFilter("category", "clothing", "shirts")}, facets)
+	assert.Equal(t, "shirts", provider.categoryCode, "the last code of the category filter is the category")
+	assert.NotContains(t, configured, "brand", "the default configuration applies to all categories")
+	if assert.Contains(t, configured, "color") {
+		assert.Equal(t, "Shirt color", configured["color"].Label)
+		assert.Equal(t, []*domain.FacetItem{{Label: "Blue", Value: "blue"}}, configured["color"].Items, "the provided configuration is merged")
+	}
+
+	configured = service.Apply(context.Background(), []domain.Filter{domain.NewKeyValueFilter("category", []string{"shoes"})}, facets)
+	assert.Len(t, configured, 1)
+	assert.Contains(t, configured, "size", "the category configuration can be a json string")
+
+	configured = service.Apply(context.Background(), nil, facets)
+	assert.Equal(t, "", provider.categoryCode)
+	assert.Equal(t, "Color", configured["color"].Label)
+
+	var unconfigured *application.FacetConfigurationService
+	assert.Equal(t, facets, unconfigured.Apply(context.Background(), nil, facets), "facets are returned as they are without configuration")
+}
+
+func TestFacetConfigurationService_ApplyTranslations(t *testing.T) {
+	service := new(application.FacetConfigurationService)
+	service.Inject(flamingo.NullLogger{}, &struct {
+		Default    config.Map `inject:"config:commerce.search.facets.default,optional"`
+		Categories config.Map `inject:"config:commerce.search.facets.categories,optional"`
+	}{
+		Default: config.Map{"facets": config.Map{"color": config.Map{"label": "search.facet.color", "itemLabels": config.Map{"red": "Rot"}}}},
+	}, &struct {
+		Providers    []domain.FacetConfigProvider    `inject:",optional"`
+		LabelService *localeApplication.LabelService `inject:",optional"`
+	}{LabelService: new(localeApplication.LabelService)})
+
+	configured := service.Apply(context.Background(), nil, domain.FacetCollection{
+		"color": {Type: string(domain.ListFacet), Name: "color", Items: []*domain.FacetItem{{Label: "Red", Value: "red"}}},
+	})
+	assert.Equal(t, "search.facet.color", configured["color"].Label, "labels without translation are used as they are")
+	assert.Equal(t, "Rot", configured["color"].Items[0].Label)
+}
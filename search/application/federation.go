@@ -0,0 +1,281 @@
+package application
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"sort"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/utils"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// FederationOptions of a federated search (see SearchService.FindFederated)
+	FederationOptions struct {
+		// TypeWeights multiply the scores of the hits of a document type - types without weight have the weight 1, types with a weight of 0 or less are left out
+		TypeWeights map[string]float64
+	}
+
+	// FederatedResult contains the hits of all document types in one ranked list and the combined facets
+	FederatedResult struct {
+		Hits           []FederatedHit
+		SearchMeta     domain.SearchMeta
+		Facets         domain.FacetCollection
+		Suggestions    []domain.Suggestion
+		PaginationInfo utils.PaginationInfo
+		// Results are the results of the document types
+		Results map[string]*SearchResult
+	}
+
+	// FederatedHit is a document with its type and its blended score
+	FederatedHit struct {
+		Type     string
+		Document domain.Document
+		Score    float64
+	}
+
+	// DocumentIdentifier returns the identity of documents, so that federated results contain them only once - bind it with injector.BindMulti.
+	// Documents without identity are deduplicated if they are comparable and equal
+	DocumentIdentifier interface {
+		DocumentID(documentType string, document domain.Document) (id string, ok bool)
+	}
+)
+
+// federationRankConstant dampens the rank in the score weight / (federationRankConstant + rank), so that the weights blend the types instead of sorting them
+const federationRankConstant = 10
+
+// FindFederated searches all document types and merges their hits into one list ranked by the weighted reciprocal rank of the hits in their type.
+// Each type contributes the hits of the requested page, duplicates keep their best score. The facets of the types are combined (see MergeFacets)
+func (s *SearchService) FindFederated(ctx context.Context, searchRequest SearchRequest) (*FederatedResult, error) {
+	results, err := s.Find(ctx, searchRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	options := FederationOptions{}
+	if searchRequest.Federation != nil {
+		options = *searchRequest.Federation
+	}
+
+	federated := &FederatedResult{
+		Hits:    []FederatedHit{},
+		Facets:  domain.FacetCollection{},
+		Results: results,
+	}
+
+	pageSize := searchRequest.PageSize
+	if pageSize == 0 {
+		pageSize = int(s.DefaultPageSize)
+	}
+
+	documentTypes := make([]string, 0, len(results))
+	for documentType := range results {
+		if options.weight(documentType) > 0 {
+			documentTypes = append(documentTypes, documentType)
+		}
+	}
+	sort.Strings(documentTypes)
+
+	positions := make(map[interface{}]int)
+	facets := make([]domain.FacetCollection, 0, len(documentTypes))
+	suggestions := make(map[string]bool)
+	for _, documentType := range documentTypes {
+		result := results[documentType]
+		weight := options.weight(documentType)
+		offset := 0
+		if result.SearchMeta.Page > 1 && pageSize > 0 {
+			offset = (result.SearchMeta.Page - 1) * pageSize
+		}
+
+		for i, document := range result.Hits {
+			hit := FederatedHit{
+				Type:     documentType,
+				Document: document,
+				Score:    weight / float64(federationRankConstant+offset+i+1),
+			}
+			key, comparable := s.documentKey(documentType, document)
+			if !comparable {
+				federated.Hits = append(federated.Hits, hit)
+				continue
+			}
+			if position, duplicate := positions[key]; duplicate {
+				if hit.Score > federated.Hits[position].Score {
+					federated.Hits[position] = hit
+				}
+				continue
+			}
+			positions[key] = len(federated.Hits)
+			federated.Hits = append(federated.Hits, hit)
+		}
+
+		facets = append(facets, result.Facets)
+		federated.SearchMeta.SelectedFacets = append(federated.SearchMeta.SelectedFacets, result.SearchMeta.SelectedFacets...)
+		for _, suggestion := range result.Suggestions {
+			if !suggestions[suggestion.Text] {
+				suggestions[suggestion.Text] = true
+				federated.Suggestions = append(federated.Suggestions, suggestion)
+			}
+		}
+
+		federated.SearchMeta.Query = result.SearchMeta.Query
+		federated.SearchMeta.OriginalQuery = result.SearchMeta.OriginalQuery
+		federated.SearchMeta.NumResults += result.SearchMeta.NumResults
+		if result.SearchMeta.Page > federated.SearchMeta.Page {
+			federated.SearchMeta.Page = result.SearchMeta.Page
+		}
+		if result.SearchMeta.NumPages > federated.SearchMeta.NumPages {
+			federated.SearchMeta.NumPages = result.SearchMeta.NumPages
+		}
+	}
+
+	sort.SliceStable(federated.Hits, func(i, j int) bool {
+		return federated.Hits[i].Score > federated.Hits[j].Score
+	})
+	federated.Facets = MergeFacets(facets...)
+	federated.SearchMeta.SelectedFacets = mergeFacetList(federated.SearchMeta.SelectedFacets)
+
+	if searchRequest.PaginationConfig == nil {
+		searchRequest.PaginationConfig = s.PaginationInfoFactory.DefaultConfig
+	}
+	var currentURL *url.URL
+	if request := web.RequestFromContext(ctx); request != nil {
+		currentURL = request.Request().URL
+	}
+	federated.PaginationInfo = utils.BuildWith(utils.CurrentResultInfos{
+		LastPage:   federated.SearchMeta.NumPages,
+		TotalHits:  federated.SearchMeta.NumResults,
+		PageSize:   searchRequest.PageSize,
+		ActivePage: federated.SearchMeta.Page,
+	}, *searchRequest.PaginationConfig, currentURL)
+
+	return federated, nil
+}
+
+func (o FederationOptions) weight(documentType string) float64 {
+	if weight, ok := o.TypeWeights[documentType]; ok {
+		return weight
+	}
+	return 1
+}
+
+// documentKey returns the identity of the document given by the DocumentIdentifiers or the document itself if it is comparable
+func (s *SearchService) documentKey(documentType string, document domain.Document) (interface{}, bool) {
+	for _, identifier := range s.DocumentIdentifiers {
+		if id, ok := identifier.DocumentID(documentType, document); ok {
+			return id, true
+		}
+	}
+	if document == nil || !isComparable(reflect.ValueOf(document)) {
+		return nil, false
+	}
+	return document, true
+}
+
+// isComparable checks the value and not only its type - a comparable struct type may contain interface fields holding slices or maps,
+// which let the comparison (and the use as map key) panic
+func isComparable(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Interface:
+		return value.IsNil() || isComparable(value.Elem())
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !isComparable(value.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if !isComparable(value.Index(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return value.Type().Comparable()
+	}
+}
+
+// MergeFacets combines the facets of the same name - the counts of items with the same value are added up,
+// items of tree facets are merged recursively and range items span the ranges of all items.
+// Facets with the same name but another type are left out
+func MergeFacets(collections ...domain.FacetCollection) domain.FacetCollection {
+	var facets []domain.Facet
+	for _, collection := range collections {
+		for _, name := range collection.Order() {
+			facets = append(facets, collection[name])
+		}
+	}
+
+	merged := domain.FacetCollection{}
+	for _, facet := range mergeFacetList(facets) {
+		merged[facet.Name] = facet
+	}
+	return merged
+}
+
+// mergeFacetList merges the facets of the same name and keeps the order of their first occurrence
+func mergeFacetList(facets []domain.Facet) []domain.Facet {
+	var result []domain.Facet
+	positions := make(map[string]int)
+	for _, facet := range facets {
+		position, ok := positions[facet.Name]
+		if !ok {
+			positions[facet.Name] = len(result)
+			facet.Items = mergeFacetItems(nil, facet.Items)
+			result = append(result, facet)
+			continue
+		}
+
+		existing := &result[position]
+		if existing.Type != facet.Type {
+			continue
+		}
+		if existing.Label == "" {
+			existing.Label = facet.Label
+		}
+		if facet.Position < existing.Position {
+			existing.Position = facet.Position
+		}
+		existing.Items = mergeFacetItems(existing.Items, facet.Items)
+	}
+	return result
+}
+
+// mergeFacetItems returns copies of the items, items with the same value are merged
+func mergeFacetItems(items []*domain.FacetItem, additional []*domain.FacetItem) []*domain.FacetItem {
+	byValue := make(map[string]*domain.FacetItem, len(items))
+	for _, item := range items {
+		byValue[item.Value] = item
+	}
+
+	for _, item := range additional {
+		existing, ok := byValue[item.Value]
+		if !ok {
+			copied := *item
+			copied.Items = mergeFacetItems(nil, item.Items)
+			byValue[item.Value] = &copied
+			items = append(items, &copied)
+			continue
+		}
+
+		if existing.Label == "" {
+			existing.Label = item.Label
+		}
+		existing.Active = existing.Active || item.Active
+		existing.Selected = existing.Selected || item.Selected
+		existing.Count += item.Count
+		if item.Min < existing.Min {
+			existing.Min = item.Min
+		}
+		if item.Max > existing.Max {
+			existing.Max = item.Max
+		}
+		existing.Items = mergeFacetItems(existing.Items, item.Items)
+	}
+	return items
+}
@@ -0,0 +1,170 @@
+package application_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/application"
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/utils"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	federationSearchServiceStub struct {
+		results map[string]domain.Result
+	}
+
+	codeIdentifier struct{}
+
+	document struct {
+		code  string
+		title string
+	}
+
+	genericDocument struct {
+		Data interface{}
+	}
+)
+
+func (s *federationSearchServiceStub) Search(context.Context, ...domain.Filter) (map[string]domain.Result, error) {
+	return s.results, nil
+}
+
+func (s *federationSearchServiceStub) SearchFor(_ context.Context, typ string, _ ...domain.Filter) (*domain.Result, error) {
+	result := s.results[typ]
+	return &result, nil
+}
+
+func (codeIdentifier) DocumentID(_ string, doc domain.Document) (string, bool) {
+	d, ok := doc.(document)
+	return d.code, ok
+}
+
+func TestSearchService_FindFederated(t *testing.T) {
+	service := &application.SearchService{
+		SearchService: &federationSearchServiceStub{results: map[string]domain.Result{
+			"product": {
+				SearchMeta: domain.SearchMeta{Query: "shirt", Page: 1, NumPages: 3, NumResults: 5},
+				Hits:       []domain.Document{document{code: "a"}, document{code: "b"}},
+				Facets: domain.FacetCollection{
+					"color": {Type: string(domain.ListFacet), Name: "color", Position: 2, Items: []*domain.FacetItem{{Value: "red", Count: 2}, {Value: "blue", Count: 1}}},
+					"price": {Type: domain.RangeFacet, Name: "price", Items: []*domain.FacetItem{{Min: 10, Max: 20}}},
+				},
+				Suggestion: []domain.Suggestion{{Text: "shirts"}},
+			},
+			"bestseller": {
+				SearchMeta: domain.SearchMeta{Query: "shirt", Page: 1, NumPages: 1, NumResults: 1},
+				Hits:       []domain.Document{document{code: "b", title: "bestseller"}},
+			},
+			"content": {
+				SearchMeta: domain.SearchMeta{Query: "shirt", Page: 1, NumPages: 1, NumResults: 2},
+				Hits:       []domain.Document{"guide", []string{"not comparable"}},
+				Facets: domain.FacetCollection{
+					"color": {Type: string(domain.ListFacet), Name: "color", Label: "Color", Position: 1, Items: []*domain.FacetItem{{Value: "red", Count: 1}, {Value: "green", Count: 4}}},
+					"price": {Type: domain.RangeFacet, Name: "price", Items: []*domain.FacetItem{{Min: 5, Max: 15}}},
+				},
+				Suggestion: []domain.Suggestion{{Text: "shirts"}, {Text: "shirt guide"}},
+			},
+			"hidden": {
+				Hits: []domain.Document{"hidden"},
+			},
+		}},
+		PaginationInfoFactory: &utils.PaginationInfoFactory{DefaultConfig: &utils.PaginationConfig{ShowAroundActivePageAmount: 1}},
+		DefaultPageSize:       2,
+		Logger:                flamingo.NullLogger{},
+		DocumentIdentifiers:   []application.DocumentIdentifier{codeIdentifier{}},
+	}
+
+	httpRequest, _ := http.NewRequest(http.MethodGet, "/search?q=shirt", nil)
+	ctx := web.ContextWithRequest(context.Background(), web.CreateRequest(httpRequest, nil))
+	result, err := service.FindFederated(ctx, application.SearchRequest{
+		Query:      "shirt",
+		Federation: &application.FederationOptions{TypeWeights: map[string]float64{"product": 2, "bestseller": 3, "hidden": 0}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []application.FederatedHit{
+		{Type: "bestseller", Document: document{code: "b", title: "bestseller"}, Score: 3.0 / 11},
+		{Type: "product", Document: document{code: "a"}, Score: 2.0 / 11},
+		{Type: "content", Document: "guide", Score: 1.0 / 11},
+		{Type: "content", Document: []string{"not comparable"}, Score: 1.0 / 12},
+	}, result.Hits, "duplicates keep their best score, types with weight 0 are left out")
+	assert.Len(t, result.Results, 4)
+
+	assert.Equal(t, "shirt", result.SearchMeta.Query)
+	assert.Equal(t, 8, result.SearchMeta.NumResults)
+	assert.Equal(t, 3, result.SearchMeta.NumPages)
+	assert.Equal(t, 1, result.SearchMeta.Page)
+	assert.Equal(t, 2, result.PaginationInfo.NextPage.Page)
+	assert.Equal(t, []domain.Suggestion{{Text: "shirts"}, {Text: "shirt guide"}}, result.Suggestions)
+
+	if assert.Contains(t, result.Facets, "color") {
+		color := result.Facets["color"]
+		assert.Equal(t, "Color", color.Label)
+		assert.Equal(t, 1, color.Position)
+		assert.Equal(t, []*domain.FacetItem{{Value: "red", Count: 3}, {Value: "green", Count: 4}, {Value: "blue", Count: 1}}, color.Items)
+	}
+	if assert.Contains(t, result.Facets, "price") {
+		assert.Equal(t, []*domain.FacetItem{{Min: 5, Max: 20}}, result.Facets["price"].Items)
+	}
+}
+
+func TestSearchService_FindFederatedUncomparableValues(t *testing.T) {
+	service := &application.SearchService{
+		SearchService: &federationSearchServiceStub{results: map[string]domain.Result{
+			"content": {
+				SearchMeta: domain.SearchMeta{Query: "shirt", Page: 1, NumPages: 1, NumResults: 4},
+				Hits: []domain.Document{
+					genericDocument{Data: []string{"slice"}},
+					genericDocument{Data: []string{"slice"}},
+					genericDocument{Data: "text"},
+					genericDocument{Data: "text"},
+				},
+			},
+		}},
+		PaginationInfoFactory: &utils.PaginationInfoFactory{DefaultConfig: &utils.PaginationConfig{ShowAroundActivePageAmount: 1}},
+		DefaultPageSize:       10,
+		Logger:                flamingo.NullLogger{},
+	}
+
+	httpRequest, _ := http.NewRequest(http.MethodGet, "/search?q=shirt", nil)
+	ctx := web.ContextWithRequest(context.Background(), web.CreateRequest(httpRequest, nil))
+	var result *application.FederatedResult
+	var err error
+	assert.NotPanics(t, func() {
+		result, err = service.FindFederated(ctx, application.SearchRequest{Query: "shirt", Federation: &application.FederationOptions{}})
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, result.Hits, 3, "documents with comparable values are deduplicated, the others are kept")
+	}
+}
+
+func TestMergeFacets(t *testing.T) {
+	first := domain.FacetCollection{
+		"category": {Type: domain.TreeFacet, Name: "category", Items: []*domain.FacetItem{
+			{Value: "clothing", Count: 2, Items: []*domain.FacetItem{{Value: "shirts", Count: 2}}},
+		}},
+	}
+	second := domain.FacetCollection{
+		"category": {Type: domain.TreeFacet, Name: "category", Items: []*domain.FacetItem{
+			{Value: "clothing", Count: 1, Active: true, Items: []*domain.FacetItem{{Value: "shirts", Count: 1}, {Value: "shoes", Count: 3}}},
+		}},
+	}
+	other := domain.FacetCollection{
+		"category": {Type: string(domain.ListFacet), Name: "category", Items: []*domain.FacetItem{{Value: "clothing", Count: 10}}},
+	}
+
+	merged := application.MergeFacets(first, second, other)
+
+	assert.Equal(t, []*domain.FacetItem{
+		{Value: "clothing", Count: 3, Active: true, Items: []*domain.FacetItem{{Value: "shirts", Count: 3}, {Value: "shoes", Count: 3}}},
+	}, merged["category"].Items, "tree items are merged recursively, facets of another type are left out")
+	assert.Equal(t, int64(2), first["category"].Items[0].Count, "the merged facets are not changed")
+}
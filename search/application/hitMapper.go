@@ -0,0 +1,16 @@
+package application
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type (
+	// APIHitMapper maps the documents of a type to their stable json representation for the search api - bind it with injector.BindMulti.
+	// Hits of types without mapper are returned as they are
+	APIHitMapper interface {
+		DocumentType() string
+		MapHit(ctx context.Context, document domain.Document) (interface{}, error)
+	}
+)
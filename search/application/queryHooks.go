@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"net/url"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+// RunQueryHooks passes a copy of the url query to the hooks and returns the resulting query.
+// The error of the first failing hook is returned, e.g. a domain.RedirectError
+func RunQueryHooks(ctx context.Context, hooks []domain.RequestQueryHook, path string, query url.Values) (url.Values, error) {
+	result := make(url.Values, len(query))
+	for key, values := range query {
+		result[key] = append([]string(nil), values...)
+	}
+
+	for _, hook := range hooks {
+		if err := hook.Hook(ctx, path, &result); err != nil {
+			return query, err
+		}
+	}
+	return result, nil
+}
+
+// ApplyQueryHooks runs the hooks for the query and the filter params of the search request.
+// A rewritten query "q" replaces the query, the filter param and the key value filter "q" of the request
+func ApplyQueryHooks(ctx context.Context, hooks []domain.RequestQueryHook, path string, request *SearchRequest) error {
+	if len(hooks) == 0 || request == nil || request.Query == "" {
+		return nil
+	}
+
+	query := make(url.Values, len(request.FilterParams)+1)
+	for key, values := range request.FilterParams {
+		query[key] = values
+	}
+	query.Set("q", request.Query)
+
+	result, err := RunQueryHooks(ctx, hooks, path, query)
+	if err != nil {
+		return err
+	}
+
+	rewritten := result.Get("q")
+	if rewritten == request.Query {
+		return nil
+	}
+	request.Query = rewritten
+	if _, ok := request.FilterParams["q"]; ok {
+		request.FilterParams["q"] = []string{rewritten}
+	}
+	for i, filter := range request.AdditionalFilter {
+		if keyValueFilter, ok := filter.(*domain.KeyValueFilter); ok {
+			if key, _ := keyValueFilter.Value(); key == "q" {
+				request.AdditionalFilter[i] = domain.NewKeyValueFilter("q", []string{rewritten})
+			}
+		}
+	}
+	return nil
+}
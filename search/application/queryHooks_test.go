@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type synonymHook map[string]string
+
+func (h synonymHook) Hook(_ context.Context, _ string, query *url.Values) error {
+	if synonym, ok := h[query.Get("q")]; ok {
+		query.Set("q", synonym)
+	}
+	return nil
+}
+
+func TestApplyQueryHooks(t *testing.T) {
+	request := &SearchRequest{
+		Query:            "tee",
+		FilterParams:     map[string][]string{"q": {"tee"}, "color": {"red"}},
+		AdditionalFilter: []domain.Filter{domain.NewKeyValueFilter("q", []string{"tee"}), domain.NewKeyValueFilter("size", []string{"m"})},
+	}
+
+	assert.NoError(t, ApplyQueryHooks(context.Background(), []domain.RequestQueryHook{synonymHook{"tee": "shirt"}}, "/search", request))
+	assert.Equal(t, "shirt", request.Query)
+	assert.Equal(t, map[string][]string{"q": {"shirt"}, "color": {"red"}}, request.FilterParams)
+	assert.Equal(t, []domain.Filter{domain.NewKeyValueFilter("q", []string{"shirt"}), domain.NewKeyValueFilter("size", []string{"m"})}, request.AdditionalFilter)
+
+	query := url.Values{"q": {"tee"}}
+	result, err := RunQueryHooks(context.Background(), []domain.RequestQueryHook{synonymHook{"tee": "shirt"}}, "/search", query)
+	assert.NoError(t, err)
+	assert.Equal(t, "shirt", result.Get("q"))
+	assert.Equal(t, "tee", query.Get("q"), "the given query is not modified")
+}
@@ -21,17 +21,28 @@ type (
 		PaginationInfoFactory *utils.PaginationInfoFactory `inject:""`
 		DefaultPageSize       float64                      `inject:"config:pagination.defaultPageSize,optional"`
 		Logger                flamingo.Logger              `inject:""`
+		DocumentIdentifiers   []DocumentIdentifier         `inject:",optional"`
+		// EventRouter is used to dispatch the domain.SearchPerformedEvent
+		EventRouter flamingo.EventRouter `inject:",optional"`
+		// FacetConfiguration is applied to the facets of the results
+		FacetConfiguration *FacetConfigurationService `inject:""`
 	}
 
 	// SearchRequest is a simple DTO for the search query data
 	SearchRequest struct {
 		AdditionalFilter []domain.Filter
+		// FilterParams are url parameters that are converted to key value, typed or composite filters (see domain.NewKeyValueFilters) - the search and category controllers pass the url query
+		FilterParams     map[string][]string
 		PageSize         int
 		Page             int
 		SortBy           string
 		SortDirection    string
 		Query            string
 		PaginationConfig *utils.PaginationConfig
+		// Cursor continues the result at a cursor of the SearchMeta instead of a page (if the search backend supports cursor pagination)
+		Cursor string
+		// Federation configures the ranking of FindFederated
+		Federation *FederationOptions
 	}
 
 	// SearchResult is the DTO for the search result
@@ -64,10 +75,12 @@ func (s *SearchService) FindBy(ctx context.Context, documentType string, searchR
 		pageSize = int(s.DefaultPageSize)
 	}
 
-	result, err := s.SearchService.SearchFor(ctx, documentType, BuildFilters(searchRequest, pageSize)...)
+	filters := BuildFilters(searchRequest, pageSize)
+	result, err := s.SearchService.SearchFor(ctx, documentType, filters...)
 	if err != nil {
 		return nil, err
 	}
+	s.dispatchSearchPerformed(ctx, documentType, filters, map[string]domain.Result{documentType: *result})
 
 	// do a logical pageSize check - and log warning
 	//  10 pageSize * (3 pages* -1 ) + lastPageSize = 35 results*
@@ -78,15 +91,17 @@ func (s *SearchService) FindBy(ctx context.Context, documentType string, searchR
 	}
 
 	paginationInfo := utils.BuildWith(utils.CurrentResultInfos{
-		LastPage:   result.SearchMeta.NumPages,
-		TotalHits:  result.SearchMeta.NumResults,
-		PageSize:   searchRequest.PageSize,
-		ActivePage: result.SearchMeta.Page,
+		LastPage:       result.SearchMeta.NumPages,
+		TotalHits:      result.SearchMeta.NumResults,
+		PageSize:       searchRequest.PageSize,
+		ActivePage:     result.SearchMeta.Page,
+		NextCursor:     result.SearchMeta.NextCursor,
+		PreviousCursor: result.SearchMeta.PreviousCursor,
 	}, *searchRequest.PaginationConfig, currentURL)
 
 	return &SearchResult{
 		SearchMeta:     result.SearchMeta,
-		Facets:         result.Facets,
+		Facets:         s.FacetConfiguration.Apply(ctx, filters, result.Facets),
 		Suggestions:    result.Suggestion,
 		Hits:           result.Hits,
 		PaginationInfo: paginationInfo,
@@ -113,10 +128,12 @@ func (s *SearchService) Find(ctx context.Context, searchRequest SearchRequest) (
 		pageSize = int(s.DefaultPageSize)
 	}
 
-	result, err := s.SearchService.Search(ctx, BuildFilters(searchRequest, pageSize)...)
+	filters := BuildFilters(searchRequest, pageSize)
+	result, err := s.SearchService.Search(ctx, filters...)
 	if err != nil {
 		return nil, err
 	}
+	s.dispatchSearchPerformed(ctx, "", filters, result)
 
 	// do a logical pageSize check - and log warning
 	//  10 pageSize * (3 pages* -1 ) + lastPageSize = 35 results*
@@ -132,15 +149,17 @@ func (s *SearchService) Find(ctx context.Context, searchRequest SearchRequest) (
 
 	for k, r := range result {
 		paginationInfo := utils.BuildWith(utils.CurrentResultInfos{
-			LastPage:   r.SearchMeta.NumPages,
-			TotalHits:  r.SearchMeta.NumResults,
-			PageSize:   searchRequest.PageSize,
-			ActivePage: r.SearchMeta.Page,
+			LastPage:       r.SearchMeta.NumPages,
+			TotalHits:      r.SearchMeta.NumResults,
+			PageSize:       searchRequest.PageSize,
+			ActivePage:     r.SearchMeta.Page,
+			NextCursor:     r.SearchMeta.NextCursor,
+			PreviousCursor: r.SearchMeta.PreviousCursor,
 		}, *searchRequest.PaginationConfig, currentURL)
 
 		searchResult[k] = &SearchResult{
 			SearchMeta:     r.SearchMeta,
-			Facets:         r.Facets,
+			Facets:         s.FacetConfiguration.Apply(ctx, filters, r.Facets),
 			Suggestions:    r.Suggestion,
 			Hits:           r.Hits,
 			PaginationInfo: paginationInfo,
@@ -161,6 +180,10 @@ func BuildFilters(request SearchRequest, defaultPageSize int) []domain.Filter {
 		filters = append(filters, domain.NewPaginationPageFilter(request.Page))
 	}
 
+	if request.Cursor != "" {
+		filters = append(filters, domain.NewPaginationCursorFilter(request.Cursor))
+	}
+
 	if request.PageSize != 0 {
 		filters = append(filters, domain.NewPaginationPageSizeFilter(request.PageSize))
 	} else if defaultPageSize != 0 {
@@ -175,6 +198,14 @@ func BuildFilters(request SearchRequest, defaultPageSize int) []domain.Filter {
 		filters = append(filters, additionalFilter)
 	}
 
+	for _, filter := range domain.NewKeyValueFilters(request.FilterParams) {
+		// the cursor of the request replaces the cursor parameter, so that only one cursor is searched
+		if _, isCursor := filter.(*domain.PaginationCursor); isCursor && request.Cursor != "" {
+			continue
+		}
+		filters = append(filters, filter)
+	}
+
 	return filters
 }
 
@@ -59,10 +59,51 @@ func TestBuildFilters(t *testing.T) {
 				domain.NewKeyValueFilter("key", []string{"value1", "value2"}),
 			},
 		},
+		{
+			name: "filter params",
+			args: args{
+				request: SearchRequest{
+					AdditionalFilter: []domain.Filter{domain.NewKeyValueFilter("key", []string{"value1"})},
+					FilterParams:     map[string][]string{"category:tree": {"clothing/shirts"}, "inStock:bool": {"true"}},
+				},
+			},
+			want: []domain.Filter{
+				domain.NewKeyValueFilter("key", []string{"value1"}),
+				domain.NewTreeFilter("category", "clothing", "shirts"),
+				domain.NewBoolFilter("inStock", true),
+			},
+		},
+		{
+			name: "cursor parameter",
+			args: args{
+				request: SearchRequest{
+					FilterParams: map[string][]string{domain.CursorParameter: {"abc"}},
+				},
+			},
+			want: []domain.Filter{
+				domain.NewPaginationCursorFilter("abc"),
+			},
+		},
+		{
+			name: "cursor of the request replaces the cursor parameter",
+			args: args{
+				request: SearchRequest{
+					Cursor:       "def",
+					FilterParams: map[string][]string{domain.CursorParameter: {"abc"}, "color": {"red"}},
+				},
+			},
+			want: []domain.Filter{
+				domain.NewPaginationCursorFilter("def"),
+				domain.NewKeyValueFilter("color", []string{"red"}),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := BuildFilters(tt.args.request, tt.args.defaultPageSize)
+			if len(got) != len(tt.want) {
+				t.Errorf("BuildFilters() returned %d filters, want %d", len(got), len(tt.want))
+			}
 			for i, want := range tt.want {
 				if len(got) <= i {
 					t.Fatalf("too few entries in filter: want %d, got %d", len(tt.want), len(got))
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// SearchPerformedEvent is dispatched by the search application service for every search
+	SearchPerformedEvent struct {
+		// Query is the normalized query (see NormalizeQuery)
+		Query string
+		// DocumentType of the search - empty for a search of all types
+		DocumentType string
+		// Filters are the normalized filters without query, pagination and sorting (see NormalizeFilters)
+		Filters map[string][]string
+		// NumResults of all searched types
+		NumResults int
+		// ResultsByType are the number of results of the searched types
+		ResultsByType map[string]int
+		Page          int
+	}
+
+	// SearchResultClickedEvent is dispatched by the click route when a search result is clicked
+	SearchResultClickedEvent struct {
+		// Query is the normalized query the result was found with
+		Query        string
+		DocumentType string
+		DocumentID   string
+		// Position of the result in the result list, starting with 1
+		Position int
+	}
+
+	// SearchAnalytics aggregates the search and click events
+	SearchAnalytics interface {
+		RecordSearch(ctx context.Context, event SearchPerformedEvent) error
+		RecordClick(ctx context.Context, event SearchResultClickedEvent) error
+		// Report returns at most limit queries of each list
+		Report(ctx context.Context, limit int) (*AnalyticsReport, error)
+	}
+
+	// AnalyticsReport contains the most searched queries and the most searched queries without results
+	AnalyticsReport struct {
+		TopQueries        []QueryStatistics `json:"topQueries"`
+		ZeroResultQueries []QueryStatistics `json:"zeroResultQueries"`
+	}
+
+	// QueryStatistics are the aggregated searches and clicks of a query
+	QueryStatistics struct {
+		Query       string `json:"query"`
+		Searches    int    `json:"searches"`
+		ZeroResults int    `json:"zeroResults"`
+		Clicks      int    `json:"clicks"`
+		// ClickThroughRate is the number of clicks per search
+		ClickThroughRate float64 `json:"clickThroughRate"`
+	}
+)
+
+var (
+	_ flamingo.Event = (*SearchPerformedEvent)(nil)
+	_ flamingo.Event = (*SearchResultClickedEvent)(nil)
+)
+
+// NormalizeQuery returns the lower case query without surrounding and repeated white space
+func NormalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// NormalizeFilters returns the url parameters of the filters (see NewFilterURLValues) with sorted values - without query, pagination and sort filters
+func NormalizeFilters(filters ...Filter) map[string][]string {
+	values := NewFilterURLValues(filters...)
+	for _, key := range []string{"q", "page", "limit", CursorParameter} {
+		values.Del(key)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string][]string, len(values))
+	for key, keyValues := range values {
+		sorted := append([]string(nil), keyValues...)
+		sort.Strings(sorted)
+		normalized[key] = sorted
+	}
+	return normalized
+}
@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	assert.Equal(t, "red shirt", domain.NormalizeQuery("  Red \t SHIRT "))
+	assert.Equal(t, "", domain.NormalizeQuery(" "))
+}
+
+func TestNormalizeFilters(t *testing.T) {
+	assert.Equal(t, map[string][]string{
+		"color":         {"blue", "red"},
+		"category:tree": {"clothing/shirts"},
+	}, domain.NormalizeFilters(
+		domain.NewQueryFilter("shirt"),
+		domain.NewPaginationPageFilter(2),
+		domain.NewPaginationPageSizeFilter(20),
+		domain.NewSortFilter("price", "asc"),
+		domain.NewKeyValueFilter("color", []string{"red", "blue"}),
+		domain.NewKeyValueFilter("page", []string{"2"}),
+		domain.NewTreeFilter("category", "clothing", "shirts"),
+	))
+	assert.Nil(t, domain.NormalizeFilters(domain.NewQueryFilter("shirt")))
+}
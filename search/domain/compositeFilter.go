@@ -0,0 +1,101 @@
+package domain
+
+type (
+	// AndFilter - matches if all of its filters match
+	AndFilter struct {
+		filters []Filter
+	}
+
+	// OrFilter - matches if any of its filters matches
+	OrFilter struct {
+		filters []Filter
+	}
+
+	// NotFilter - matches if its filter does not match
+	NotFilter struct {
+		filter Filter
+	}
+
+	// filterGroup is implemented by the composite filters that collect filters while parsing url parameters
+	filterGroup interface {
+		Filter
+		add(filter Filter)
+	}
+)
+
+var (
+	_ filterGroup = new(AndFilter)
+	_ filterGroup = new(OrFilter)
+	_ Filter      = new(NotFilter)
+)
+
+// NewAndFilter factory
+func NewAndFilter(filters ...Filter) *AndFilter {
+	return &AndFilter{
+		filters: filters,
+	}
+}
+
+// Value of the current filter - the url encoded parameters of each filter
+func (f *AndFilter) Value() (string, []string) {
+	return AndFilterPrefix, encodeFilters(f.filters)
+}
+
+// Filters returns the combined filters
+func (f *AndFilter) Filters() []Filter {
+	return f.filters
+}
+
+func (f *AndFilter) add(filter Filter) {
+	f.filters = append(f.filters, filter)
+}
+
+// NewOrFilter factory
+func NewOrFilter(filters ...Filter) *OrFilter {
+	return &OrFilter{
+		filters: filters,
+	}
+}
+
+// Value of the current filter - the url encoded parameters of each filter
+func (f *OrFilter) Value() (string, []string) {
+	return OrFilterPrefix, encodeFilters(f.filters)
+}
+
+// Filters returns the combined filters
+func (f *OrFilter) Filters() []Filter {
+	return f.filters
+}
+
+func (f *OrFilter) add(filter Filter) {
+	f.filters = append(f.filters, filter)
+}
+
+// NewNotFilter factory
+func NewNotFilter(filter Filter) *NotFilter {
+	return &NotFilter{
+		filter: filter,
+	}
+}
+
+// Value of the current filter - the value of the negated filter with the key prefixed by "not:"
+func (f *NotFilter) Value() (string, []string) {
+	if f.filter == nil {
+		return NotFilterPrefix, nil
+	}
+	key, values := f.filter.Value()
+	return NotFilterPrefix + FilterKeySeparator + key, values
+}
+
+// Filter returns the negated filter
+func (f *NotFilter) Filter() Filter {
+	return f.filter
+}
+
+func encodeFilters(filters []Filter) []string {
+	result := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		result = append(result, NewFilterURLValues(filter).Encode())
+	}
+	return result
+}
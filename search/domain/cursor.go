@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+type (
+	// PaginationCursor - if the search supports cursor pagination this filter continues a result at SearchMeta.NextCursor or SearchMeta.PreviousCursor.
+	// The cursor is opaque, only the search backend that returned it knows its content
+	PaginationCursor struct {
+		cursor string
+	}
+)
+
+// CursorParameter is the url parameter of the pagination cursor
+const CursorParameter = "cursor"
+
+// ErrInvalidCursor is returned by DecodeCursor for cursors that were not created by EncodeCursor
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// NewPaginationCursorFilter factory
+func NewPaginationCursorFilter(cursor string) *PaginationCursor {
+	return &PaginationCursor{
+		cursor: cursor,
+	}
+}
+
+// Value of the current filter
+func (f *PaginationCursor) Value() (string, []string) {
+	return CursorParameter, []string{f.cursor}
+}
+
+// Cursor returns the opaque cursor
+func (f *PaginationCursor) Cursor() string {
+	return f.cursor
+}
+
+// EncodeCursor returns the position as url safe cursor - search backends can use it to create their opaque cursors
+func EncodeCursor(position interface{}) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor reads the position of a cursor created by EncodeCursor
+func DecodeCursor(cursor string, position interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, position); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package domain
+
+type (
+	// DocumentTagger returns the invalidation tags of a search result document for the search result cache - bind it with injector.BindMulti
+	DocumentTagger interface {
+		Tags(document Document) []string
+	}
+)
@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// FacetConfig configures which facets are shown and how - merchandisers can hide, rename and reorder facets and their items
+	FacetConfig struct {
+		// Include is the whitelist of the shown facets, its order is the position of facets without configured position - all facets are shown if it is empty
+		Include []string `json:"include,omitempty"`
+		// Exclude is the blacklist of the hidden facets
+		Exclude []string `json:"exclude,omitempty"`
+		// Facets are the settings by facet name
+		Facets map[string]FacetSettings `json:"facets,omitempty"`
+	}
+
+	// FacetSettings configure a facet - zero values keep the facet as returned by the search backend
+	FacetSettings struct {
+		// Label (or translation key) of the facet
+		Label    string `json:"label,omitempty"`
+		Position int    `json:"position,omitempty"`
+		// Sort orders the items by FacetSortCount, FacetSortAlpha or FacetSortCustom
+		Sort string `json:"sort,omitempty"`
+		// Order are the item values in the order of FacetSortCustom, other items follow in their order
+		Order []string `json:"order,omitempty"`
+		// MaxItems is the number of visible items - selected items are always visible
+		MaxItems int `json:"maxItems,omitempty"`
+		// ItemLabels are labels (or translation keys) by item value
+		ItemLabels map[string]string `json:"itemLabels,omitempty"`
+		// Buckets are the bounds of the intervals a range facet is split into - the facet becomes a list facet with the intervals as items in the url notation of a RangeFilter
+		Buckets []float64 `json:"buckets,omitempty"`
+	}
+
+	// FacetConfigProvider returns the facet configuration of a category (an empty category code for searches without category) - bind it with injector.BindMulti.
+	// The configurations of the providers are merged in their binding order
+	FacetConfigProvider interface {
+		FacetConfig(ctx context.Context, categoryCode string) (*FacetConfig, error)
+	}
+
+	// TranslateFunc returns the translation of a label (or translation key)
+	TranslateFunc func(label string) string
+)
+
+// Sort orders of facet items
+const (
+	// FacetSortCount orders the items by their count, descending
+	FacetSortCount = "count"
+	// FacetSortAlpha orders the items by their label
+	FacetSortAlpha = "alpha"
+	// FacetSortCustom orders the items by FacetSettings.Order
+	FacetSortCustom = "custom"
+)
+
+// Merge returns the configuration overwritten by the other configuration - its whitelist and blacklist replace the lists if they are set,
+// the facet settings are merged by facet name and field
+func (c FacetConfig) Merge(other FacetConfig) FacetConfig {
+	merged := FacetConfig{
+		Include: c.Include,
+		Exclude: c.Exclude,
+		Facets:  make(map[string]FacetSettings, len(c.Facets)+len(other.Facets)),
+	}
+	if len(other.Include) > 0 {
+		merged.Include = other.Include
+	}
+	if len(other.Exclude) > 0 {
+		merged.Exclude = other.Exclude
+	}
+	for name, settings := range c.Facets {
+		merged.Facets[name] = settings
+	}
+	for name, settings := range other.Facets {
+		merged.Facets[name] = merged.Facets[name].merge(settings)
+	}
+	return merged
+}
+
+func (s FacetSettings) merge(other FacetSettings) FacetSettings {
+	if other.Label != "" {
+		s.Label = other.Label
+	}
+	if other.Position != 0 {
+		s.Position = other.Position
+	}
+	if other.Sort != "" {
+		s.Sort = other.Sort
+	}
+	if len(other.Order) > 0 {
+		s.Order = other.Order
+	}
+	if other.MaxItems != 0 {
+		s.MaxItems = other.MaxItems
+	}
+	if len(other.ItemLabels) > 0 {
+		labels := make(map[string]string, len(s.ItemLabels)+len(other.ItemLabels))
+		for value, label := range s.ItemLabels {
+			labels[value] = label
+		}
+		for value, label := range other.ItemLabels {
+			labels[value] = label
+		}
+		s.ItemLabels = labels
+	}
+	if len(other.Buckets) > 0 {
+		s.Buckets = other.Buckets
+	}
+	return s
+}
+
+// Apply returns the configured copy of the facets, the facets are not changed. Labels are translated with translate (if given)
+func (c FacetConfig) Apply(facets FacetCollection, translate TranslateFunc) FacetCollection {
+	if translate == nil {
+		translate = func(label string) string { return label }
+	}
+
+	included := make(map[string]int, len(c.Include))
+	for i, name := range c.Include {
+		included[name] = i + 1
+	}
+	excluded := make(map[string]bool, len(c.Exclude))
+	for _, name := range c.Exclude {
+		excluded[name] = true
+	}
+
+	result := make(FacetCollection, len(facets))
+	for key, facet := range facets {
+		position, isIncluded := included[facet.Name]
+		if (len(included) > 0 && !isIncluded) || excluded[facet.Name] {
+			continue
+		}
+
+		settings := c.Facets[facet.Name]
+		facet.Items = copyFacetItems(facet.Items)
+		if isIncluded {
+			facet.Position = position
+		}
+		if settings.Position != 0 {
+			facet.Position = settings.Position
+		}
+		if settings.Label != "" {
+			facet.Label = translate(settings.Label)
+		}
+		if len(settings.Buckets) > 0 && facet.Type == RangeFacet {
+			facet.Type = string(ListFacet)
+			facet.Items = bucketItems(facet.Items, settings.Buckets)
+		}
+		relabelItems(facet.Items, settings.ItemLabels, translate)
+		sortItems(facet.Items, settings)
+		facet.Items = visibleItems(facet.Items, settings.MaxItems)
+
+		result[key] = facet
+	}
+	return result
+}
+
+func copyFacetItems(items []*FacetItem) []*FacetItem {
+	if items == nil {
+		return nil
+	}
+	copied := make([]*FacetItem, len(items))
+	for i, item := range items {
+		itemCopy := *item
+		itemCopy.Items = copyFacetItems(item.Items)
+		copied[i] = &itemCopy
+	}
+	return copied
+}
+
+func relabelItems(items []*FacetItem, labels map[string]string, translate TranslateFunc) {
+	for _, item := range items {
+		if label, ok := labels[item.Value]; ok {
+			item.Label = translate(label)
+		}
+		relabelItems(item.Items, labels, translate)
+	}
+}
+
+// sortItems sorts the items and the items of tree facets
+func sortItems(items []*FacetItem, settings FacetSettings) {
+	var less func(a, b *FacetItem) bool
+	switch settings.Sort {
+	case FacetSortCount:
+		less = func(a, b *FacetItem) bool { return a.Count > b.Count }
+	case FacetSortAlpha:
+		less = func(a, b *FacetItem) bool { return strings.ToLower(a.Label) < strings.ToLower(b.Label) }
+	case FacetSortCustom:
+		order := make(map[string]int, len(settings.Order))
+		for i, value := range settings.Order {
+			order[value] = i + 1
+		}
+		rank := func(item *FacetItem) int {
+			if i, ok := order[item.Value]; ok {
+				return i
+			}
+			return len(order) + 1
+		}
+		less = func(a, b *FacetItem) bool { return rank(a) < rank(b) }
+	default:
+		return
+	}
+
+	var sortTree func(items []*FacetItem)
+	sortTree = func(items []*FacetItem) {
+		sort.SliceStable(items, func(i, j int) bool { return less(items[i], items[j]) })
+		for _, item := range items {
+			sortTree(item.Items)
+		}
+	}
+	sortTree(items)
+}
+
+// visibleItems returns the first maxItems items and the selected or active items after them
+func visibleItems(items []*FacetItem, maxItems int) []*FacetItem {
+	if maxItems <= 0 || len(items) <= maxItems {
+		return items
+	}
+	visible := items[:maxItems:maxItems]
+	for _, item := range items[maxItems:] {
+		if item.Selected || item.Active {
+			visible = append(visible, item)
+		}
+	}
+	return visible
+}
+
+// bucketItems splits the range of the range facet items into the intervals between the bounds, the first and the last interval are open.
+// The values are in the url notation of a RangeFilter, e.g. "[10,50)". The count of an interval is the sum of the counts of the items within it -
+// items whose range spans several intervals (e.g. one item with the minimum and maximum of all hits) are not counted
+func bucketItems(items []*FacetItem, bounds []float64) []*FacetItem {
+	bounds = append([]float64(nil), bounds...)
+	sort.Float64s(bounds)
+
+	var min, max, selectedMin, selectedMax float64
+	hasRange := false
+	for i, item := range items {
+		if i == 0 || item.Min < min {
+			min = item.Min
+		}
+		if i == 0 || item.Max > max {
+			max = item.Max
+		}
+		if item.Selected || item.Active {
+			selectedMin, selectedMax = item.SelectedMin, item.SelectedMax
+		}
+		hasRange = hasRange || item.Max > item.Min
+	}
+
+	buckets := make([]*FacetItem, 0, len(bounds)+1)
+	for i := 0; i <= len(bounds); i++ {
+		lower, upper := "", ""
+		if i > 0 {
+			lower = formatBound(bounds[i-1])
+		}
+		if i < len(bounds) {
+			upper = formatBound(bounds[i])
+		}
+		// only intervals overlapping the range of the facet
+		if hasRange && ((i > 0 && bounds[i-1] > max) || (i < len(bounds) && bounds[i] <= min)) {
+			continue
+		}
+
+		bucket := &FacetItem{Value: "[" + lower + "," + upper + ")"}
+		for _, item := range items {
+			if (i == 0 || item.Min >= bounds[i-1]) && (i == len(bounds) || item.Max < bounds[i]) {
+				bucket.Count += item.Count
+			}
+		}
+		switch {
+		case lower == "":
+			bucket.Label = "< " + upper
+		case upper == "":
+			bucket.Label = lower + "+"
+		default:
+			bucket.Label = lower + " - " + upper
+		}
+		if (selectedMin != 0 || selectedMax != 0) && (i == 0 || bounds[i-1] == selectedMin) && (i == len(bounds) || bounds[i] == selectedMax) {
+			bucket.Selected = true
+			bucket.Active = true
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+func formatBound(bound float64) string {
+	return strconv.FormatFloat(bound, 'f', -1, 64)
+}
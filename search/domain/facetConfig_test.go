@@ -0,0 +1,123 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+func TestFacetConfig_Apply(t *testing.T) {
+	facets := domain.FacetCollection{
+		"color": {Type: string(domain.ListFacet), Name: "color", Label: "color", Position: 3, Items: []*domain.FacetItem{
+			{Label: "Red", Value: "red", Count: 2},
+			{Label: "blue", Value: "blue", Count: 5},
+			{Label: "Green", Value: "green", Count: 1, Selected: true},
+		}},
+		"brand": {Type: string(domain.ListFacet), Name: "brand", Position: 1},
+		"size":  {Type: string(domain.ListFacet), Name: "size", Position: 2},
+		"price": {Type: domain.RangeFacet, Name: "price", Position: 4, Items: []*domain.FacetItem{{Min: 5, Max: 120, SelectedMin: 50, SelectedMax: 100, Selected: true}}},
+	}
+
+	facetConfig := domain.FacetConfig{
+		Include: []string{"price", "color", "brand"},
+		Exclude: []string{"brand"},
+		Facets: map[string]domain.FacetSettings{
+			"color": {Label: "t.color", Sort: domain.FacetSortCount, MaxItems: 1, ItemLabels: map[string]string{"blue": "Blue"}},
+			"price": {Position: 10, Buckets: []float64{100, 10, 50, 200}},
+		},
+	}
+	configured := facetConfig.Apply(facets, func(label string) string { return "translated " + label })
+
+	assert.Len(t, configured, 2, "only whitelisted facets that are not blacklisted are shown")
+	color := configured["color"]
+	assert.Equal(t, "translated t.color", color.Label)
+	assert.Equal(t, 2, color.Position, "the position is the position in the whitelist")
+	assert.Equal(t, []*domain.FacetItem{
+		{Label: "translated Blue", Value: "blue", Count: 5},
+		{Label: "Green", Value: "green", Count: 1, Selected: true},
+	}, color.Items, "the items are sorted by count, selected items stay visible")
+
+	price := configured["price"]
+	assert.Equal(t, 10, price.Position)
+	assert.Equal(t, string(domain.ListFacet), price.Type)
+	assert.Equal(t, []*domain.FacetItem{
+		{Label: "< 10", Value: "[,10)"},
+		{Label: "10 - 50", Value: "[10,50)"},
+		{Label: "50 - 100", Value: "[50,100)", Selected: true, Active: true},
+		{Label: "100 - 200", Value: "[100,200)"},
+	}, price.Items, "the range is bucketed into the intervals overlapping the range")
+
+	assert.Equal(t, "Red", facets["color"].Items[0].Label, "the facets are not changed")
+	assert.Equal(t, domain.RangeFacet, facets["price"].Type)
+}
+
+func TestFacetConfig_ApplyBucketCounts(t *testing.T) {
+	facets := domain.FacetCollection{
+		"price": {Type: domain.RangeFacet, Name: "price", Items: []*domain.FacetItem{
+			{Min: 5, Max: 5, Count: 2},
+			{Min: 20, Max: 30, Count: 3},
+			{Min: 60, Max: 60, Count: 4},
+			{Min: 70, Max: 120, Count: 5},
+			{Min: 150, Max: 150, Count: 1},
+		}},
+	}
+
+	configured := domain.FacetConfig{Facets: map[string]domain.FacetSettings{
+		"price": {Buckets: []float64{10, 50, 100}, Sort: domain.FacetSortCount},
+	}}.Apply(facets, nil)
+
+	assert.Equal(t, []*domain.FacetItem{
+		{Label: "50 - 100", Value: "[50,100)", Count: 4},
+		{Label: "10 - 50", Value: "[10,50)", Count: 3},
+		{Label: "< 10", Value: "[,10)", Count: 2},
+		{Label: "100+", Value: "[100,)", Count: 1},
+	}, configured["price"].Items, "the counts of the items within an interval are summed, items spanning several intervals are not counted")
+}
+
+func TestFacetConfig_ApplySort(t *testing.T) {
+	facets := domain.FacetCollection{
+		"category": {Type: domain.TreeFacet, Name: "category", Items: []*domain.FacetItem{
+			{Label: "shoes", Value: "shoes"},
+			{Label: "Clothing", Value: "clothing", Items: []*domain.FacetItem{{Label: "shirts", Value: "shirts"}, {Label: "Pants", Value: "pants"}}},
+			{Label: "bags", Value: "bags"},
+		}},
+	}
+
+	alpha := domain.FacetConfig{Facets: map[string]domain.FacetSettings{"category": {Sort: domain.FacetSortAlpha}}}.Apply(facets, nil)
+	items := alpha["category"].Items
+	assert.Equal(t, []string{"bags", "clothing", "shoes"}, []string{items[0].Value, items[1].Value, items[2].Value})
+	assert.Equal(t, "pants", items[1].Items[0].Value, "the tree items are sorted too")
+
+	custom := domain.FacetConfig{Facets: map[string]domain.FacetSettings{"category": {Sort: domain.FacetSortCustom, Order: []string{"clothing"}}}}.Apply(facets, nil)
+	items = custom["category"].Items
+	assert.Equal(t, []string{"clothing", "shoes", "bags"}, []string{items[0].Value, items[1].Value, items[2].Value}, "unordered items follow in their order")
+}
+
+func TestFacetConfig_Merge(t *testing.T) {
+	base := domain.FacetConfig{
+		Include: []string{"color"},
+		Exclude: []string{"brand"},
+		Facets: map[string]domain.FacetSettings{
+			"color": {Label: "Color", MaxItems: 5, ItemLabels: map[string]string{"red": "Red"}},
+		},
+	}
+	merged := base.Merge(domain.FacetConfig{
+		Include: []string{"size", "color"},
+		Facets: map[string]domain.FacetSettings{
+			"color": {MaxItems: 10, ItemLabels: map[string]string{"blue": "Blue"}},
+			"size":  {Sort: domain.FacetSortAlpha},
+		},
+	})
+
+	assert.Equal(t, domain.FacetConfig{
+		Include: []string{"size", "color"},
+		Exclude: []string{"brand"},
+		Facets: map[string]domain.FacetSettings{
+			"color": {Label: "Color", MaxItems: 10, ItemLabels: map[string]string{"red": "Red", "blue": "Blue"}},
+			"size":  {Sort: domain.FacetSortAlpha},
+		},
+	}, merged)
+	assert.Equal(t, 5, base.Facets["color"].MaxItems, "the merged configurations are not changed")
+}
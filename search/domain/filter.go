@@ -55,15 +55,10 @@ const (
 )
 
 //NewKeyValueFilters - Factory method that you can use to get a list of KeyValueFilter based from url.Values
+// Parameters in the syntax of typed or composite filters (see NewFilterURLValues) result in RangeFilter, TreeFilter, BoolFilter, ExistsFilter, AndFilter, OrFilter or NotFilter
+// The parameter "cursor" results in a PaginationCursor
 func NewKeyValueFilters(params map[string][]string) []Filter {
-	var result []Filter
-	for k, v := range params {
-		if len(v) == 0 {
-			continue
-		}
-		result = append(result, NewKeyValueFilter(k, v))
-	}
-	return result
+	return parseFilterParams(params)
 }
 
 // NewKeyValueFilter factory
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The url parameters of the typed filters are marked by a suffix of the key, composite filters by a prefix:
+//
+//	price=[10,50)                          RangeFilter in interval notation, open bounds are left empty: price=[10,)
+//	category:tree=clothing/shirts          TreeFilter
+//	inStock:bool=true                      BoolFilter
+//	color:exists=true                      ExistsFilter - "false" negates it
+//	not:color=red                          NotFilter
+//	or:1:color=red&or:1:size:tree=m        OrFilter - parameters with the same group id form one filter, groups can be nested
+//	and:1:...                              AndFilter
+//
+// All other parameters are key value filters.
+const (
+	// FilterKeySeparator separates the prefixes and suffixes of a filter url parameter
+	FilterKeySeparator = ":"
+	// TreeFilterSuffix marks the url parameter of a TreeFilter
+	TreeFilterSuffix = "tree"
+	// BoolFilterSuffix marks the url parameter of a BoolFilter
+	BoolFilterSuffix = "bool"
+	// ExistsFilterSuffix marks the url parameter of an ExistsFilter
+	ExistsFilterSuffix = "exists"
+	// NotFilterPrefix marks the url parameter of a NotFilter
+	NotFilterPrefix = "not"
+	// AndFilterPrefix marks the url parameters of an AndFilter, followed by the group id
+	AndFilterPrefix = "and"
+	// OrFilterPrefix marks the url parameters of an OrFilter, followed by the group id
+	OrFilterPrefix = "or"
+)
+
+// NewFilterURLValues returns the url parameters of the filters - parsing them with NewKeyValueFilters returns equivalent filters.
+// Sort filters have no url parameter and are skipped
+func NewFilterURLValues(filters ...Filter) url.Values {
+	values := make(url.Values)
+	groups := 0
+	for _, filter := range filters {
+		addFilterURLValues(values, "", filter, &groups)
+	}
+	return values
+}
+
+func addFilterURLValues(values url.Values, prefix string, filter Filter, groups *int) {
+	switch f := filter.(type) {
+	case nil, *SortFilter:
+		return
+	case *TreeFilter:
+		values.Add(prefix+f.key+FilterKeySeparator+TreeFilterSuffix, strings.Join(f.path, TreePathSeparator))
+	case *BoolFilter:
+		values.Add(prefix+f.key+FilterKeySeparator+BoolFilterSuffix, strconv.FormatBool(f.value))
+	case *ExistsFilter:
+		values.Add(prefix+f.key+FilterKeySeparator+ExistsFilterSuffix, "true")
+	case *NotFilter:
+		addFilterURLValues(values, prefix+NotFilterPrefix+FilterKeySeparator, f.filter, groups)
+	case *AndFilter:
+		*groups++
+		groupPrefix := prefix + AndFilterPrefix + FilterKeySeparator + strconv.Itoa(*groups) + FilterKeySeparator
+		for _, child := range f.filters {
+			addFilterURLValues(values, groupPrefix, child, groups)
+		}
+	case *OrFilter:
+		*groups++
+		groupPrefix := prefix + OrFilterPrefix + FilterKeySeparator + strconv.Itoa(*groups) + FilterKeySeparator
+		for _, child := range f.filters {
+			addFilterURLValues(values, groupPrefix, child, groups)
+		}
+	default:
+		key, filterValues := filter.Value()
+		for _, value := range filterValues {
+			values.Add(prefix+key, value)
+		}
+	}
+}
+
+// parseFilterParams returns the filters of the url parameters in the order of the sorted keys
+func parseFilterParams(params map[string][]string) []Filter {
+	keys := make([]string, 0, len(params))
+	for key, values := range params {
+		if len(values) == 0 {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var result []Filter
+	groups := make(map[string]filterGroup)
+	for _, key := range keys {
+		if key == CursorParameter {
+			result = append(result, NewPaginationCursorFilter(params[key][0]))
+			continue
+		}
+		addParsedFilter(func(filter Filter) {
+			result = append(result, filter)
+		}, groups, "", strings.Split(key, FilterKeySeparator), params[key])
+	}
+	return result
+}
+
+// addParsedFilter resolves the prefixes of the key parts and adds the filter to its parent
+func addParsedFilter(parent func(Filter), groups map[string]filterGroup, groupPath string, parts []string, values []string) {
+	if len(parts) > 2 && (parts[0] == AndFilterPrefix || parts[0] == OrFilterPrefix) {
+		groupPath += strings.Join(parts[:2], FilterKeySeparator) + FilterKeySeparator
+		group, ok := groups[groupPath]
+		if !ok {
+			if parts[0] == AndFilterPrefix {
+				group = NewAndFilter()
+			} else {
+				group = NewOrFilter()
+			}
+			groups[groupPath] = group
+			parent(group)
+		}
+		addParsedFilter(group.add, groups, groupPath, parts[2:], values)
+		return
+	}
+
+	if len(parts) > 1 && parts[0] == NotFilterPrefix {
+		addParsedFilter(func(filter Filter) {
+			parent(NewNotFilter(filter))
+		}, groups, groupPath+NotFilterPrefix+FilterKeySeparator, parts[1:], values)
+		return
+	}
+
+	parent(parseFilterParam(strings.Join(parts, FilterKeySeparator), values))
+}
+
+// parseFilterParam returns the typed filter of the key suffix or value syntax - multiple values of a typed filter are OR combined
+func parseFilterParam(key string, values []string) Filter {
+	if i := strings.LastIndex(key, FilterKeySeparator); i > 0 {
+		name := key[:i]
+		var filters []Filter
+		for _, value := range values {
+			switch key[i+1:] {
+			case TreeFilterSuffix:
+				filters = append(filters, NewTreeFilter(name, splitTreePath(value)...))
+			case BoolFilterSuffix:
+				if b, err := strconv.ParseBool(value); err == nil {
+					filters = append(filters, NewBoolFilter(name, b))
+				}
+			case ExistsFilterSuffix:
+				if b, err := strconv.ParseBool(value); err == nil && b {
+					filters = append(filters, NewExistsFilter(name))
+				} else if err == nil {
+					filters = append(filters, NewNotFilter(NewExistsFilter(name)))
+				}
+			}
+		}
+		if len(filters) == 1 {
+			return filters[0]
+		}
+		if len(filters) > 1 {
+			return NewOrFilter(filters...)
+		}
+	}
+
+	ranges := make([]Filter, 0, len(values))
+	for _, value := range values {
+		rangeFilter, ok := ParseRangeFilter(key, value)
+		if !ok {
+			return NewKeyValueFilter(key, values)
+		}
+		ranges = append(ranges, rangeFilter)
+	}
+	if len(ranges) == 1 {
+		return ranges[0]
+	}
+	return NewOrFilter(ranges...)
+}
+
+func splitTreePath(value string) []string {
+	var path []string
+	for _, code := range strings.Split(value, TreePathSeparator) {
+		if code = strings.TrimSpace(code); code != "" {
+			path = append(path, code)
+		}
+	}
+	return path
+}
@@ -0,0 +1,140 @@
+package domain_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+func TestNewKeyValueFilters(t *testing.T) {
+	min, max := float64(10), float64(50)
+
+	tests := []struct {
+		name   string
+		params map[string][]string
+		want   []domain.Filter
+	}{
+		{
+			name:   "key value",
+			params: map[string][]string{"color": {"red", "blue"}, "empty": {}},
+			want:   []domain.Filter{domain.NewKeyValueFilter("color", []string{"red", "blue"})},
+		},
+		{
+			name:   "cursor",
+			params: map[string][]string{"cursor": {"abc"}, "page": {"2"}},
+			want:   []domain.Filter{domain.NewPaginationCursorFilter("abc"), domain.NewKeyValueFilter("page", []string{"2"})},
+		},
+		{
+			name:   "range",
+			params: map[string][]string{"price": {"[10,50)"}},
+			want:   []domain.Filter{domain.NewRangeFilterWithInclusion("price", &min, true, &max, false)},
+		},
+		{
+			name:   "multiple ranges",
+			params: map[string][]string{"price": {"(,10]", "[50,)"}},
+			want: []domain.Filter{domain.NewOrFilter(
+				domain.NewRangeFilterWithInclusion("price", nil, false, &min, true),
+				domain.NewRangeFilterWithInclusion("price", &max, true, nil, false),
+			)},
+		},
+		{
+			name:   "no range",
+			params: map[string][]string{"price": {"[10,abc]"}},
+			want:   []domain.Filter{domain.NewKeyValueFilter("price", []string{"[10,abc]"})},
+		},
+		{
+			name:   "tree, bool and exists",
+			params: map[string][]string{"category:tree": {"/clothing/shirts"}, "inStock:bool": {"true"}, "color:exists": {"false"}},
+			want: []domain.Filter{
+				domain.NewTreeFilter("category", "clothing", "shirts"),
+				domain.NewNotFilter(domain.NewExistsFilter("color")),
+				domain.NewBoolFilter("inStock", true),
+			},
+		},
+		{
+			name:   "composites",
+			params: map[string][]string{"or:1:color": {"red"}, "or:1:and:2:size": {"m"}, "or:1:and:2:not:fit": {"slim"}, "not:brand": {"x"}},
+			want: []domain.Filter{
+				domain.NewNotFilter(domain.NewKeyValueFilter("brand", []string{"x"})),
+				domain.NewOrFilter(
+					domain.NewAndFilter(
+						domain.NewNotFilter(domain.NewKeyValueFilter("fit", []string{"slim"})),
+						domain.NewKeyValueFilter("size", []string{"m"}),
+					),
+					domain.NewKeyValueFilter("color", []string{"red"}),
+				),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.NewKeyValueFilters(tt.params))
+		})
+	}
+}
+
+func TestNewFilterURLValues(t *testing.T) {
+	min := float64(10)
+	filters := []domain.Filter{
+		domain.NewQueryFilter("shirt"),
+		domain.NewSortFilter("price", domain.SortDirectionAscending),
+		domain.NewRangeFilterWithInclusion("price", &min, false, nil, false),
+		domain.NewOrFilter(
+			domain.NewTreeFilter("category", "clothing", "shirts"),
+			domain.NewAndFilter(domain.NewBoolFilter("sale", true), domain.NewNotFilter(domain.NewExistsFilter("brand"))),
+		),
+	}
+
+	values := domain.NewFilterURLValues(filters...)
+	assert.Equal(t, url.Values{
+		"q":                           {"shirt"},
+		"price":                       {"(10,)"},
+		"or:1:category:tree":          {"clothing/shirts"},
+		"or:1:and:2:sale:bool":        {"true"},
+		"or:1:and:2:not:brand:exists": {"true"},
+	}, values)
+
+	assert.Equal(t, []domain.Filter{
+		domain.NewOrFilter(
+			domain.NewAndFilter(domain.NewNotFilter(domain.NewExistsFilter("brand")), domain.NewBoolFilter("sale", true)),
+			domain.NewTreeFilter("category", "clothing", "shirts"),
+		),
+		domain.NewRangeFilterWithInclusion("price", &min, false, nil, false),
+		domain.NewKeyValueFilter("q", []string{"shirt"}),
+	}, domain.NewKeyValueFilters(values), "the parsed url values are equivalent")
+}
+
+func TestRangeFilter_Matches(t *testing.T) {
+	min, max := float64(10), float64(20)
+	filter := domain.NewRangeFilterWithInclusion("price", &min, false, &max, true)
+
+	assert.False(t, filter.Matches(10))
+	assert.True(t, filter.Matches(15))
+	assert.True(t, filter.Matches(20))
+	assert.False(t, filter.Matches(21))
+
+	key, values := filter.Value()
+	assert.Equal(t, "price", key)
+	assert.Equal(t, []string{"(10,20]"}, values)
+}
+
+func TestEncodeCursor(t *testing.T) {
+	type position struct {
+		Code   string
+		Offset int
+	}
+
+	cursor, err := domain.EncodeCursor(position{Code: "a/b", Offset: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, url.QueryEscape(cursor), "cursors are url safe")
+
+	var decoded position
+	assert.NoError(t, domain.DecodeCursor(cursor, &decoded))
+	assert.Equal(t, position{Code: "a/b", Offset: 10}, decoded)
+
+	assert.Equal(t, domain.ErrInvalidCursor, domain.DecodeCursor("%%%", &decoded))
+	assert.Equal(t, domain.ErrInvalidCursor, domain.DecodeCursor("YWJj", &decoded))
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+)
+
+type (
+	// IndexDocument is a document prepared for search backends that index the documents themselves (e.g. the in-memory search)
+	IndexDocument struct {
+		// ID identifies the document within its type - indexing a document with an existing id replaces it
+		ID string
+		// Type is the document type, e.g. "product" - the search returns one result per type
+		Type string
+		// Fields contains the full-text searchable texts by field name (e.g. title, description), field boosts are configured by field name
+		Fields map[string]string
+		// Keywords contains the values for key value filters, list facets and sorting by field name.
+		// Values of tree facets are paths of codes separated by "/" (e.g. "clothing/shirts")
+		Keywords map[string][]string
+		// Numbers contains the values for range facets and sorting by field name
+		Numbers map[string]float64
+		// Labels contains the labels of keyword values (e.g. the category name of a category code) by field name and value
+		Labels map[string]map[string]string
+		// Document is returned as hit
+		Document Document
+	}
+
+	// DocumentSource provides the documents of the index, e.g. the products of the fixtures - bind it with injector.BindMulti
+	DocumentSource interface {
+		Documents(ctx context.Context) ([]IndexDocument, error)
+	}
+)
+
+// KeywordLabel returns the label of the keyword value or the value itself
+func (d IndexDocument) KeywordLabel(field string, value string) string {
+	if label := d.Labels[field][value]; label != "" {
+		return label
+	}
+	return value
+}
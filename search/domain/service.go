@@ -34,6 +34,9 @@ type (
 		NumResults     int
 		SelectedFacets []Facet
 		SortOptions    []SortOption
+		// NextCursor and PreviousCursor are set by search backends that support the PaginationCursor filter - they are empty at the ends of the result
+		NextCursor     string
+		PreviousCursor string
 	}
 
 	// SortOption defines how sorting is possible, with both an asc and desc option
@@ -80,8 +83,8 @@ type (
 
 	// Suggestion hint
 	Suggestion struct {
-		Text      string
-		Highlight string
+		Text      string `json:"text"`
+		Highlight string `json:"highlight"`
 	}
 
 	// Document holds a search result document
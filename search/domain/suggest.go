@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// SuggestService provides the data for an autocomplete of a search box
+	SuggestService interface {
+		// Suggest returns at most limit entries of each kind for the (partial) query
+		Suggest(ctx context.Context, query string, limit int) (*SuggestResult, error)
+	}
+
+	// SuggestResult contains the suggestions for a query
+	SuggestResult struct {
+		Query string `json:"query"`
+		// Completions of the query, e.g. "flamingo" for "flam"
+		Completions []Suggestion `json:"completions"`
+		// Hits are the top documents of the query
+		Hits []SuggestHit `json:"hits"`
+		// Terms are filter values matching the query, e.g. categories or brands
+		Terms []SuggestTerm `json:"terms"`
+	}
+
+	// SuggestHit is a document suggested for the query
+	SuggestHit struct {
+		Type      string   `json:"type"`
+		ID        string   `json:"id"`
+		Text      string   `json:"text"`
+		Highlight string   `json:"highlight"`
+		Document  Document `json:"-"`
+	}
+
+	// SuggestTerm is a filter value suggested for the query - Field and Value can be used as key value filter
+	SuggestTerm struct {
+		Field     string `json:"field"`
+		Value     string `json:"value"`
+		Text      string `json:"text"`
+		Highlight string `json:"highlight"`
+		Count     int    `json:"count"`
+	}
+)
+
+const (
+	// HighlightStart is the markup before a matched part of a suggestion
+	HighlightStart = "<em>"
+	// HighlightEnd is the markup after a matched part of a suggestion
+	HighlightEnd = "</em>"
+)
+
+// Highlight returns the html escaped text with the beginnings of words matching the words of the query wrapped in HighlightStart and HighlightEnd
+func Highlight(text string, query string) string {
+	queryWords := strings.Fields(strings.ToLower(query))
+
+	var builder strings.Builder
+	wordStart := true
+	for i := 0; i < len(text); {
+		if wordStart {
+			if length := matchedPrefix(text[i:], queryWords); length > 0 {
+				builder.WriteString(HighlightStart)
+				builder.WriteString(html.EscapeString(text[i : i+length]))
+				builder.WriteString(HighlightEnd)
+				i += length
+				wordStart = false
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		builder.WriteString(html.EscapeString(text[i : i+size]))
+		wordStart = isWordSeparator(r)
+		i += size
+	}
+	return builder.String()
+}
+
+// matchedPrefix returns the byte length of the longest query word the text starts with
+func matchedPrefix(text string, queryWords []string) int {
+	longest := 0
+	for _, word := range queryWords {
+		if len(word) > longest && len(text) >= len(word) && strings.EqualFold(text[:len(word)], word) {
+			longest = len(word)
+		}
+	}
+	return longest
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
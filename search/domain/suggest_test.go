@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		want  string
+	}{
+		{
+			name:  "word beginnings",
+			text:  "Pink Flamingo Shirt",
+			query: "fla sh",
+			want:  "Pink <em>Fla</em>mingo <em>Sh</em>irt",
+		},
+		{
+			name:  "no match within words",
+			text:  "Flamingo",
+			query: "ming",
+			want:  "Flamingo",
+		},
+		{
+			name:  "longest query word",
+			text:  "shirts",
+			query: "s shirt",
+			want:  "<em>shirt</em>s",
+		},
+		{
+			name:  "escaped html",
+			text:  "Tom & <Jerry>",
+			query: "jer",
+			want:  "Tom &amp; &lt;<em>Jer</em>ry&gt;",
+		},
+		{
+			name:  "empty query",
+			text:  "Flamingo",
+			query: "",
+			want:  "Flamingo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.Highlight(tt.text, tt.query))
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+type (
+	// RangeFilter - filters numeric values (e.g. a price slider) between min and max, a nil bound is open
+	RangeFilter struct {
+		key          string
+		min          *float64
+		max          *float64
+		minInclusive bool
+		maxInclusive bool
+	}
+
+	// TreeFilter - filters values of a hierarchy (e.g. categories) by a path of codes, matching the node and all nodes below
+	TreeFilter struct {
+		key  string
+		path []string
+	}
+
+	// BoolFilter - filters flags (e.g. in stock)
+	BoolFilter struct {
+		key   string
+		value bool
+	}
+
+	// ExistsFilter - filters documents that have a value for the key
+	ExistsFilter struct {
+		key string
+	}
+)
+
+var (
+	_ Filter = new(RangeFilter)
+	_ Filter = new(TreeFilter)
+	_ Filter = new(BoolFilter)
+	_ Filter = new(ExistsFilter)
+)
+
+const (
+	// TreePathSeparator separates the codes of a TreeFilter path
+	TreePathSeparator = "/"
+)
+
+// NewRangeFilter factory - both bounds are inclusive, nil bounds are open
+func NewRangeFilter(key string, min, max *float64) *RangeFilter {
+	return NewRangeFilterWithInclusion(key, min, true, max, true)
+}
+
+// NewRangeFilterWithInclusion factory - the inclusive flags define if the bounds are part of the range
+func NewRangeFilterWithInclusion(key string, min *float64, minInclusive bool, max *float64, maxInclusive bool) *RangeFilter {
+	return &RangeFilter{
+		key:          key,
+		min:          min,
+		max:          max,
+		minInclusive: minInclusive,
+		maxInclusive: maxInclusive,
+	}
+}
+
+// Value of the current filter - the range in interval notation, e.g. "[10,50)" or "[10,)" for an open upper bound
+func (f *RangeFilter) Value() (string, []string) {
+	return f.key, []string{f.interval()}
+}
+
+// Key of the filter
+func (f *RangeFilter) Key() string {
+	return f.key
+}
+
+// Min returns the lower bound - false if the range is open
+func (f *RangeFilter) Min() (float64, bool) {
+	if f.min == nil {
+		return math.Inf(-1), false
+	}
+	return *f.min, true
+}
+
+// Max returns the upper bound - false if the range is open
+func (f *RangeFilter) Max() (float64, bool) {
+	if f.max == nil {
+		return math.Inf(1), false
+	}
+	return *f.max, true
+}
+
+// MinInclusive returns true if the lower bound is part of the range
+func (f *RangeFilter) MinInclusive() bool {
+	return f.minInclusive
+}
+
+// MaxInclusive returns true if the upper bound is part of the range
+func (f *RangeFilter) MaxInclusive() bool {
+	return f.maxInclusive
+}
+
+// Matches checks if the number is within the range
+func (f *RangeFilter) Matches(number float64) bool {
+	if f.min != nil && (number < *f.min || (!f.minInclusive && number == *f.min)) {
+		return false
+	}
+	if f.max != nil && (number > *f.max || (!f.maxInclusive && number == *f.max)) {
+		return false
+	}
+	return true
+}
+
+func (f *RangeFilter) interval() string {
+	var builder strings.Builder
+	if f.minInclusive {
+		builder.WriteString("[")
+	} else {
+		builder.WriteString("(")
+	}
+	if f.min != nil {
+		builder.WriteString(strconv.FormatFloat(*f.min, 'f', -1, 64))
+	}
+	builder.WriteString(",")
+	if f.max != nil {
+		builder.WriteString(strconv.FormatFloat(*f.max, 'f', -1, 64))
+	}
+	if f.maxInclusive {
+		builder.WriteString("]")
+	} else {
+		builder.WriteString(")")
+	}
+	return builder.String()
+}
+
+// ParseRangeFilter parses a range in interval notation, e.g. "[10,50]", "(10,50)" or "[10,)" - false if the value is no interval
+func ParseRangeFilter(key string, value string) (*RangeFilter, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) < 3 {
+		return nil, false
+	}
+	opening, closing := value[0], value[len(value)-1]
+	if (opening != '[' && opening != '(') || (closing != ']' && closing != ')') {
+		return nil, false
+	}
+	bounds := strings.Split(value[1:len(value)-1], ",")
+	if len(bounds) != 2 {
+		return nil, false
+	}
+
+	var parsed [2]*float64
+	for i, bound := range bounds {
+		bound = strings.TrimSpace(bound)
+		if bound == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return nil, false
+		}
+		parsed[i] = &number
+	}
+
+	return NewRangeFilterWithInclusion(key, parsed[0], opening == '[', parsed[1], closing == ']'), true
+}
+
+// NewTreeFilter factory - the path is given as codes from the root, e.g. "clothing", "shirts"
+func NewTreeFilter(key string, path ...string) *TreeFilter {
+	return &TreeFilter{
+		key:  key,
+		path: path,
+	}
+}
+
+// Value of the current filter - the path joined by the TreePathSeparator
+func (f *TreeFilter) Value() (string, []string) {
+	return f.key, []string{strings.Join(f.path, TreePathSeparator)}
+}
+
+// Key of the filter
+func (f *TreeFilter) Key() string {
+	return f.key
+}
+
+// Path returns the codes of the path from the root
+func (f *TreeFilter) Path() []string {
+	return f.path
+}
+
+// Matches checks if the path is the path of the filter or below it
+func (f *TreeFilter) Matches(path []string) bool {
+	if len(path) < len(f.path) {
+		return false
+	}
+	for i, code := range f.path {
+		if !strings.EqualFold(path[i], code) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewBoolFilter factory
+func NewBoolFilter(key string, value bool) *BoolFilter {
+	return &BoolFilter{
+		key:   key,
+		value: value,
+	}
+}
+
+// Value of the current filter
+func (f *BoolFilter) Value() (string, []string) {
+	return f.key, []string{strconv.FormatBool(f.value)}
+}
+
+// Key of the filter
+func (f *BoolFilter) Key() string {
+	return f.key
+}
+
+// Bool returns the expected value
+func (f *BoolFilter) Bool() bool {
+	return f.value
+}
+
+// NewExistsFilter factory
+func NewExistsFilter(key string) *ExistsFilter {
+	return &ExistsFilter{
+		key: key,
+	}
+}
+
+// Value of the current filter
+func (f *ExistsFilter) Value() (string, []string) {
+	return f.key, []string{"true"}
+}
+
+// Key of the filter
+func (f *ExistsFilter) Key() string {
+	return f.key
+}
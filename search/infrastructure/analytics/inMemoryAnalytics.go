@@ -0,0 +1,148 @@
+package analytics
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type (
+	// InMemoryAnalytics aggregates the search events per query in memory - it needs to be bound as singleton.
+	// If more than maxQueries queries are tracked, the least used tenth of the queries is dropped
+	InMemoryAnalytics struct {
+		mutex      sync.Mutex
+		queries    map[string]*domain.QueryStatistics
+		maxQueries int
+	}
+)
+
+const defaultMaxQueries = 10000
+
+var _ domain.SearchAnalytics = (*InMemoryAnalytics)(nil)
+
+// Inject dependencies
+func (a *InMemoryAnalytics) Inject(
+	config *struct {
+		MaxQueries float64 `inject:"config:commerce.search.analytics.maxQueries,optional"`
+	},
+) {
+	if config != nil && config.MaxQueries > 0 {
+		a.maxQueries = int(config.MaxQueries)
+	}
+}
+
+// RecordSearch counts the search and the search without results of the query, searches without query are ignored
+func (a *InMemoryAnalytics) RecordSearch(_ context.Context, event domain.SearchPerformedEvent) error {
+	if event.Query == "" {
+		return nil
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	statistics := a.statistics(event.Query)
+	statistics.Searches++
+	if event.NumResults == 0 {
+		statistics.ZeroResults++
+	}
+	return nil
+}
+
+// RecordClick counts the click on a result of the query - clicks of queries that were not searched are ignored
+func (a *InMemoryAnalytics) RecordClick(_ context.Context, event domain.SearchResultClickedEvent) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if statistics, ok := a.queries[event.Query]; ok {
+		statistics.Clicks++
+	}
+	return nil
+}
+
+// Report returns the most searched queries and the queries with the most searches without results - all queries if limit is 0
+func (a *InMemoryAnalytics) Report(_ context.Context, limit int) (*domain.AnalyticsReport, error) {
+	a.mutex.Lock()
+	all := make([]domain.QueryStatistics, 0, len(a.queries))
+	for _, statistics := range a.queries {
+		result := *statistics
+		if result.Searches > 0 {
+			result.ClickThroughRate = float64(result.Clicks) / float64(result.Searches)
+		}
+		all = append(all, result)
+	}
+	a.mutex.Unlock()
+
+	report := &domain.AnalyticsReport{
+		TopQueries:        make([]domain.QueryStatistics, 0, len(all)),
+		ZeroResultQueries: make([]domain.QueryStatistics, 0),
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Searches != all[j].Searches {
+			return all[i].Searches > all[j].Searches
+		}
+		return all[i].Query < all[j].Query
+	})
+	for _, statistics := range all {
+		if statistics.Searches > 0 {
+			report.TopQueries = append(report.TopQueries, statistics)
+		}
+		if statistics.ZeroResults > 0 {
+			report.ZeroResultQueries = append(report.ZeroResultQueries, statistics)
+		}
+	}
+
+	sort.SliceStable(report.ZeroResultQueries, func(i, j int) bool {
+		return report.ZeroResultQueries[i].ZeroResults > report.ZeroResultQueries[j].ZeroResults
+	})
+	if limit > 0 && len(report.TopQueries) > limit {
+		report.TopQueries = report.TopQueries[:limit]
+	}
+	if limit > 0 && len(report.ZeroResultQueries) > limit {
+		report.ZeroResultQueries = report.ZeroResultQueries[:limit]
+	}
+	return report, nil
+}
+
+// statistics returns the statistics of the query and adds them if the query is unknown - the caller needs to hold the mutex
+func (a *InMemoryAnalytics) statistics(query string) *domain.QueryStatistics {
+	if statistics, ok := a.queries[query]; ok {
+		return statistics
+	}
+
+	if a.queries == nil {
+		a.queries = make(map[string]*domain.QueryStatistics)
+	}
+	maxQueries := a.maxQueries
+	if maxQueries <= 0 {
+		maxQueries = defaultMaxQueries
+	}
+	if len(a.queries) >= maxQueries {
+		a.dropLeastUsed(maxQueries)
+	}
+
+	statistics := &domain.QueryStatistics{Query: query}
+	a.queries[query] = statistics
+	return statistics
+}
+
+// dropLeastUsed drops the least used tenth of the queries (at least one), so that the queries are not sorted for every new query
+func (a *InMemoryAnalytics) dropLeastUsed(maxQueries int) {
+	all := make([]*domain.QueryStatistics, 0, len(a.queries))
+	for _, statistics := range a.queries {
+		all = append(all, statistics)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Searches+all[i].Clicks < all[j].Searches+all[j].Clicks
+	})
+
+	count := len(all) - maxQueries + 1
+	if tenth := maxQueries / 10; count < tenth {
+		count = tenth
+	}
+	for _, statistics := range all[:count] {
+		delete(a.queries, statistics.Query)
+	}
+}
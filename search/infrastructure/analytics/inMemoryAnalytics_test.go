@@ -0,0 +1,89 @@
+package analytics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/analytics"
+)
+
+func TestInMemoryAnalytics_Report(t *testing.T) {
+	ctx := context.Background()
+	store := new(analytics.InMemoryAnalytics)
+
+	for _, event := range []domain.SearchPerformedEvent{
+		{Query: "shirt", NumResults: 10},
+		{Query: "shirt", NumResults: 10},
+		{Query: "shirt", NumResults: 0},
+		{Query: "shirt", NumResults: 8},
+		{Query: "flamingo", NumResults: 0},
+		{Query: "flamingo", NumResults: 0},
+		{Query: "shoes", NumResults: 3},
+		{Query: "", NumResults: 100},
+	} {
+		assert.NoError(t, store.RecordSearch(ctx, event))
+	}
+	assert.NoError(t, store.RecordClick(ctx, domain.SearchResultClickedEvent{Query: "shirt", DocumentID: "1"}))
+	assert.NoError(t, store.RecordClick(ctx, domain.SearchResultClickedEvent{Query: "shoes", DocumentID: "2"}))
+	assert.NoError(t, store.RecordClick(ctx, domain.SearchResultClickedEvent{Query: "never searched", DocumentID: "3"}))
+
+	report, err := store.Report(ctx, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []domain.QueryStatistics{
+		{Query: "shirt", Searches: 4, ZeroResults: 1, Clicks: 1, ClickThroughRate: 0.25},
+		{Query: "flamingo", Searches: 2, ZeroResults: 2},
+		{Query: "shoes", Searches: 1, Clicks: 1, ClickThroughRate: 1},
+	}, report.TopQueries, "searches without query and clicks of queries that were not searched are ignored")
+	assert.Equal(t, []domain.QueryStatistics{
+		{Query: "flamingo", Searches: 2, ZeroResults: 2},
+		{Query: "shirt", Searches: 4, ZeroResults: 1, Clicks: 1, ClickThroughRate: 0.25},
+	}, report.ZeroResultQueries)
+
+	report, err = store.Report(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, report.TopQueries, 1)
+	assert.Len(t, report.ZeroResultQueries, 1)
+}
+
+func TestInMemoryAnalytics_MaxQueries(t *testing.T) {
+	ctx := context.Background()
+	store := new(analytics.InMemoryAnalytics)
+	store.Inject(&struct {
+		MaxQueries float64 `inject:"config:commerce.search.analytics.maxQueries,optional"`
+	}{MaxQueries: 2})
+
+	assert.NoError(t, store.RecordSearch(ctx, domain.SearchPerformedEvent{Query: "shirt", NumResults: 1}))
+	assert.NoError(t, store.RecordSearch(ctx, domain.SearchPerformedEvent{Query: "shirt", NumResults: 1}))
+	assert.NoError(t, store.RecordSearch(ctx, domain.SearchPerformedEvent{Query: "rare", NumResults: 1}))
+	assert.NoError(t, store.RecordSearch(ctx, domain.SearchPerformedEvent{Query: "shoes", NumResults: 1}))
+
+	report, err := store.Report(ctx, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, report.TopQueries, 2) {
+		assert.Equal(t, "shirt", report.TopQueries[0].Query)
+		assert.Equal(t, "shoes", report.TopQueries[1].Query, "the least used query is dropped")
+	}
+}
+
+func TestInMemoryAnalytics_ClicksDoNotEvictQueries(t *testing.T) {
+	ctx := context.Background()
+	store := new(analytics.InMemoryAnalytics)
+	store.Inject(&struct {
+		MaxQueries float64 `inject:"config:commerce.search.analytics.maxQueries,optional"`
+	}{MaxQueries: 2})
+
+	assert.NoError(t, store.RecordSearch(ctx, domain.SearchPerformedEvent{Query: "shirt", NumResults: 1}))
+	assert.NoError(t, store.RecordSearch(ctx, domain.SearchPerformedEvent{Query: "shoes", NumResults: 1}))
+	for _, query := range []string{"a", "b", "c"} {
+		assert.NoError(t, store.RecordClick(ctx, domain.SearchResultClickedEvent{Query: query, DocumentID: "1"}))
+	}
+
+	report, err := store.Report(ctx, 0)
+	assert.NoError(t, err)
+	assert.Len(t, report.TopQueries, 2)
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+type (
+	// CachingSearchService is a dingo interceptor that caches the results of the bound domain.SearchService in the SearchCache.
+	// The results are tagged with the categories of the filters and the tags of the bound domain.DocumentTaggers
+	CachingSearchService struct {
+		domain.SearchService
+		cache *SearchCache
+	}
+)
+
+// CacheNameSearch is the cache name of the search results in the metrics
+const CacheNameSearch = "search"
+
+var _ domain.SearchService = (*CachingSearchService)(nil)
+
+// Inject dependencies
+func (s *CachingSearchService) Inject(cache *SearchCache) {
+	s.cache = cache
+}
+
+// Search returns the cached results of all types or searches them with the intercepted SearchService
+func (s *CachingSearchService) Search(ctx context.Context, filter ...domain.Filter) (map[string]domain.Result, error) {
+	value, err := s.cache.Load(ctx, CacheNameSearch, s.cache.Key("search", filter), func(ctx context.Context) (interface{}, []string, error) {
+		results, err := s.SearchService.Search(ctx, filter...)
+		tags := FilterTags(filter)
+		for _, result := range results {
+			tags = append(tags, s.cache.DocumentTags(result.Hits)...)
+		}
+		return results, tags, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the map is copied, so that callers can't change the cached results
+	cached := value.(map[string]domain.Result)
+	results := make(map[string]domain.Result, len(cached))
+	for documentType, result := range cached {
+		results[documentType] = result
+	}
+	return results, nil
+}
+
+// SearchFor returns the cached result of the type or searches it with the intercepted SearchService
+func (s *CachingSearchService) SearchFor(ctx context.Context, typ string, filter ...domain.Filter) (*domain.Result, error) {
+	value, err := s.cache.Load(ctx, CacheNameSearch, s.cache.Key("searchFor:"+typ, filter), func(ctx context.Context) (interface{}, []string, error) {
+		result, err := s.SearchService.SearchFor(ctx, typ, filter...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return *result, append(FilterTags(filter), s.cache.DocumentTags(result.Hits)...), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := value.(domain.Result)
+	return &result, nil
+}
@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	searchServiceStub struct {
+		mutex   sync.Mutex
+		calls   int
+		err     error
+		release chan struct{}
+	}
+
+	codeTagger struct{}
+)
+
+func (s *searchServiceStub) Search(ctx context.Context, filter ...domain.Filter) (map[string]domain.Result, error) {
+	result, err := s.SearchFor(ctx, "product", filter...)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]domain.Result{"product": *result}, nil
+}
+
+func (s *searchServiceStub) SearchFor(ctx context.Context, _ string, _ ...domain.Filter) (*domain.Result, error) {
+	s.mutex.Lock()
+	s.calls++
+	s.mutex.Unlock()
+
+	if s.release != nil {
+		<-s.release
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.Result{Hits: []domain.Document{"a", "b"}}, nil
+}
+
+func (s *searchServiceStub) callCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+func (codeTagger) Tags(document domain.Document) []string {
+	return []string{TagProduct(document.(string))}
+}
+
+func newCachingSearchService(ttl string) (*CachingSearchService, *searchServiceStub, *time.Time) {
+	cache := new(SearchCache)
+	cache.Inject(flamingo.NullLogger{}, &struct {
+		Size            float64 `inject:"config:commerce.search.cache.size,optional"`
+		TTL             string  `inject:"config:commerce.search.cache.ttl,optional"`
+		DefaultPageSize float64 `inject:"config:pagination.defaultPageSize,optional"`
+	}{Size: 10, TTL: ttl, DefaultPageSize: 20}, &struct {
+		Taggers []domain.DocumentTagger `inject:",optional"`
+	}{Taggers: []domain.DocumentTagger{codeTagger{}}})
+
+	now := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time {
+		return now
+	}
+
+	stub := new(searchServiceStub)
+	service := &CachingSearchService{SearchService: stub}
+	service.Inject(cache)
+	return service, stub, &now
+}
+
+func TestCachingSearchService_SearchFor(t *testing.T) {
+	t.Run("results are cached until the ttl expires", func(t *testing.T) {
+		service, stub, now := newCachingSearchService("1m")
+
+		for i := 0; i < 3; i++ {
+			result, err := service.SearchFor(context.Background(), "product", domain.NewKeyValueFilter("color", []string{"red", "blue"}))
+			assert.NoError(t, err)
+			assert.Len(t, result.Hits, 2)
+		}
+		_, _ = service.SearchFor(context.Background(), "product", domain.NewKeyValueFilter("color", []string{"blue", "red"}), domain.NewPaginationPageSizeFilter(20))
+		assert.Equal(t, 1, stub.callCount(), "equal filters have the same key")
+
+		_, _ = service.Search(context.Background(), domain.NewKeyValueFilter("color", []string{"red", "blue"}))
+		assert.Equal(t, 2, stub.callCount(), "the search of all types is cached separately")
+
+		*now = now.Add(time.Minute)
+		_, _ = service.SearchFor(context.Background(), "product", domain.NewKeyValueFilter("color", []string{"red", "blue"}))
+		assert.Equal(t, 3, stub.callCount())
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		service, stub, _ := newCachingSearchService("1m")
+		stub.err = errors.New("backend error")
+
+		for i := 0; i < 2; i++ {
+			_, err := service.SearchFor(context.Background(), "product")
+			assert.Error(t, err)
+		}
+		assert.Equal(t, 2, stub.callCount())
+	})
+
+	t.Run("concurrent searches result in one call", func(t *testing.T) {
+		service, stub, _ := newCachingSearchService("1m")
+		stub.release = make(chan struct{})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.SearchFor(context.Background(), "product")
+				assert.NoError(t, err)
+			}()
+		}
+		for stub.callCount() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(stub.release)
+		wg.Wait()
+		assert.Equal(t, 1, stub.callCount())
+	})
+}
+
+func TestSearchCache_Invalidate(t *testing.T) {
+	service, stub, _ := newCachingSearchService("1m")
+	search := func(category string) {
+		_, err := service.SearchFor(context.Background(), "product", domain.NewKeyValueFilter("category", []string{category}))
+		assert.NoError(t, err)
+	}
+
+	search("clothing")
+	search("shoes")
+	assert.Equal(t, 2, service.cache.Len())
+
+	service.cache.InvalidateCategories("clothing")
+	assert.Equal(t, 1, service.cache.Len(), "results of the category filter are invalidated")
+	search("shoes")
+	assert.Equal(t, 2, stub.callCount())
+
+	service.cache.InvalidateProducts("a")
+	assert.Equal(t, 0, service.cache.Len(), "results containing the product are invalidated")
+	assert.Empty(t, service.cache.tags, "the tag index is cleaned up")
+
+	search("shoes")
+	service.cache.Flush()
+	assert.Equal(t, 0, service.cache.Len())
+}
+
+func TestSearchCache_InvalidateDuringLoad(t *testing.T) {
+	service, stub, _ := newCachingSearchService("1m")
+	stub.release = make(chan struct{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := service.SearchFor(context.Background(), "product")
+		assert.NoError(t, err)
+	}()
+
+	for stub.callCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	service.cache.InvalidateProducts("a")
+	close(stub.release)
+	<-done
+
+	assert.Equal(t, 0, service.cache.Len(), "the result loaded during the invalidation is not cached")
+
+	stub.release = nil
+	_, err := service.SearchFor(context.Background(), "product")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stub.callCount())
+	assert.Equal(t, 1, service.cache.Len())
+}
+
+func TestSearchCache_LoadCancelledCaller(t *testing.T) {
+	service, stub, _ := newCachingSearchService("1m")
+	stub.release = make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	go func() {
+		_, err := service.SearchFor(ctx, "product")
+		cancelled <- err
+	}()
+	for stub.callCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waiting := make(chan error)
+	go func() {
+		_, err := service.SearchFor(context.Background(), "product")
+		waiting <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-cancelled, "the cancelled caller returns with the error of its context")
+	close(stub.release)
+	assert.NoError(t, <-waiting, "the waiting caller is not failed by the cancelled first caller")
+	assert.Equal(t, 1, stub.callCount())
+	assert.Equal(t, 1, service.cache.Len(), "the result is cached")
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+// Key returns a stable cache key of the filters. Key value filters are merged by key and sorted with their values, so their order does not matter.
+// The pagination page and page size filters (also given as key value filters "page" and "limit") are replaced by the effective page and page size,
+// so that a request without page size equals the request with the default page size. The order of sort filters is kept
+func Key(filters []domain.Filter, defaultPageSize int) string {
+	keyValues := make(map[string]map[string]struct{})
+	var parts, sorts []string
+	page, pageSize := 1, defaultPageSize
+
+	for _, filter := range filters {
+		key, values := filter.Value()
+		switch f := filter.(type) {
+		case *domain.PaginationPage:
+			page = number(values, page)
+		case *domain.PaginationPageSize:
+			if f.GetPageSize() > 0 {
+				pageSize = f.GetPageSize()
+			}
+		case *domain.SortFilter:
+			sorts = append(sorts, "sort:"+escaped(key, values))
+		case *domain.KeyValueFilter:
+			switch key {
+			case "page":
+				page = number(values, page)
+			case "limit":
+				pageSize = number(values, pageSize)
+			default:
+				if keyValues[key] == nil {
+					keyValues[key] = make(map[string]struct{})
+				}
+				for _, value := range values {
+					keyValues[key][value] = struct{}{}
+				}
+			}
+		default:
+			parts = append(parts, fmt.Sprintf("%T:%s", filter, escaped(key, values)))
+		}
+	}
+
+	for key, valueSet := range keyValues {
+		values := make([]string, 0, len(valueSet))
+		for value := range valueSet {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		parts = append(parts, "kv:"+escaped(key, values))
+	}
+	sort.Strings(parts)
+
+	if page > 1 {
+		parts = append(parts, "page="+strconv.Itoa(page))
+	}
+	if pageSize > 0 {
+		parts = append(parts, "limit="+strconv.Itoa(pageSize))
+	}
+	return strings.Join(append(parts, sorts...), "&")
+}
+
+func escaped(key string, values []string) string {
+	escapedValues := make([]string, len(values))
+	for i, value := range values {
+		escapedValues[i] = url.QueryEscape(value)
+	}
+	return url.QueryEscape(key) + "=" + strings.Join(escapedValues, ",")
+}
+
+// number returns the first value as positive number or the fallback
+func number(values []string, fallback int) int {
+	if len(values) == 0 {
+		return fallback
+	}
+	n, err := strconv.Atoi(values[0])
+	if err != nil || n < 1 {
+		return fallback
+	}
+	return n
+}
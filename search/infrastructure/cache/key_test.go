@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+func TestKey(t *testing.T) {
+	min := float64(10)
+	base := Key([]domain.Filter{
+		domain.NewQueryFilter("shirt"),
+		domain.NewKeyValueFilter("color", []string{"red", "blue"}),
+		domain.NewKeyValueFilter("size", []string{"m"}),
+		domain.NewSortFilter("price", domain.SortDirectionAscending),
+	}, 20)
+
+	tests := []struct {
+		name    string
+		filters []domain.Filter
+		equal   bool
+	}{
+		{
+			name: "order of key value filters and values",
+			filters: []domain.Filter{
+				domain.NewKeyValueFilter("size", []string{"m"}),
+				domain.NewSortFilter("price", domain.SortDirectionAscending),
+				domain.NewKeyValueFilter("color", []string{"blue"}),
+				domain.NewQueryFilter("shirt"),
+				domain.NewKeyValueFilter("color", []string{"red"}),
+			},
+			equal: true,
+		},
+		{
+			name: "default page size and first page",
+			filters: []domain.Filter{
+				domain.NewQueryFilter("shirt"),
+				domain.NewKeyValueFilter("color", []string{"red", "blue"}),
+				domain.NewKeyValueFilter("size", []string{"m"}),
+				domain.NewSortFilter("price", domain.SortDirectionAscending),
+				domain.NewPaginationPageSizeFilter(20),
+				domain.NewKeyValueFilter("page", []string{"1"}),
+			},
+			equal: true,
+		},
+		{
+			name: "other page",
+			filters: []domain.Filter{
+				domain.NewQueryFilter("shirt"),
+				domain.NewKeyValueFilter("color", []string{"red", "blue"}),
+				domain.NewKeyValueFilter("size", []string{"m"}),
+				domain.NewSortFilter("price", domain.SortDirectionAscending),
+				domain.NewPaginationPageFilter(2),
+			},
+		},
+		{
+			name: "other value",
+			filters: []domain.Filter{
+				domain.NewQueryFilter("shirt"),
+				domain.NewKeyValueFilter("color", []string{"red", "green"}),
+				domain.NewKeyValueFilter("size", []string{"m"}),
+				domain.NewSortFilter("price", domain.SortDirectionAscending),
+			},
+		},
+		{
+			name: "typed filter with the same value",
+			filters: []domain.Filter{
+				domain.NewQueryFilter("shirt"),
+				domain.NewKeyValueFilter("color", []string{"red", "blue"}),
+				domain.NewKeyValueFilter("size", []string{"m"}),
+				domain.NewSortFilter("price", domain.SortDirectionAscending),
+				domain.NewRangeFilter("price", &min, nil),
+			},
+		},
+		{
+			name: "values containing separators",
+			filters: []domain.Filter{
+				domain.NewQueryFilter("shirt"),
+				domain.NewKeyValueFilter("color", []string{"red,blue"}),
+				domain.NewKeyValueFilter("size", []string{"m"}),
+				domain.NewSortFilter("price", domain.SortDirectionAscending),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.equal {
+				assert.Equal(t, base, Key(tt.filters, 20))
+			} else {
+				assert.NotEqual(t, base, Key(tt.filters, 20))
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"golang.org/x/sync/singleflight"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/opencensus"
+)
+
+type (
+	// SearchCache is the shared storage of the caching search services - a size limited LRU cache with time to live and invalidation tags.
+	// It needs to be bound as singleton
+	SearchCache struct {
+		mutex           sync.Mutex
+		entries         map[string]*list.Element
+		tags            map[string]map[string]struct{}
+		lru             *list.List
+		size            int
+		ttl             time.Duration
+		defaultPageSize int
+		loader          singleflight.Group
+		now             func() time.Time
+		taggers         []domain.DocumentTagger
+		// generation is increased by every invalidation, results loaded during an invalidation are not cached
+		generation uint64
+	}
+
+	// LoadFunc loads an uncached value and returns it with its invalidation tags.
+	// The context carries the values of the first caller but is not cancelled with it, because the value is shared with all concurrent callers
+	LoadFunc func(ctx context.Context) (value interface{}, tags []string, err error)
+
+	cacheEntry struct {
+		key       string
+		value     interface{}
+		tags      []string
+		expiresAt time.Time
+	}
+)
+
+const (
+	defaultSize = 1000
+	defaultTTL  = time.Minute
+
+	tagProductPrefix  = "product:"
+	tagCategoryPrefix = "category:"
+)
+
+var (
+	hitStat  = stats.Int64("flamingo-commerce/search/cache/hit", "search results found in the cache", stats.UnitDimensionless)
+	missStat = stats.Int64("flamingo-commerce/search/cache/miss", "search results loaded from the search service", stats.UnitDimensionless)
+	cacheKey tag.Key
+)
+
+func init() {
+	cacheKey, _ = tag.NewKey("cache")
+	opencensus.View("flamingo-commerce/search/cache/hit/count", hitStat, view.Count(), cacheKey)
+	opencensus.View("flamingo-commerce/search/cache/miss/count", missStat, view.Count(), cacheKey)
+}
+
+// TagProduct returns the invalidation tag of a product
+func TagProduct(marketplaceCode string) string {
+	return tagProductPrefix + marketplaceCode
+}
+
+// TagCategory returns the invalidation tag of a category
+func TagCategory(code string) string {
+	return tagCategoryPrefix + code
+}
+
+// Inject dependencies
+func (c *SearchCache) Inject(
+	logger flamingo.Logger,
+	config *struct {
+		Size            float64 `inject:"config:commerce.search.cache.size,optional"`
+		TTL             string  `inject:"config:commerce.search.cache.ttl,optional"`
+		DefaultPageSize float64 `inject:"config:pagination.defaultPageSize,optional"`
+	},
+	optionals *struct {
+		Taggers []domain.DocumentTagger `inject:",optional"`
+	},
+) {
+	c.init()
+	if optionals != nil {
+		c.taggers = optionals.Taggers
+	}
+	if config == nil {
+		return
+	}
+
+	logger = logger.WithField(flamingo.LogKeyCategory, "searchcache").WithField(flamingo.LogKeyModule, "search")
+	if config.Size > 0 {
+		c.size = int(config.Size)
+	}
+	if config.TTL != "" {
+		ttl, err := time.ParseDuration(config.TTL)
+		if err != nil {
+			logger.Error("search.cache.SearchCache: invalid ttl ", err)
+		} else {
+			c.ttl = ttl
+		}
+	}
+	c.defaultPageSize = int(config.DefaultPageSize)
+}
+
+func (c *SearchCache) init() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries != nil {
+		return
+	}
+	c.entries = make(map[string]*list.Element)
+	c.tags = make(map[string]map[string]struct{})
+	c.lru = list.New()
+	c.size = defaultSize
+	c.ttl = defaultTTL
+	c.now = time.Now
+}
+
+// Key returns the normalized cache key of the filters, see Key
+func (c *SearchCache) Key(prefix string, filters []domain.Filter) string {
+	return prefix + "?" + Key(filters, c.defaultPageSize)
+}
+
+// Load returns the cached value of the key or loads and caches it. Concurrent loads of the same key result in one call of load, errors are not cached.
+// The load is detached from the cancellation of the calling context, so that a cancelled caller does not fail the other callers waiting for the value -
+// the cancelled caller returns with the error of its context. The hits and misses are recorded with the cache name
+func (c *SearchCache) Load(ctx context.Context, name string, key string, load LoadFunc) (interface{}, error) {
+	ctx, _ = tag.New(ctx, tag.Upsert(cacheKey, name))
+	if value, ok := c.get(key); ok {
+		stats.Record(ctx, hitStat.M(1))
+		return value, nil
+	}
+
+	stats.Record(ctx, missStat.M(1))
+	loadCtx := context.WithoutCancel(ctx)
+	loaded := c.loader.DoChan(key, func() (interface{}, error) {
+		generation := c.currentGeneration()
+		value, tags, err := load(loadCtx)
+		if err == nil {
+			c.set(key, value, tags, generation)
+		}
+		return value, err
+	})
+
+	select {
+	case result := <-loaded:
+		return result.Val, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// DocumentTags returns the invalidation tags of the documents given by the bound domain.DocumentTaggers (see TagProduct and TagCategory)
+func (c *SearchCache) DocumentTags(documents []domain.Document) []string {
+	var tags []string
+	for _, document := range documents {
+		for _, tagger := range c.taggers {
+			tags = append(tags, tagger.Tags(document)...)
+		}
+	}
+	return tags
+}
+
+// FilterTags returns the category tags of the filters on the key "category" - so that cached results of a category are invalidated with the category
+func FilterTags(filters []domain.Filter) []string {
+	var tags []string
+	for _, filter := range filters {
+		key, values := filter.Value()
+		if key != "category" {
+			continue
+		}
+		for _, value := range values {
+			for _, code := range strings.Split(value, domain.TreePathSeparator) {
+				if code != "" {
+					tags = append(tags, TagCategory(code))
+				}
+			}
+		}
+	}
+	return tags
+}
+
+func (c *SearchCache) get(key string) (interface{}, bool) {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *SearchCache) currentGeneration() uint64 {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.generation
+}
+
+// set caches the value unless the cache was invalidated since the load started in the given generation - the value might be stale
+func (c *SearchCache) set(key string, value interface{}, tags []string, generation uint64) {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.ttl <= 0 || c.generation != generation {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	entry := &cacheEntry{key: key, value: value, tags: tags, expiresAt: c.now().Add(c.ttl)}
+	c.entries[key] = c.lru.PushFront(entry)
+	for _, invalidationTag := range tags {
+		if c.tags[invalidationTag] == nil {
+			c.tags[invalidationTag] = make(map[string]struct{})
+		}
+		c.tags[invalidationTag][key] = struct{}{}
+	}
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *SearchCache) remove(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	c.lru.Remove(element)
+	delete(c.entries, entry.key)
+	for _, invalidationTag := range entry.tags {
+		delete(c.tags[invalidationTag], entry.key)
+		if len(c.tags[invalidationTag]) == 0 {
+			delete(c.tags, invalidationTag)
+		}
+	}
+}
+
+// InvalidateTags removes the results with one of the tags from the cache
+func (c *SearchCache) InvalidateTags(tags ...string) {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	for _, invalidationTag := range tags {
+		for key := range c.tags[invalidationTag] {
+			if element, ok := c.entries[key]; ok {
+				c.remove(element)
+			}
+		}
+	}
+}
+
+// InvalidateProducts removes the results containing one of the products from the cache
+func (c *SearchCache) InvalidateProducts(marketplaceCodes ...string) {
+	tags := make([]string, len(marketplaceCodes))
+	for i, marketplaceCode := range marketplaceCodes {
+		tags[i] = TagProduct(marketplaceCode)
+	}
+	c.InvalidateTags(tags...)
+}
+
+// InvalidateCategories removes the results of the categories and the results containing products of the categories from the cache
+func (c *SearchCache) InvalidateCategories(codes ...string) {
+	tags := make([]string, len(codes))
+	for i, code := range codes {
+		tags[i] = TagCategory(code)
+	}
+	c.InvalidateTags(tags...)
+}
+
+// Flush removes all results from the cache
+func (c *SearchCache) Flush() {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	c.entries = make(map[string]*list.Element)
+	c.tags = make(map[string]map[string]struct{})
+	c.lru.Init()
+}
+
+// Len returns the number of cached results (including expired ones that have not been removed yet)
+func (c *SearchCache) Len() int {
+	c.init()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.lru.Len()
+}
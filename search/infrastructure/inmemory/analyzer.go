@@ -0,0 +1,114 @@
+package inmemory
+
+import (
+	"strings"
+)
+
+type (
+	// Analyzer splits texts into the terms of the index - terms are lower cased, stop words are removed and the remaining words are stemmed
+	Analyzer struct {
+		stemmer   Stemmer
+		stopWords map[string]bool
+	}
+
+	// Stemmer reduces a lower cased word to its stem
+	Stemmer interface {
+		Stem(word string) string
+	}
+
+	// EnglishStemmer is a light stemmer for english that removes plural and common inflection suffixes
+	EnglishStemmer struct{}
+
+	// GermanStemmer is a light stemmer for german that folds umlauts and removes common inflection suffixes
+	GermanStemmer struct{}
+
+	noopStemmer struct{}
+)
+
+var (
+	stemmers = map[string]Stemmer{
+		"en": EnglishStemmer{},
+		"de": GermanStemmer{},
+	}
+
+	stopWords = map[string][]string{
+		"en": {"a", "an", "and", "are", "as", "at", "be", "by", "for", "from", "in", "is", "it", "of", "on", "or", "the", "to", "with"},
+		"de": {"auf", "das", "dem", "den", "der", "des", "die", "ein", "eine", "einer", "eines", "für", "im", "in", "ist", "mit", "oder", "und", "von", "zu"},
+	}
+)
+
+// NewAnalyzer returns the analyzer for the locale (e.g. "de" or "de_DE") - unknown locales are analyzed without stemming and stop words
+func NewAnalyzer(locale string) *Analyzer {
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "_-"); i > 0 {
+		language = language[:i]
+	}
+
+	analyzer := &Analyzer{stemmer: noopStemmer{}, stopWords: make(map[string]bool)}
+	if stemmer, ok := stemmers[language]; ok {
+		analyzer.stemmer = stemmer
+	}
+	for _, word := range stopWords[language] {
+		analyzer.stopWords[word] = true
+	}
+	return analyzer
+}
+
+// Terms returns the terms of the text in their order of occurrence (including duplicates)
+func (a *Analyzer) Terms(text string) []string {
+	textWords := words(text)
+	terms := make([]string, 0, len(textWords))
+	for _, word := range textWords {
+		if a.stopWords[word] {
+			continue
+		}
+		terms = append(terms, a.stemmer.Stem(word))
+	}
+	return terms
+}
+
+// Stem removes plural and inflection suffixes, e.g. "shirts", "dresses", "printed" and "running" are stemmed to "shirt", "dress", "print" and "run"
+func (EnglishStemmer) Stem(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ing") && len(word) > 5:
+		return undouble(word[:len(word)-3])
+	case strings.HasSuffix(word, "ed") && len(word) > 4:
+		return undouble(word[:len(word)-2])
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// undouble removes the last letter of a double consonant ending ("runn" -> "run")
+func undouble(stem string) string {
+	n := len(stem)
+	if n < 3 || stem[n-1] != stem[n-2] || strings.ContainsRune("aeiouls", rune(stem[n-1])) {
+		return stem
+	}
+	return stem[:n-1]
+}
+
+// Stem folds umlauts and removes inflection suffixes, e.g. "Schuhe" and "Schuhen" are stemmed to "schuh", "Hüte" to "hut"
+func (GermanStemmer) Stem(word string) string {
+	word = strings.NewReplacer("ä", "a", "ö", "o", "ü", "u", "ß", "ss").Replace(word)
+
+	for _, suffix := range []string{"ern", "em", "en", "er", "es", "e", "s", "n"} {
+		if strings.HasSuffix(word, suffix) && len([]rune(word))-len(suffix) >= 3 {
+			return word[:len(word)-len(suffix)]
+		}
+	}
+	return word
+}
+
+func (noopStemmer) Stem(word string) string {
+	return word
+}
@@ -0,0 +1,46 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/inmemory"
+)
+
+func TestAnalyzer_Terms(t *testing.T) {
+	assert.Equal(t, []string{"pink", "shirt", "flamingo", "print"}, inmemory.NewAnalyzer("en").Terms("The pink Shirts with a flamingo-print!"))
+	assert.Equal(t, []string{"schwarz", "schuh", "hut"}, inmemory.NewAnalyzer("de_DE").Terms("Schwarze Schuhe und Hüte"))
+	assert.Equal(t, []string{"the", "shirts"}, inmemory.NewAnalyzer("").Terms("The shirts"), "unknown locales are not stemmed")
+}
+
+func TestEnglishStemmer_Stem(t *testing.T) {
+	stemmer := inmemory.EnglishStemmer{}
+	for word, stem := range map[string]string{
+		"shirts":   "shirt",
+		"dresses":  "dress",
+		"boxes":    "box",
+		"berries":  "berry",
+		"printed":  "print",
+		"running":  "run",
+		"dress":    "dress",
+		"cactus":   "cactus",
+		"red":      "red",
+		"sneakers": "sneaker",
+	} {
+		assert.Equal(t, stem, stemmer.Stem(word), word)
+	}
+}
+
+func TestGermanStemmer_Stem(t *testing.T) {
+	stemmer := inmemory.GermanStemmer{}
+	for word, stem := range map[string]string{
+		"schuhe":  "schuh",
+		"schuhen": "schuh",
+		"hemden":  "hemd",
+		"größe":   "gross",
+		"rot":     "rot",
+	} {
+		assert.Equal(t, stem, stemmer.Stem(word), word)
+	}
+}
@@ -0,0 +1,229 @@
+package inmemory
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+)
+
+// field returns the document field of the facet
+func (f FacetConfig) field() string {
+	if f.Field != "" {
+		return f.Field
+	}
+	return f.Name
+}
+
+// label returns the facet label or the name
+func (f FacetConfig) label() string {
+	if f.Label != "" {
+		return f.Label
+	}
+	return f.Name
+}
+
+// buildFacets computes the configured facets - the counts of a facet consider all filters except the filter of the facet itself,
+// so that the other values of a selected facet are still available. Facets without items are omitted
+func (s *SearchService) buildFacets(index *invertedIndex, matches []scoredDocument, request *searchRequest) (searchDomain.FacetCollection, []searchDomain.Facet) {
+	facets := make(searchDomain.FacetCollection)
+	var selectedFacets []searchDomain.Facet
+
+	for _, config := range s.facets {
+		field := config.field()
+		var documents []searchDomain.IndexDocument
+		for _, match := range matches {
+			if s.matchesFilters(index, match.document, request, field) {
+				documents = append(documents, match.document)
+			}
+		}
+
+		selection := request.selection(field)
+		facet := searchDomain.Facet{
+			Type:     config.Type,
+			Name:     config.Name,
+			Label:    config.label(),
+			Position: config.Position,
+		}
+		switch config.Type {
+		case searchDomain.TreeFacet:
+			facet.Items = treeFacetItems(documents, field, selection)
+		case searchDomain.RangeFacet:
+			facet.Items = rangeFacetItems(documents, field, selection)
+		default:
+			facet.Type = string(searchDomain.ListFacet)
+			facet.Items = listFacetItems(documents, field, selection.values)
+		}
+
+		if len(facet.Items) == 0 {
+			continue
+		}
+		facets[config.Name] = facet
+
+		if selected := selectedItems(facet.Items); len(selected) > 0 {
+			selectedFacet := facet
+			selectedFacet.Items = selected
+			selectedFacets = append(selectedFacets, selectedFacet)
+		}
+	}
+
+	return facets, selectedFacets
+}
+
+// listFacetItems counts the documents per keyword value - ordered by count and label
+func listFacetItems(documents []searchDomain.IndexDocument, field string, selectedValues []string) []*searchDomain.FacetItem {
+	items := make(map[string]*searchDomain.FacetItem)
+	var order []*searchDomain.FacetItem
+	for _, document := range documents {
+		counted := make(map[string]bool)
+		for _, value := range document.Keywords[field] {
+			if counted[value] {
+				continue
+			}
+			counted[value] = true
+
+			item, ok := items[value]
+			if !ok {
+				selected := containsFold(selectedValues, value)
+				item = &searchDomain.FacetItem{
+					Label:    document.KeywordLabel(field, value),
+					Value:    value,
+					Selected: selected,
+					Active:   selected,
+				}
+				items[value] = item
+				order = append(order, item)
+			}
+			item.Count++
+		}
+	}
+
+	sortFacetItems(order)
+	return order
+}
+
+// treeFacetItems builds the tree of the path values - a document is counted once for each node of its paths.
+// Nodes are selected by their code (key value filters) or their path (tree filters)
+func treeFacetItems(documents []searchDomain.IndexDocument, field string, selection facetSelection) []*searchDomain.FacetItem {
+	nodes := make(map[string]*searchDomain.FacetItem)
+	var roots []*searchDomain.FacetItem
+
+	for _, document := range documents {
+		counted := make(map[string]bool)
+		for _, path := range document.Keywords[field] {
+			var parent *searchDomain.FacetItem
+			nodePath := ""
+			for _, code := range strings.Split(path, "/") {
+				if code == "" {
+					continue
+				}
+				nodePath += "/" + code
+
+				node, ok := nodes[nodePath]
+				if !ok {
+					node = &searchDomain.FacetItem{
+						Label:    document.KeywordLabel(field, code),
+						Value:    code,
+						Selected: containsFold(selection.values, code) || containsFold(selection.paths, nodePath),
+					}
+					nodes[nodePath] = node
+					if parent == nil {
+						roots = append(roots, node)
+					} else {
+						parent.Items = append(parent.Items, node)
+					}
+				}
+				if !counted[nodePath] {
+					counted[nodePath] = true
+					node.Count++
+				}
+				parent = node
+			}
+		}
+	}
+
+	sortTree(roots)
+	return roots
+}
+
+// sortTree sorts the items of all levels and marks the selected items and their parents as active
+func sortTree(items []*searchDomain.FacetItem) bool {
+	active := false
+	for _, item := range items {
+		item.Active = sortTree(item.Items) || item.Selected
+		active = active || item.Active
+	}
+	sortFacetItems(items)
+	return active
+}
+
+// rangeFacetItems returns one item with the minimum and maximum of the number field and the selected range
+func rangeFacetItems(documents []searchDomain.IndexDocument, field string, selection facetSelection) []*searchDomain.FacetItem {
+	item := &searchDomain.FacetItem{Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, document := range documents {
+		number, ok := document.Numbers[field]
+		if !ok {
+			continue
+		}
+		item.Count++
+		item.Min = math.Min(item.Min, number)
+		item.Max = math.Max(item.Max, number)
+	}
+	if item.Count == 0 {
+		return nil
+	}
+
+	item.Value = formatRange(item.Min, item.Max)
+	item.Label = item.Value
+	item.SelectedMin, item.SelectedMax = item.Min, item.Max
+	if len(selection.ranges) > 0 {
+		min, _ := selection.ranges[0].Min()
+		max, _ := selection.ranges[0].Max()
+		item.Selected, item.Active = true, true
+		item.SelectedMin = math.Max(min, item.Min)
+		item.SelectedMax = math.Min(max, item.Max)
+	} else if len(selection.values) > 0 {
+		if min, max, ok := parseRange(selection.values[0]); ok {
+			item.Selected, item.Active = true, true
+			item.SelectedMin = math.Max(min, item.Min)
+			item.SelectedMax = math.Min(max, item.Max)
+		}
+	}
+	return []*searchDomain.FacetItem{item}
+}
+
+// selectedItems returns the selected items of all levels
+func selectedItems(items []*searchDomain.FacetItem) []*searchDomain.FacetItem {
+	var result []*searchDomain.FacetItem
+	for _, item := range items {
+		if item.Selected {
+			result = append(result, item)
+		}
+		result = append(result, selectedItems(item.Items)...)
+	}
+	return result
+}
+
+func sortFacetItems(items []*searchDomain.FacetItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return strings.ToLower(items[i].Label) < strings.ToLower(items[j].Label)
+	})
+}
+
+func formatRange(min, max float64) string {
+	return strconv.FormatFloat(min, 'f', -1, 64) + "-" + strconv.FormatFloat(max, 'f', -1, 64)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+package inmemory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// DocumentIndex holds the documents and their inverted indexes by document type - it is bound as singleton.
+	// The documents of the bound searchDomain.DocumentSource's are loaded on first use
+	DocumentIndex struct {
+		mutex     sync.RWMutex
+		analyzer  *Analyzer
+		sources   []searchDomain.DocumentSource
+		logger    flamingo.Logger
+		loaded    bool
+		documents map[string][]searchDomain.IndexDocument
+		indexes   map[string]*invertedIndex
+		types     []string
+	}
+
+	// invertedIndex is the inverted index of the documents of one type
+	invertedIndex struct {
+		documents []searchDomain.IndexDocument
+		// postings contains the term frequencies by term, document and field
+		postings     map[string]map[int]map[string]int
+		fieldLengths []map[string]int
+		avgLengths   map[string]float64
+		// filterKeys contains the keyword and number fields of the documents
+		filterKeys map[string]bool
+	}
+)
+
+const (
+	// bm25K1 controls the term frequency saturation
+	bm25K1 = 1.2
+	// bm25B controls the field length normalization
+	bm25B = 0.75
+)
+
+// Inject dependencies
+func (i *DocumentIndex) Inject(
+	logger flamingo.Logger,
+	config *struct {
+		Locale string `inject:"config:commerce.search.inmemory.locale,optional"`
+	},
+	optionals *struct {
+		Sources []searchDomain.DocumentSource `inject:",optional"`
+	},
+) {
+	i.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "inmemory.DocumentIndex")
+	locale := ""
+	if config != nil {
+		locale = config.Locale
+	}
+	i.analyzer = NewAnalyzer(locale)
+	if optionals != nil {
+		i.sources = optionals.Sources
+	}
+}
+
+// Index adds the documents - documents with the id of an existing document of the same type replace it
+func (i *DocumentIndex) Index(documents ...searchDomain.IndexDocument) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.index(documents)
+}
+
+// Reindex drops all documents and loads the documents of the bound sources again
+func (i *DocumentIndex) Reindex(ctx context.Context) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.documents = nil
+	i.indexes = nil
+	i.types = nil
+	i.loaded = true
+	return i.loadSources(ctx)
+}
+
+// Types returns the indexed document types in the order of their first occurrence
+func (i *DocumentIndex) Types(ctx context.Context) []string {
+	i.ensureLoaded(ctx)
+
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	return append([]string(nil), i.types...)
+}
+
+// getAnalyzer returns the analyzer - a default analyzer is used if the index was not injected
+func (i *DocumentIndex) getAnalyzer() *Analyzer {
+	if i.analyzer == nil {
+		return NewAnalyzer("")
+	}
+	return i.analyzer
+}
+
+// get returns the inverted index of the type
+func (i *DocumentIndex) get(ctx context.Context, documentType string) (*invertedIndex, bool) {
+	i.ensureLoaded(ctx)
+
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	index, ok := i.indexes[documentType]
+	return index, ok
+}
+
+func (i *DocumentIndex) ensureLoaded(ctx context.Context) {
+	i.mutex.RLock()
+	loaded := i.loaded
+	i.mutex.RUnlock()
+	if loaded {
+		return
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.loaded {
+		return
+	}
+	i.loaded = true
+	if err := i.loadSources(ctx); err != nil && i.logger != nil {
+		i.logger.WithContext(ctx).Error(err)
+	}
+}
+
+// loadSources indexes the documents of all sources - the caller needs to hold the write lock
+func (i *DocumentIndex) loadSources(ctx context.Context) error {
+	for _, source := range i.sources {
+		documents, err := source.Documents(ctx)
+		if err != nil {
+			return errors.Wrap(err, "documents could not be loaded")
+		}
+		i.index(documents)
+	}
+	return nil
+}
+
+// index adds the documents and rebuilds the inverted indexes of the changed types - the caller needs to hold the write lock
+func (i *DocumentIndex) index(documents []searchDomain.IndexDocument) {
+	if i.documents == nil {
+		i.documents = make(map[string][]searchDomain.IndexDocument)
+		i.indexes = make(map[string]*invertedIndex)
+	}
+
+	changedTypes := make(map[string]bool)
+	for _, document := range documents {
+		existing, known := i.documents[document.Type]
+		if !known {
+			i.types = append(i.types, document.Type)
+		}
+		changedTypes[document.Type] = true
+
+		replaced := false
+		for k := range existing {
+			if document.ID != "" && existing[k].ID == document.ID {
+				existing[k] = document
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			existing = append(existing, document)
+		}
+		i.documents[document.Type] = existing
+	}
+
+	analyzer := i.getAnalyzer()
+	for documentType := range changedTypes {
+		// the inverted index gets its own copy, searches on the previous index may still be running
+		i.indexes[documentType] = newInvertedIndex(analyzer, append([]searchDomain.IndexDocument(nil), i.documents[documentType]...))
+	}
+}
+
+func newInvertedIndex(analyzer *Analyzer, documents []searchDomain.IndexDocument) *invertedIndex {
+	index := &invertedIndex{
+		documents:    documents,
+		postings:     make(map[string]map[int]map[string]int),
+		fieldLengths: make([]map[string]int, len(documents)),
+		avgLengths:   make(map[string]float64),
+		filterKeys:   make(map[string]bool),
+	}
+
+	totalLengths := make(map[string]int)
+	for doc, document := range documents {
+		for key := range document.Keywords {
+			index.filterKeys[key] = true
+		}
+		for key := range document.Numbers {
+			index.filterKeys[key] = true
+		}
+
+		index.fieldLengths[doc] = make(map[string]int, len(document.Fields))
+		for field, text := range document.Fields {
+			terms := analyzer.Terms(text)
+			index.fieldLengths[doc][field] = len(terms)
+			totalLengths[field] += len(terms)
+
+			for _, term := range terms {
+				if index.postings[term] == nil {
+					index.postings[term] = make(map[int]map[string]int)
+				}
+				if index.postings[term][doc] == nil {
+					index.postings[term][doc] = make(map[string]int)
+				}
+				index.postings[term][doc][field]++
+			}
+		}
+	}
+
+	for field, total := range totalLengths {
+		index.avgLengths[field] = float64(total) / float64(len(documents))
+	}
+
+	return index
+}
+
+// search returns the BM25 scores of the documents that contain all terms - the score of a field is multiplied by its boost (default 1)
+func (index *invertedIndex) search(terms []string, boosts map[string]float64) map[int]float64 {
+	scores := make(map[int]float64)
+	total := float64(len(index.documents))
+
+	for n, term := range uniqueTerms(terms) {
+		postings := index.postings[term]
+		idf := math.Log(1 + (total-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+
+		termScores := make(map[int]float64, len(postings))
+		for doc, frequencies := range postings {
+			if _, matchesPreviousTerms := scores[doc]; n > 0 && !matchesPreviousTerms {
+				continue
+			}
+			for field, frequency := range frequencies {
+				boost, ok := boosts[field]
+				if !ok {
+					boost = 1
+				}
+				tf := float64(frequency)
+				norm := 1 - bm25B + bm25B*float64(index.fieldLengths[doc][field])/index.avgLengths[field]
+				termScores[doc] += boost * idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+			}
+		}
+
+		for doc, score := range termScores {
+			termScores[doc] = scores[doc] + score
+		}
+		scores = termScores
+		if len(scores) == 0 {
+			break
+		}
+	}
+
+	return scores
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	result := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			result = append(result, term)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,103 @@
+package inmemory
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type (
+	// prefixIndex maps the prefixes of the words of its entries to the entries ordered by weight
+	prefixIndex struct {
+		entries  map[string]*prefixEntry
+		prefixes map[string][]*prefixEntry
+	}
+
+	// prefixEntry is a suggestion, e.g. a word of a title or a category
+	prefixEntry struct {
+		text   string
+		field  string
+		value  string
+		weight int
+		words  []string
+	}
+)
+
+// maxPrefixLength is the length of the longest indexed prefix - entries of longer prefixes are filtered from the entries of the longest one
+const maxPrefixLength = 8
+
+func newPrefixIndex() *prefixIndex {
+	return &prefixIndex{
+		entries:  make(map[string]*prefixEntry),
+		prefixes: make(map[string][]*prefixEntry),
+	}
+}
+
+// add adds the entry or increases the weight of the entry with the same key - the prefixes are indexed by build
+func (p *prefixIndex) add(key string, text string, field string, value string) {
+	if entry, ok := p.entries[key]; ok {
+		entry.weight++
+		return
+	}
+	p.entries[key] = &prefixEntry{
+		text:   text,
+		field:  field,
+		value:  value,
+		weight: 1,
+		words:  words(text),
+	}
+}
+
+// build indexes the prefixes of all words of the entries
+func (p *prefixIndex) build() {
+	for _, entry := range p.entries {
+		indexed := make(map[string]bool)
+		for _, word := range entry.words {
+			runes := []rune(word)
+			for length := 1; length <= len(runes) && length <= maxPrefixLength; length++ {
+				prefix := string(runes[:length])
+				if indexed[prefix] {
+					continue
+				}
+				indexed[prefix] = true
+				p.prefixes[prefix] = append(p.prefixes[prefix], entry)
+			}
+		}
+	}
+
+	for _, entries := range p.prefixes {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].weight != entries[j].weight {
+				return entries[i].weight > entries[j].weight
+			}
+			return entries[i].text < entries[j].text
+		})
+	}
+}
+
+// lookup returns the entries with a word starting with the prefix ordered by weight
+func (p *prefixIndex) lookup(prefix string) []*prefixEntry {
+	prefix = strings.ToLower(prefix)
+	runes := []rune(prefix)
+	if len(runes) <= maxPrefixLength {
+		return p.prefixes[prefix]
+	}
+
+	var result []*prefixEntry
+	for _, entry := range p.prefixes[string(runes[:maxPrefixLength])] {
+		for _, word := range entry.words {
+			if strings.HasPrefix(word, prefix) {
+				result = append(result, entry)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// words returns the lower cased words of the text
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,525 @@
+package inmemory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// SearchService is an embedded full-text search adapter that searches the documents of the DocumentIndex
+	SearchService struct {
+		index           *DocumentIndex
+		logger          flamingo.Logger
+		defaultPageSize int
+		maxPageSize     int
+		boosts          map[string]float64
+		facets          []FacetConfig
+		sortFields      []string
+	}
+
+	// FacetConfig defines a facet that is computed for the documents
+	FacetConfig struct {
+		// Name of the facet and the filter key
+		Name  string `json:"name"`
+		Label string `json:"label"`
+		// Type is one of ListFacet, TreeFacet or RangeFacet
+		Type string `json:"type"`
+		// Field is the keyword (ListFacet, TreeFacet) or number (RangeFacet) field - defaults to the name
+		Field    string `json:"field"`
+		Position int    `json:"position"`
+	}
+
+	// searchRequest collects the interpreted filters
+	searchRequest struct {
+		query         string
+		page          int
+		pageSize      int
+		sortBy        string
+		sortDirection string
+		filters       []searchDomain.Filter
+	}
+
+	// facetSelection collects the values of the filters of a facet
+	facetSelection struct {
+		values []string
+		paths  []string
+		ranges []*searchDomain.RangeFilter
+	}
+
+	// scoredDocument is a document matching the query
+	scoredDocument struct {
+		document searchDomain.IndexDocument
+		score    float64
+	}
+)
+
+const (
+	defaultPageSize    = 20
+	defaultMaxPageSize = 100
+)
+
+var _ searchDomain.SearchService = (*SearchService)(nil)
+
+// Inject dependencies
+func (s *SearchService) Inject(
+	index *DocumentIndex,
+	logger flamingo.Logger,
+	config *struct {
+		DefaultPageSize float64      `inject:"config:commerce.search.inmemory.defaultPageSize,optional"`
+		MaxPageSize     float64      `inject:"config:commerce.search.inmemory.maxPageSize,optional"`
+		Boosts          config.Map   `inject:"config:commerce.search.inmemory.boosts,optional"`
+		Facets          config.Slice `inject:"config:commerce.search.inmemory.facets,optional"`
+		SortFields      config.Slice `inject:"config:commerce.search.inmemory.sortFields,optional"`
+	},
+) {
+	s.index = index
+	s.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "inmemory.SearchService")
+	s.defaultPageSize = defaultPageSize
+	s.maxPageSize = defaultMaxPageSize
+	if config == nil {
+		return
+	}
+
+	if config.DefaultPageSize > 0 {
+		s.defaultPageSize = int(config.DefaultPageSize)
+	}
+	if config.MaxPageSize > 0 {
+		s.maxPageSize = int(config.MaxPageSize)
+	}
+	if len(config.Boosts) > 0 {
+		if err := config.Boosts.MapInto(&s.boosts); err != nil {
+			s.logger.Error("invalid commerce.search.inmemory.boosts: ", err)
+		}
+	}
+	if len(config.Facets) > 0 {
+		if err := config.Facets.MapInto(&s.facets); err != nil {
+			s.logger.Error("invalid commerce.search.inmemory.facets: ", err)
+		}
+	}
+	if len(config.SortFields) > 0 {
+		if err := config.SortFields.MapInto(&s.sortFields); err != nil {
+			s.logger.Error("invalid commerce.search.inmemory.sortFields: ", err)
+		}
+	}
+}
+
+// Search returns a result for each indexed document type
+func (s *SearchService) Search(ctx context.Context, filter ...searchDomain.Filter) (map[string]searchDomain.Result, error) {
+	results := make(map[string]searchDomain.Result)
+	for _, documentType := range s.index.Types(ctx) {
+		result, err := s.SearchFor(ctx, documentType, filter...)
+		if err != nil {
+			return nil, err
+		}
+		results[documentType] = *result
+	}
+	return results, nil
+}
+
+// SearchFor returns the documents of the type matching the filters.
+// Supported are QueryFilter (all terms need to be found), SortFilter, PaginationPage, PaginationPageSize, key value filters and the typed and composite filters.
+// Values of one key are OR combined, different filters are AND combined. Key value filters of range facets are given as "min-max" (one bound may be empty),
+// of tree facets they match documents with the value anywhere in their paths. Key value filters on keys that no document has are ignored (e.g. tracking parameters)
+func (s *SearchService) SearchFor(ctx context.Context, documentType string, filter ...searchDomain.Filter) (*searchDomain.Result, error) {
+	index, ok := s.index.get(ctx, documentType)
+	if !ok {
+		return nil, errors.Wrapf(searchDomain.ErrNotFound, "document type %q is not indexed", documentType)
+	}
+
+	request := s.buildRequest(filter)
+	matches := s.matchQuery(index, request.query)
+
+	var hits []scoredDocument
+	for _, match := range matches {
+		if s.matchesFilters(index, match.document, request, "") {
+			hits = append(hits, match)
+		}
+	}
+	s.sort(hits, request)
+
+	// pages behind the last page are empty - checked before multiplying, so that huge page numbers do not overflow
+	numResults := len(hits)
+	start, end := numResults, numResults
+	if request.page-1 <= numResults/request.pageSize {
+		start = (request.page - 1) * request.pageSize
+		if numResults-start > request.pageSize {
+			end = start + request.pageSize
+		}
+	}
+	documents := make([]searchDomain.Document, 0, end-start)
+	for _, hit := range hits[start:end] {
+		documents = append(documents, hit.document.Document)
+	}
+
+	facets, selectedFacets := s.buildFacets(index, matches, request)
+
+	return &searchDomain.Result{
+		SearchMeta: searchDomain.SearchMeta{
+			Query:          request.query,
+			OriginalQuery:  request.query,
+			Page:           request.page,
+			NumPages:       int(math.Ceil(float64(numResults) / float64(request.pageSize))),
+			NumResults:     numResults,
+			SelectedFacets: selectedFacets,
+			SortOptions:    s.sortOptions(request),
+		},
+		Hits:   documents,
+		Facets: facets,
+	}, nil
+}
+
+func (s *SearchService) buildRequest(filters []searchDomain.Filter) *searchRequest {
+	request := &searchRequest{
+		page:     1,
+		pageSize: s.defaultPageSize,
+	}
+	if request.pageSize < 1 {
+		request.pageSize = defaultPageSize
+	}
+
+	for _, filter := range filters {
+		key, values := filter.Value()
+		if len(values) == 0 {
+			continue
+		}
+
+		switch f := filter.(type) {
+		case *searchDomain.QueryFilter:
+			request.query = values[0]
+		case *searchDomain.SortFilter:
+			request.sortBy = key
+			request.sortDirection = values[0]
+		case *searchDomain.PaginationPageSize:
+			if f.GetPageSize() > 0 {
+				request.pageSize = f.GetPageSize()
+			}
+		case *searchDomain.PaginationCursor:
+			// cursor pagination is not supported, the page is used
+			continue
+		default:
+			// the pagination page and url parameters passed as key value filters (e.g. by the search controller)
+			switch key {
+			case "q":
+				request.query = values[0]
+			case "page", "limit":
+				number, err := strconv.Atoi(values[0])
+				if err != nil || number < 1 {
+					continue
+				}
+				if key == "page" {
+					request.page = number
+				} else {
+					request.pageSize = number
+				}
+			default:
+				request.filters = append(request.filters, filter)
+			}
+		}
+	}
+
+	if request.pageSize > s.maxPageSize && s.maxPageSize > 0 {
+		request.pageSize = s.maxPageSize
+	}
+
+	return request
+}
+
+// matchQuery returns the documents that contain all terms of the query with their score - all documents if the query is empty
+func (s *SearchService) matchQuery(index *invertedIndex, query string) []scoredDocument {
+	if strings.TrimSpace(query) == "" {
+		matches := make([]scoredDocument, len(index.documents))
+		for i, document := range index.documents {
+			matches[i] = scoredDocument{document: document}
+		}
+		return matches
+	}
+
+	terms := s.index.getAnalyzer().Terms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	scores := index.search(terms, s.boosts)
+	matches := make([]scoredDocument, 0, len(scores))
+	for i, document := range index.documents {
+		if score, ok := scores[i]; ok {
+			matches = append(matches, scoredDocument{document: document, score: score})
+		}
+	}
+	return matches
+}
+
+// matchesFilters checks all filters of the request except the filters on the ignored key only (used for facet counts)
+func (s *SearchService) matchesFilters(index *invertedIndex, document searchDomain.IndexDocument, request *searchRequest, ignoredKey string) bool {
+	for _, filter := range request.filters {
+		if ignoredKey != "" && onlyKey(filter, ignoredKey) {
+			continue
+		}
+		if kvFilter, ok := filter.(*searchDomain.KeyValueFilter); ok {
+			if key, _ := kvFilter.Value(); !index.filterKeys[key] {
+				continue
+			}
+		}
+		if !s.matches(document, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches evaluates the filter for the document - unknown filter types are handled as key value filters
+func (s *SearchService) matches(document searchDomain.IndexDocument, filter searchDomain.Filter) bool {
+	switch f := filter.(type) {
+	case *searchDomain.RangeFilter:
+		number, ok := document.Numbers[f.Key()]
+		return ok && f.Matches(number)
+	case *searchDomain.TreeFilter:
+		for _, path := range document.Keywords[f.Key()] {
+			if f.Matches(strings.FieldsFunc(path, isPathSeparator)) {
+				return true
+			}
+		}
+		return false
+	case *searchDomain.BoolFilter:
+		if number, ok := document.Numbers[f.Key()]; ok {
+			return (number != 0) == f.Bool()
+		}
+		for _, value := range document.Keywords[f.Key()] {
+			if b, err := strconv.ParseBool(value); err == nil && b == f.Bool() {
+				return true
+			}
+		}
+		return false
+	case *searchDomain.ExistsFilter:
+		_, hasNumber := document.Numbers[f.Key()]
+		return hasNumber || len(document.Keywords[f.Key()]) > 0 || document.Fields[f.Key()] != ""
+	case *searchDomain.AndFilter:
+		for _, child := range f.Filters() {
+			if !s.matches(document, child) {
+				return false
+			}
+		}
+		return true
+	case *searchDomain.OrFilter:
+		for _, child := range f.Filters() {
+			if s.matches(document, child) {
+				return true
+			}
+		}
+		return false
+	case *searchDomain.NotFilter:
+		return f.Filter() == nil || !s.matches(document, f.Filter())
+	default:
+		key, values := filter.Value()
+		return s.matchesFilter(document, key, values)
+	}
+}
+
+func (s *SearchService) matchesFilter(document searchDomain.IndexDocument, key string, values []string) bool {
+	facet, isFacet := s.facetConfig(key)
+
+	if number, ok := document.Numbers[key]; ok && (!isFacet || facet.Type == searchDomain.RangeFacet) {
+		for _, value := range values {
+			if min, max, ok := parseRange(value); ok && number >= min && number <= max {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, documentValue := range document.Keywords[key] {
+		candidates := []string{documentValue}
+		if isFacet && facet.Type == searchDomain.TreeFacet {
+			candidates = strings.Split(documentValue, "/")
+		}
+		for _, candidate := range candidates {
+			for _, value := range values {
+				if strings.EqualFold(candidate, value) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// onlyKey checks if all (combined) filters are on the key
+func onlyKey(filter searchDomain.Filter, key string) bool {
+	switch f := filter.(type) {
+	case *searchDomain.AndFilter:
+		return allOnlyKey(f.Filters(), key)
+	case *searchDomain.OrFilter:
+		return allOnlyKey(f.Filters(), key)
+	case *searchDomain.NotFilter:
+		return f.Filter() != nil && onlyKey(f.Filter(), key)
+	default:
+		filterKey, _ := filter.Value()
+		return filterKey == key
+	}
+}
+
+func allOnlyKey(filters []searchDomain.Filter, key string) bool {
+	for _, filter := range filters {
+		if !onlyKey(filter, key) {
+			return false
+		}
+	}
+	return len(filters) > 0
+}
+
+// selection returns the values of the key value, tree and range filters on the key - including the OR combined ones
+func (r *searchRequest) selection(key string) facetSelection {
+	var selection facetSelection
+	for _, filter := range r.filters {
+		selection.add(filter, key)
+	}
+	return selection
+}
+
+func (s *facetSelection) add(filter searchDomain.Filter, key string) {
+	switch f := filter.(type) {
+	case *searchDomain.KeyValueFilter:
+		if filterKey, values := f.Value(); filterKey == key {
+			s.values = append(s.values, values...)
+		}
+	case *searchDomain.TreeFilter:
+		if f.Key() == key {
+			s.paths = append(s.paths, "/"+strings.Join(f.Path(), "/"))
+		}
+	case *searchDomain.RangeFilter:
+		if f.Key() == key {
+			s.ranges = append(s.ranges, f)
+		}
+	case *searchDomain.OrFilter:
+		for _, child := range f.Filters() {
+			s.add(child, key)
+		}
+	case *searchDomain.AndFilter:
+		for _, child := range f.Filters() {
+			s.add(child, key)
+		}
+	}
+}
+
+func isPathSeparator(r rune) bool {
+	return r == '/'
+}
+
+// facetConfig returns the facet of the filter key
+func (s *SearchService) facetConfig(key string) (FacetConfig, bool) {
+	for _, facet := range s.facets {
+		if facet.field() == key {
+			return facet, true
+		}
+	}
+	return FacetConfig{}, false
+}
+
+// sort the hits by the sort field (missing values last) or by score if a query is given
+func (s *SearchService) sort(hits []scoredDocument, request *searchRequest) {
+	if request.sortBy == "" {
+		if request.query != "" {
+			sort.SliceStable(hits, func(i, j int) bool {
+				return hits[i].score > hits[j].score
+			})
+		}
+		return
+	}
+
+	descending := request.sortDirection == searchDomain.SortDirectionDescending
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, aOk := sortValue(hits[i].document, request.sortBy)
+		b, bOk := sortValue(hits[j].document, request.sortBy)
+		if !aOk || !bOk {
+			return aOk
+		}
+		if descending {
+			return compareSortValues(b, a)
+		}
+		return compareSortValues(a, b)
+	})
+}
+
+// sortValue returns the number, the first keyword or the text of the field
+func sortValue(document searchDomain.IndexDocument, field string) (interface{}, bool) {
+	if number, ok := document.Numbers[field]; ok {
+		return number, true
+	}
+	if keywords := document.Keywords[field]; len(keywords) > 0 {
+		return strings.ToLower(keywords[0]), true
+	}
+	if text, ok := document.Fields[field]; ok {
+		return strings.ToLower(text), true
+	}
+	return nil, false
+}
+
+func compareSortValues(a, b interface{}) bool {
+	aNumber, aIsNumber := a.(float64)
+	bNumber, bIsNumber := b.(float64)
+	if aIsNumber && bIsNumber {
+		return aNumber < bNumber
+	}
+	if aIsNumber != bIsNumber {
+		return aIsNumber
+	}
+	return a.(string) < b.(string)
+}
+
+func (s *SearchService) sortOptions(request *searchRequest) []searchDomain.SortOption {
+	labels := s.sortFields
+	isConfigured := false
+	for _, label := range labels {
+		if label == request.sortBy {
+			isConfigured = true
+		}
+	}
+	if request.sortBy != "" && !isConfigured {
+		labels = append(labels[:len(labels):len(labels)], request.sortBy)
+	}
+
+	options := make([]searchDomain.SortOption, 0, len(labels))
+	for _, label := range labels {
+		selected := label == request.sortBy
+		options = append(options, searchDomain.SortOption{
+			Label:        label,
+			Asc:          label,
+			Desc:         label,
+			SelectedAsc:  selected && request.sortDirection != searchDomain.SortDirectionDescending,
+			SelectedDesc: selected && request.sortDirection == searchDomain.SortDirectionDescending,
+		})
+	}
+	return options
+}
+
+// parseRange parses "min-max" - a missing bound is open, a single number matches exactly
+func parseRange(value string) (float64, float64, bool) {
+	parts := strings.SplitN(value, "-", 2)
+	if len(parts) == 1 {
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return number, number, err == nil
+	}
+
+	min, max := math.Inf(-1), math.Inf(1)
+	var err error
+	if bound := strings.TrimSpace(parts[0]); bound != "" {
+		if min, err = strconv.ParseFloat(bound, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	if bound := strings.TrimSpace(parts[1]); bound != "" {
+		if max, err = strconv.ParseFloat(bound, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return min, max, true
+}
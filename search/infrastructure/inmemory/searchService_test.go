@@ -0,0 +1,295 @@
+package inmemory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/inmemory"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type staticSource []searchDomain.IndexDocument
+
+func (s staticSource) Documents(context.Context) ([]searchDomain.IndexDocument, error) {
+	return s, nil
+}
+
+func document(id string, title string, description string, category string, color string, price float64) searchDomain.IndexDocument {
+	return searchDomain.IndexDocument{
+		ID:       id,
+		Type:     "product",
+		Fields:   map[string]string{"title": title, "description": description},
+		Keywords: map[string][]string{"category": {category}, "color": {color}, "title": {title}},
+		Numbers:  map[string]float64{"price": price},
+		Labels:   map[string]map[string]string{"category": {"clothing": "Clothing", "shirts": "Shirts", "shoes": "Shoes"}},
+		Document: id,
+	}
+}
+
+func searchService(t *testing.T) *inmemory.SearchService {
+	t.Helper()
+	index := new(inmemory.DocumentIndex)
+	index.Inject(flamingo.NullLogger{}, &struct {
+		Locale string `inject:"config:commerce.search.inmemory.locale,optional"`
+	}{Locale: "en"}, &struct {
+		Sources []searchDomain.DocumentSource `inject:",optional"`
+	}{Sources: []searchDomain.DocumentSource{staticSource{
+		document("shirt-pink", "Pink shirt", "A pink cotton shirt with flamingo print", "clothing/shirts", "pink", 20),
+		document("shirt-blue", "Blue shirt", "A blue shirt", "clothing/shirts", "blue", 30),
+		document("sneaker", "Flamingo sneakers", "Pink sneakers for running", "clothing/shoes", "pink", 50),
+		document("mug", "Mug", "A mug with flamingo print", "home", "white", 10),
+	}}})
+
+	service := new(inmemory.SearchService)
+	service.Inject(index, flamingo.NullLogger{}, &struct {
+		DefaultPageSize float64      `inject:"config:commerce.search.inmemory.defaultPageSize,optional"`
+		MaxPageSize     float64      `inject:"config:commerce.search.inmemory.maxPageSize,optional"`
+		Boosts          config.Map   `inject:"config:commerce.search.inmemory.boosts,optional"`
+		Facets          config.Slice `inject:"config:commerce.search.inmemory.facets,optional"`
+		SortFields      config.Slice `inject:"config:commerce.search.inmemory.sortFields,optional"`
+	}{
+		DefaultPageSize: 2,
+		MaxPageSize:     3,
+		Boosts:          config.Map{"title": float64(3)},
+		Facets: config.Slice{
+			config.Map{"name": "category", "type": "TreeFacet", "position": float64(1)},
+			config.Map{"name": "price", "type": "RangeFacet", "position": float64(2)},
+			config.Map{"name": "color", "label": "Color", "type": "ListFacet", "position": float64(3)},
+		},
+		SortFields: config.Slice{"price", "title"},
+	})
+	return service
+}
+
+func hitIDs(result *searchDomain.Result) []string {
+	ids := make([]string, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.(string))
+	}
+	return ids
+}
+
+func TestSearchService_Query(t *testing.T) {
+	service := searchService(t)
+	ctx := context.Background()
+
+	result, err := service.SearchFor(ctx, "product", searchDomain.NewQueryFilter("flamingo"), searchDomain.NewPaginationPageSizeFilter(10))
+	assert.NoError(t, err)
+	assert.Equal(t, "sneaker", hitIDs(result)[0], "title matches are boosted")
+	assert.ElementsMatch(t, []string{"sneaker", "shirt-pink", "mug"}, hitIDs(result))
+
+	result, err = service.SearchFor(ctx, "product", searchDomain.NewQueryFilter("Pink Shirts"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shirt-pink"}, hitIDs(result), "all terms need to match - plurals are stemmed")
+	assert.Equal(t, "Pink Shirts", result.SearchMeta.Query)
+
+	result, err = service.SearchFor(ctx, "product", searchDomain.NewQueryFilter("the"))
+	assert.NoError(t, err)
+	assert.Empty(t, result.Hits, "queries with stop words only do not match")
+
+	_, err = service.SearchFor(ctx, "content")
+	assert.Equal(t, searchDomain.ErrNotFound, errors.Cause(err))
+
+	results, err := service.Search(ctx, searchDomain.NewQueryFilter("mug"))
+	assert.NoError(t, err)
+	if assert.Contains(t, results, "product") {
+		assert.Equal(t, 1, results["product"].SearchMeta.NumResults)
+	}
+}
+
+func TestSearchService_FiltersSortingAndPagination(t *testing.T) {
+	service := searchService(t)
+	ctx := context.Background()
+
+	result, err := service.SearchFor(ctx, "product", searchDomain.NewKeyValueFilter("color", []string{"pink", "BLUE"}), searchDomain.NewSortFilter("price", searchDomain.SortDirectionDescending))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sneaker", "shirt-blue"}, hitIDs(result))
+	assert.Equal(t, 3, result.SearchMeta.NumResults)
+	assert.Equal(t, 2, result.SearchMeta.NumPages)
+	assert.Equal(t, 1, result.SearchMeta.Page)
+	if assert.Len(t, result.SearchMeta.SortOptions, 2) {
+		assert.True(t, result.SearchMeta.SortOptions[0].SelectedDesc)
+	}
+
+	result, err = service.SearchFor(ctx, "product", searchDomain.NewPaginationPageFilter(2), searchDomain.NewSortFilter("title", searchDomain.SortDirectionAscending))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mug", "shirt-pink"}, hitIDs(result), "the second page sorted by title")
+
+	result, err = service.SearchFor(ctx, "product",
+		searchDomain.NewKeyValueFilter("category", []string{"shirts"}),
+		searchDomain.NewKeyValueFilter("price", []string{"25-"}),
+		searchDomain.NewKeyValueFilter("utm_source", []string{"newsletter"}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shirt-blue"}, hitIDs(result), "tree values match any path segment, unknown keys are ignored")
+
+	result, err = service.SearchFor(ctx, "product", searchDomain.NewKeyValueFilter("q", []string{"mug"}), searchDomain.NewKeyValueFilter("page", []string{"1"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mug"}, hitIDs(result), "url parameters passed as key value filters are interpreted")
+}
+
+func TestSearchService_PageWindow(t *testing.T) {
+	service := searchService(t)
+	ctx := context.Background()
+
+	result, err := service.SearchFor(ctx, "product", searchDomain.NewKeyValueFilter("page", []string{"2"}), searchDomain.NewKeyValueFilter("limit", []string{"9223372036854775807"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mug"}, hitIDs(result), "the page size is limited to the max page size")
+	assert.Equal(t, 2, result.SearchMeta.NumPages)
+
+	result, err = service.SearchFor(ctx, "product", searchDomain.NewKeyValueFilter("page", []string{"9223372036854775807"}), searchDomain.NewKeyValueFilter("limit", []string{"9223372036854775807"}))
+	assert.NoError(t, err)
+	assert.Empty(t, result.Hits, "pages behind the last page are empty")
+	assert.Equal(t, 4, result.SearchMeta.NumResults)
+
+	result, err = service.SearchFor(ctx, "product", searchDomain.NewPaginationPageFilter(3))
+	assert.NoError(t, err)
+	assert.Empty(t, result.Hits)
+
+	result, err = service.SearchFor(ctx, "product", searchDomain.NewPaginationPageFilter(2))
+	assert.NoError(t, err)
+	assert.Len(t, result.Hits, 2, "the last page is full")
+}
+
+func TestSearchService_TypedFilters(t *testing.T) {
+	service := searchService(t)
+	ctx := context.Background()
+	min, max := float64(20), float64(50)
+
+	tests := []struct {
+		name    string
+		filters []searchDomain.Filter
+		want    []string
+	}{
+		{
+			name:    "range with exclusive upper bound",
+			filters: []searchDomain.Filter{searchDomain.NewRangeFilterWithInclusion("price", &min, true, &max, false)},
+			want:    []string{"shirt-pink", "shirt-blue"},
+		},
+		{
+			name:    "open range",
+			filters: []searchDomain.Filter{searchDomain.NewRangeFilter("price", nil, &min)},
+			want:    []string{"shirt-pink", "mug"},
+		},
+		{
+			name:    "tree path matches the node and its children",
+			filters: []searchDomain.Filter{searchDomain.NewTreeFilter("category", "clothing")},
+			want:    []string{"shirt-pink", "shirt-blue", "sneaker"},
+		},
+		{
+			name:    "tree path does not match segments",
+			filters: []searchDomain.Filter{searchDomain.NewTreeFilter("category", "shirts")},
+			want:    []string{},
+		},
+		{
+			name: "or and not",
+			filters: []searchDomain.Filter{
+				searchDomain.NewOrFilter(searchDomain.NewTreeFilter("category", "home"), searchDomain.NewKeyValueFilter("color", []string{"pink"})),
+				searchDomain.NewNotFilter(searchDomain.NewTreeFilter("category", "clothing", "shoes")),
+			},
+			want: []string{"shirt-pink", "mug"},
+		},
+		{
+			name:    "exists",
+			filters: []searchDomain.Filter{searchDomain.NewNotFilter(searchDomain.NewExistsFilter("color"))},
+			want:    []string{},
+		},
+		{
+			name:    "parsed url parameters",
+			filters: searchDomain.NewKeyValueFilters(map[string][]string{"price": {"(20,]"}, "and:1:color": {"pink"}, "and:1:category:tree": {"clothing"}}),
+			want:    []string{"sneaker"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.SearchFor(ctx, "product", append(tt.filters, searchDomain.NewPaginationPageSizeFilter(10))...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, hitIDs(result))
+		})
+	}
+
+	result, err := service.SearchFor(ctx, "product", searchDomain.NewTreeFilter("category", "clothing", "shirts"), searchDomain.NewRangeFilter("price", &min, nil))
+	assert.NoError(t, err)
+	category := result.Facets["category"]
+	if assert.Len(t, category.Items, 1) && assert.Len(t, category.Items[0].Items, 2) {
+		assert.True(t, category.Items[0].Active)
+		assert.True(t, category.Items[0].Items[0].Selected, "the node of the tree filter path is selected")
+	}
+	price := result.Facets["price"]
+	if assert.Len(t, price.Items, 1) {
+		assert.True(t, price.Items[0].Selected)
+		assert.Equal(t, float64(20), price.Items[0].SelectedMin)
+		assert.Equal(t, float64(30), price.Items[0].SelectedMax)
+	}
+}
+
+func TestSearchService_Facets(t *testing.T) {
+	service := searchService(t)
+
+	result, err := service.SearchFor(context.Background(), "product",
+		searchDomain.NewKeyValueFilter("color", []string{"pink"}),
+		searchDomain.NewKeyValueFilter("category", []string{"shirts"}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shirt-pink"}, hitIDs(result))
+	assert.Equal(t, []string{"category", "price", "color"}, result.Facets.Order())
+
+	color := result.Facets["color"]
+	assert.Equal(t, "Color", color.Label)
+	assert.Equal(t, string(searchDomain.ListFacet), color.Type)
+	if assert.Len(t, color.Items, 2, "the color facet ignores the color filter") {
+		assert.Equal(t, "blue", color.Items[0].Value)
+		assert.Equal(t, int64(1), color.Items[0].Count)
+		assert.Equal(t, "pink", color.Items[1].Value)
+		assert.True(t, color.Items[1].Selected)
+	}
+
+	category := result.Facets["category"]
+	if assert.Len(t, category.Items, 1, "the category facet ignores the category filter") {
+		clothing := category.Items[0]
+		assert.Equal(t, "Clothing", clothing.Label)
+		assert.Equal(t, int64(2), clothing.Count)
+		assert.True(t, clothing.Active)
+		assert.False(t, clothing.Selected)
+		if assert.Len(t, clothing.Items, 2) {
+			assert.Equal(t, "shirts", clothing.Items[0].Value)
+			assert.True(t, clothing.Items[0].Selected)
+			assert.Equal(t, "shoes", clothing.Items[1].Value)
+		}
+	}
+
+	price := result.Facets["price"]
+	if assert.Len(t, price.Items, 1) {
+		assert.Equal(t, float64(20), price.Items[0].Min)
+		assert.Equal(t, float64(20), price.Items[0].Max)
+		assert.False(t, price.Items[0].Selected)
+	}
+
+	if assert.Len(t, result.SearchMeta.SelectedFacets, 2) {
+		assert.Equal(t, "category", result.SearchMeta.SelectedFacets[0].Name)
+		assert.Equal(t, "shirts", result.SearchMeta.SelectedFacets[0].Items[0].Value)
+		assert.Equal(t, "color", result.SearchMeta.SelectedFacets[1].Name)
+	}
+}
+
+func TestDocumentIndex_Index(t *testing.T) {
+	index := new(inmemory.DocumentIndex)
+	index.Index(document("mug", "Mug", "", "home", "white", 10))
+	index.Index(document("mug", "Cup", "", "home", "white", 10), document("plate", "Plate", "", "home", "white", 10))
+
+	service := new(inmemory.SearchService)
+	service.Inject(index, flamingo.NullLogger{}, nil)
+	result, err := service.SearchFor(context.Background(), "product")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mug", "plate"}, hitIDs(result))
+
+	result, err = service.SearchFor(context.Background(), "product", searchDomain.NewQueryFilter("cup"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mug"}, hitIDs(result), "documents with the same id are replaced")
+	assert.Empty(t, result.Facets, "no facets are configured")
+}
@@ -0,0 +1,281 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// SuggestService suggests completions, hits and terms from prefix indexes of the documents of the DocumentIndex.
+	// The prefix indexes are built on first use and rebuilt when the documents of a type changed
+	SuggestService struct {
+		index            *DocumentIndex
+		searchService    *SearchService
+		logger           flamingo.Logger
+		completionFields []string
+		termFields       []string
+		mutex            sync.Mutex
+		suggestIndexes   map[string]*suggestIndex
+	}
+
+	// suggestIndex contains the prefix indexes of the inverted index of one document type
+	suggestIndex struct {
+		source      *invertedIndex
+		completions *prefixIndex
+		terms       *prefixIndex
+	}
+
+	// scoredHit is a suggested document with the score of the best matching completion
+	scoredHit struct {
+		hit   searchDomain.SuggestHit
+		score float64
+	}
+)
+
+// maxCompletionHits is the number of completions of the last word that are searched for the top hits
+const maxCompletionHits = 10
+
+var _ searchDomain.SuggestService = (*SuggestService)(nil)
+
+// Inject dependencies
+func (s *SuggestService) Inject(
+	index *DocumentIndex,
+	searchService *SearchService,
+	logger flamingo.Logger,
+	config *struct {
+		CompletionFields config.Slice `inject:"config:commerce.search.inmemory.suggest.completionFields,optional"`
+		TermFields       config.Slice `inject:"config:commerce.search.inmemory.suggest.termFields,optional"`
+	},
+) {
+	s.index = index
+	s.searchService = searchService
+	s.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "inmemory.SuggestService")
+	s.completionFields = []string{"title"}
+	if config == nil {
+		return
+	}
+
+	if len(config.CompletionFields) > 0 {
+		if err := config.CompletionFields.MapInto(&s.completionFields); err != nil {
+			s.logger.Error("invalid commerce.search.inmemory.suggest.completionFields: ", err)
+		}
+	}
+	if len(config.TermFields) > 0 {
+		if err := config.TermFields.MapInto(&s.termFields); err != nil {
+			s.logger.Error("invalid commerce.search.inmemory.suggest.termFields: ", err)
+		}
+	}
+}
+
+// Suggest returns the completions of the last word of the query, the top documents of all types and the terms of the term fields starting with the last word.
+// The hits need to contain all complete words of the query and a completion of the last word - the last word is complete if the query ends with a space
+func (s *SuggestService) Suggest(ctx context.Context, query string, limit int) (*searchDomain.SuggestResult, error) {
+	result := &searchDomain.SuggestResult{
+		Query:       query,
+		Completions: []searchDomain.Suggestion{},
+		Hits:        []searchDomain.SuggestHit{},
+		Terms:       []searchDomain.SuggestTerm{},
+	}
+	queryWords := words(query)
+	if len(queryWords) == 0 || limit < 1 {
+		return result, nil
+	}
+
+	completeWords, prefix := queryWords, ""
+	if !strings.HasSuffix(query, " ") {
+		completeWords, prefix = queryWords[:len(queryWords)-1], queryWords[len(queryWords)-1]
+	}
+
+	completionWeights := make(map[string]int)
+	var completionOrder []string
+	termEntries := make(map[string]*searchDomain.SuggestTerm)
+	var termOrder []string
+	var hits []scoredHit
+
+	for _, documentType := range s.index.Types(ctx) {
+		index, ok := s.getSuggestIndex(ctx, documentType)
+		if !ok {
+			continue
+		}
+
+		completions := []string{prefix}
+		if prefix != "" {
+			completions = nil
+			for _, entry := range index.completions.lookup(prefix) {
+				if _, known := completionWeights[entry.text]; !known {
+					completionOrder = append(completionOrder, entry.text)
+				}
+				completionWeights[entry.text] += entry.weight
+				if len(completions) < maxCompletionHits {
+					completions = append(completions, entry.text)
+				}
+			}
+			for _, entry := range index.terms.lookup(prefix) {
+				key := entry.field + ":" + entry.value
+				term, known := termEntries[key]
+				if !known {
+					term = &searchDomain.SuggestTerm{Field: entry.field, Value: entry.value, Text: entry.text}
+					termEntries[key] = term
+					termOrder = append(termOrder, key)
+				}
+				term.Count += entry.weight
+			}
+		}
+
+		hits = append(hits, s.hits(index.source, documentType, completeWords, completions, query)...)
+	}
+
+	sort.SliceStable(completionOrder, func(i, j int) bool {
+		return completionWeights[completionOrder[i]] > completionWeights[completionOrder[j]]
+	})
+	for _, completion := range completionOrder {
+		if len(result.Completions) == limit {
+			break
+		}
+		text := strings.Join(append(append([]string(nil), completeWords...), completion), " ")
+		result.Completions = append(result.Completions, searchDomain.Suggestion{Text: text, Highlight: searchDomain.Highlight(text, query)})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].score > hits[j].score
+	})
+	for _, hit := range hits {
+		if len(result.Hits) == limit {
+			break
+		}
+		result.Hits = append(result.Hits, hit.hit)
+	}
+
+	sort.SliceStable(termOrder, func(i, j int) bool {
+		return termEntries[termOrder[i]].Count > termEntries[termOrder[j]].Count
+	})
+	for _, key := range termOrder {
+		if len(result.Terms) == limit {
+			break
+		}
+		term := termEntries[key]
+		term.Highlight = searchDomain.Highlight(term.Text, prefix)
+		result.Terms = append(result.Terms, *term)
+	}
+
+	return result, nil
+}
+
+// hits searches the documents that contain the complete words and one of the completions - a document gets the score of its best completion
+func (s *SuggestService) hits(index *invertedIndex, documentType string, completeWords []string, completions []string, query string) []scoredHit {
+	analyzer := s.index.getAnalyzer()
+	completeTerms := analyzer.Terms(strings.Join(completeWords, " "))
+
+	scores := make(map[int]float64)
+	for _, completion := range completions {
+		terms := append(append([]string(nil), completeTerms...), analyzer.Terms(completion)...)
+		if len(terms) == 0 {
+			continue
+		}
+		for doc, score := range index.search(terms, s.searchService.boosts) {
+			if score > scores[doc] {
+				scores[doc] = score
+			}
+		}
+	}
+
+	hits := make([]scoredHit, 0, len(scores))
+	for doc, score := range scores {
+		document := index.documents[doc]
+		text := document.ID
+		if titles := document.Keywords["title"]; len(titles) > 0 {
+			text = titles[0]
+		} else if title := document.Fields["title"]; title != "" {
+			text = title
+		}
+		hits = append(hits, scoredHit{
+			hit: searchDomain.SuggestHit{
+				Type:      documentType,
+				ID:        document.ID,
+				Text:      text,
+				Highlight: searchDomain.Highlight(text, query),
+				Document:  document.Document,
+			},
+			score: score,
+		})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].hit.ID < hits[j].hit.ID
+	})
+	return hits
+}
+
+// getSuggestIndex returns the prefix indexes of the document type - they are rebuilt if the documents changed
+func (s *SuggestService) getSuggestIndex(ctx context.Context, documentType string) (*suggestIndex, bool) {
+	source, ok := s.index.get(ctx, documentType)
+	if !ok {
+		return nil, false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.suggestIndexes == nil {
+		s.suggestIndexes = make(map[string]*suggestIndex)
+	}
+	if index, ok := s.suggestIndexes[documentType]; ok && index.source == source {
+		return index, true
+	}
+
+	index := s.buildSuggestIndex(source)
+	s.suggestIndexes[documentType] = index
+	return index, true
+}
+
+// buildSuggestIndex indexes the words of the completion fields and the keyword values of the term fields, weighted by the number of documents
+func (s *SuggestService) buildSuggestIndex(source *invertedIndex) *suggestIndex {
+	analyzer := s.index.getAnalyzer()
+	index := &suggestIndex{
+		source:      source,
+		completions: newPrefixIndex(),
+		terms:       newPrefixIndex(),
+	}
+
+	for _, document := range source.documents {
+		added := make(map[string]bool)
+		for _, field := range s.completionFields {
+			for _, word := range words(document.Fields[field]) {
+				if added[word] || analyzer.stopWords[word] {
+					continue
+				}
+				added[word] = true
+				index.completions.add(word, word, "", "")
+			}
+		}
+
+		for _, field := range s.termFields {
+			facet, _ := s.searchService.facetConfig(field)
+			for _, keyword := range document.Keywords[field] {
+				values := []string{keyword}
+				if facet.Type == searchDomain.TreeFacet {
+					values = strings.FieldsFunc(keyword, isPathSeparator)
+				}
+				for _, value := range values {
+					key := field + ":" + value
+					if added[key] {
+						continue
+					}
+					added[key] = true
+					index.terms.add(key, document.KeywordLabel(field, value), field, value)
+				}
+			}
+		}
+	}
+
+	index.completions.build()
+	index.terms.build()
+	return index
+}
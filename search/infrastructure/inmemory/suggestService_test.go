@@ -0,0 +1,101 @@
+package inmemory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/inmemory"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+func suggestService(t *testing.T) (*inmemory.SuggestService, *inmemory.DocumentIndex) {
+	t.Helper()
+	index := new(inmemory.DocumentIndex)
+	index.Inject(flamingo.NullLogger{}, nil, &struct {
+		Sources []searchDomain.DocumentSource `inject:",optional"`
+	}{Sources: []searchDomain.DocumentSource{staticSource{
+		document("shirt-pink", "Pink flamingo shirt", "A pink cotton shirt", "clothing/shirts", "pink", 20),
+		document("shirt-blue", "Blue shirt", "A blue shirt", "clothing/shirts", "blue", 30),
+		document("sneaker", "Flamingo sneakers", "Pink sneakers for running", "clothing/shoes", "pink", 50),
+		document("mug", "Flamingo mug", "A mug", "home", "white", 10),
+	}}})
+
+	search := new(inmemory.SearchService)
+	search.Inject(index, flamingo.NullLogger{}, &struct {
+		DefaultPageSize float64      `inject:"config:commerce.search.inmemory.defaultPageSize,optional"`
+		MaxPageSize     float64      `inject:"config:commerce.search.inmemory.maxPageSize,optional"`
+		Boosts          config.Map   `inject:"config:commerce.search.inmemory.boosts,optional"`
+		Facets          config.Slice `inject:"config:commerce.search.inmemory.facets,optional"`
+		SortFields      config.Slice `inject:"config:commerce.search.inmemory.sortFields,optional"`
+	}{
+		Facets: config.Slice{config.Map{"name": "category", "type": "TreeFacet"}},
+	})
+
+	service := new(inmemory.SuggestService)
+	service.Inject(index, search, flamingo.NullLogger{}, &struct {
+		CompletionFields config.Slice `inject:"config:commerce.search.inmemory.suggest.completionFields,optional"`
+		TermFields       config.Slice `inject:"config:commerce.search.inmemory.suggest.termFields,optional"`
+	}{
+		CompletionFields: config.Slice{"title"},
+		TermFields:       config.Slice{"category", "color"},
+	})
+	return service, index
+}
+
+func TestSuggestService_Suggest(t *testing.T) {
+	service, index := suggestService(t)
+	ctx := context.Background()
+
+	result, err := service.Suggest(ctx, "fla", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []searchDomain.Suggestion{{Text: "flamingo", Highlight: "<em>fla</em>mingo"}}, result.Completions)
+	if assert.Len(t, result.Hits, 2, "the hits are limited") {
+		assert.Equal(t, "product", result.Hits[0].Type)
+		assert.Contains(t, result.Hits[0].Highlight, "<em>Fla</em>mingo")
+	}
+	assert.Empty(t, result.Terms)
+
+	result, err = service.Suggest(ctx, "pink s", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pink shirt", "pink sneakers"}, suggestionTexts(result.Completions), "ordered by the number of documents")
+	assert.Equal(t, "<em>pink</em> <em>s</em>hirt", result.Completions[0].Highlight)
+	assert.ElementsMatch(t, []string{"shirt-pink", "sneaker"}, suggestHitIDs(result.Hits), "hits contain the complete words and a completion")
+	if assert.Len(t, result.Terms, 2) {
+		assert.Equal(t, searchDomain.SuggestTerm{Field: "category", Value: "shirts", Text: "Shirts", Highlight: "<em>S</em>hirts", Count: 2}, result.Terms[0])
+		assert.Equal(t, "shoes", result.Terms[1].Value)
+	}
+
+	result, err = service.Suggest(ctx, "flamingo ", 5)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Completions, "a complete last word is not completed")
+	assert.Len(t, result.Hits, 3)
+
+	index.Index(document("mug", "Coffee mug", "A mug", "home", "white", 10))
+	result, err = service.Suggest(ctx, "cof", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"coffee"}, suggestionTexts(result.Completions), "the prefix indexes are rebuilt after changes")
+
+	result, err = service.Suggest(ctx, " ", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, &searchDomain.SuggestResult{Query: " ", Completions: []searchDomain.Suggestion{}, Hits: []searchDomain.SuggestHit{}, Terms: []searchDomain.SuggestTerm{}}, result)
+}
+
+func suggestionTexts(suggestions []searchDomain.Suggestion) []string {
+	texts := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		texts = append(texts, suggestion.Text)
+	}
+	return texts
+}
+
+func suggestHitIDs(hits []searchDomain.SuggestHit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
@@ -0,0 +1,178 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// Engine applies stop words, synonyms and query rules to search queries - it is a domain.RequestQueryHook.
+	// The rules are configured or loaded from a json file, invalid rules are logged and skipped
+	Engine struct {
+		logger    flamingo.Logger
+		locale    string
+		rules     []*compiledRule
+		synonyms  map[string]string
+		stopWords map[string]bool
+	}
+
+	// Definition contains the rules, synonyms and stop words - it is the format of the rules file
+	Definition struct {
+		Rules []Rule `json:"rules"`
+		// Synonyms are groups of words - all words of a group are replaced by the first word
+		Synonyms [][]string `json:"synonyms"`
+		// StopWords are removed from the query
+		StopWords []string `json:"stopWords"`
+	}
+)
+
+// QueryParameter is the url parameter of the search query
+const QueryParameter = "q"
+
+var _ domain.RequestQueryHook = (*Engine)(nil)
+
+// Inject dependencies
+func (e *Engine) Inject(
+	logger flamingo.Logger,
+	config *struct {
+		Rules     config.Slice `inject:"config:commerce.search.rules.rules,optional"`
+		Synonyms  config.Slice `inject:"config:commerce.search.rules.synonyms,optional"`
+		StopWords config.Slice `inject:"config:commerce.search.rules.stopWords,optional"`
+		File      string       `inject:"config:commerce.search.rules.file,optional"`
+		Locale    string       `inject:"config:locale.locale,optional"`
+	},
+) {
+	e.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "rules.Engine")
+	if config == nil {
+		return
+	}
+
+	e.locale = config.Locale
+	var definition Definition
+	if err := config.Rules.MapInto(&definition.Rules); len(config.Rules) > 0 && err != nil {
+		e.logger.Error("invalid commerce.search.rules.rules: ", err)
+	}
+	if err := config.Synonyms.MapInto(&definition.Synonyms); len(config.Synonyms) > 0 && err != nil {
+		e.logger.Error("invalid commerce.search.rules.synonyms: ", err)
+	}
+	if err := config.StopWords.MapInto(&definition.StopWords); len(config.StopWords) > 0 && err != nil {
+		e.logger.Error("invalid commerce.search.rules.stopWords: ", err)
+	}
+
+	if config.File != "" {
+		fileDefinition, err := LoadDefinition(config.File)
+		if err != nil {
+			e.logger.Error(err)
+		} else {
+			definition.Rules = append(definition.Rules, fileDefinition.Rules...)
+			definition.Synonyms = append(definition.Synonyms, fileDefinition.Synonyms...)
+			definition.StopWords = append(definition.StopWords, fileDefinition.StopWords...)
+		}
+	}
+
+	for _, err := range e.Load(definition) {
+		e.logger.Error(err)
+	}
+}
+
+// LoadDefinition reads the json file
+func LoadDefinition(file string) (Definition, error) {
+	var definition Definition
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return definition, errors.Wrap(err, "search rules file could not be read")
+	}
+	if err := json.Unmarshal(content, &definition); err != nil {
+		return definition, errors.Wrapf(err, "search rules file %q is invalid", file)
+	}
+	return definition, nil
+}
+
+// Load replaces the rules, synonyms and stop words - the errors of invalid rules are returned, the valid rules are loaded anyway
+func (e *Engine) Load(definition Definition) []error {
+	var errs []error
+	rules := make([]*compiledRule, 0, len(definition.Rules))
+	for _, rule := range definition.Rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, compiled)
+	}
+
+	synonyms := make(map[string]string)
+	for _, group := range definition.Synonyms {
+		if len(group) == 0 {
+			continue
+		}
+		for _, synonym := range group[1:] {
+			synonyms[normalize(synonym)] = normalize(group[0])
+		}
+	}
+
+	stopWords := make(map[string]bool, len(definition.StopWords))
+	for _, word := range definition.StopWords {
+		stopWords[normalize(word)] = true
+	}
+
+	e.rules, e.synonyms, e.stopWords = rules, synonyms, stopWords
+	return errs
+}
+
+// Hook redirects (domain.RedirectError) or rewrites the query parameter "q"
+func (e *Engine) Hook(_ context.Context, _ string, query *url.Values) error {
+	if query == nil {
+		return nil
+	}
+	original := query.Get(QueryParameter)
+	if strings.TrimSpace(original) == "" {
+		return nil
+	}
+
+	result := e.Apply(original, e.locale)
+	if result.Redirect != "" {
+		return &domain.RedirectError{To: result.Redirect}
+	}
+	if result.Query != normalize(original) {
+		query.Set(QueryParameter, result.Query)
+	}
+	return nil
+}
+
+// Apply returns the normalized query without stop words, with replaced synonyms and the result of the first rule matching the query and the locale
+func (e *Engine) Apply(query string, locale string) Result {
+	words := strings.Fields(strings.ToLower(query))
+	result := make([]string, 0, len(words))
+	for _, word := range words {
+		if e.stopWords[word] {
+			continue
+		}
+		if synonym, ok := e.synonyms[word]; ok {
+			word = synonym
+		}
+		result = append(result, word)
+	}
+	normalized := strings.Join(result, " ")
+	if normalized == "" {
+		// a query of stop words only is kept
+		normalized = normalize(query)
+	}
+
+	for _, rule := range e.rules {
+		if rule.matches(normalized, locale) {
+			matched := rule.rule
+			return Result{Query: rule.rewrite(normalized), Redirect: matched.Redirect, Rule: &matched}
+		}
+	}
+	return Result{Query: normalized}
+}
@@ -0,0 +1,112 @@
+package rules_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/rules"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+func engine(t *testing.T, definition rules.Definition) *rules.Engine {
+	t.Helper()
+	engine := new(rules.Engine)
+	engine.Inject(flamingo.NullLogger{}, nil)
+	assert.Empty(t, engine.Load(definition))
+	return engine
+}
+
+func TestEngine_Apply(t *testing.T) {
+	e := engine(t, rules.Definition{
+		Rules: []rules.Rule{
+			{Query: "iPhone", Redirect: "/category/smartphones"},
+			{Match: rules.MatchPrefix, Query: "cheap", Rewrite: "sale"},
+			{Match: rules.MatchRegex, Query: `^(\w+) shirts?$`, Rewrite: "shirt $1"},
+			{Query: "handy", Locales: []string{"de"}, Redirect: "/de/category/smartphones"},
+		},
+		Synonyms:  [][]string{{"tshirt", "tee", "t-shirt"}},
+		StopWords: []string{"the", "a"},
+	})
+
+	tests := []struct {
+		name     string
+		query    string
+		locale   string
+		want     string
+		redirect string
+	}{
+		{name: "exact match is case insensitive", query: " IPHONE ", want: "iphone", redirect: "/category/smartphones"},
+		{name: "exact match needs the whole query", query: "iphone case", want: "iphone case"},
+		{name: "prefix match", query: "cheap shoes", want: "sale"},
+		{name: "regex rewrite with groups", query: "Red Shirts", want: "shirt red"},
+		{name: "stop words and synonyms", query: "the T-Shirt", want: "tshirt"},
+		{name: "stop words only are kept", query: "the", want: "the"},
+		{name: "locale scope by language", query: "handy", locale: "de_DE", want: "handy", redirect: "/de/category/smartphones"},
+		{name: "locale scope excludes other locales", query: "handy", locale: "en", want: "handy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := e.Apply(tt.query, tt.locale)
+			assert.Equal(t, tt.want, result.Query)
+			assert.Equal(t, tt.redirect, result.Redirect)
+		})
+	}
+}
+
+func TestEngine_Load(t *testing.T) {
+	e := new(rules.Engine)
+	errs := e.Load(rules.Definition{Rules: []rules.Rule{
+		{Match: rules.MatchRegex, Query: "(", Rewrite: "x"},
+		{Match: "fuzzy", Query: "a", Rewrite: "b"},
+		{Query: "a"},
+		{Query: "broken", Redirect: "http://[::1"},
+		{Query: "valid", Rewrite: "rewritten"},
+	}})
+	assert.Len(t, errs, 4)
+	assert.Empty(t, e.Apply("broken", "").Redirect, "rules with invalid redirects are rejected")
+	assert.Equal(t, "rewritten", e.Apply("valid", "").Query, "valid rules are loaded anyway")
+}
+
+func TestEngine_Hook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "rules.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{
+		"rules": [{"match": "exact", "query": "iphone", "redirect": "/category/smartphones"}],
+		"stopWords": ["the"]
+	}`), 0600))
+
+	e := new(rules.Engine)
+	e.Inject(flamingo.NullLogger{}, &struct {
+		Rules     config.Slice `inject:"config:commerce.search.rules.rules,optional"`
+		Synonyms  config.Slice `inject:"config:commerce.search.rules.synonyms,optional"`
+		StopWords config.Slice `inject:"config:commerce.search.rules.stopWords,optional"`
+		File      string       `inject:"config:commerce.search.rules.file,optional"`
+		Locale    string       `inject:"config:locale.locale,optional"`
+	}{
+		Synonyms: config.Slice{config.Slice{"sneaker", "trainer"}},
+		File:     file,
+	})
+
+	query := url.Values{"q": {"the iPhone"}}
+	err = e.Hook(context.Background(), "/search", &query)
+	assert.Equal(t, &domain.RedirectError{To: "/category/smartphones"}, err)
+
+	query = url.Values{"q": {"Trainer"}, "color": {"red"}}
+	assert.NoError(t, e.Hook(context.Background(), "/search", &query))
+	assert.Equal(t, url.Values{"q": {"sneaker"}, "color": {"red"}}, query)
+
+	query = url.Values{"q": {"Red  Shoes"}}
+	assert.NoError(t, e.Hook(context.Background(), "/search", &query))
+	assert.Equal(t, "Red  Shoes", query.Get("q"), "queries without changes are kept as entered")
+}
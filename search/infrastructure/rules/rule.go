@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Rule matches search queries and redirects or rewrites them
+	Rule struct {
+		// Match is one of MatchExact (default), MatchPrefix or MatchRegex
+		Match string `json:"match"`
+		// Query is the matched query or the regular expression - queries are matched case insensitive
+		Query string `json:"query"`
+		// Locales restrict the rule to these locales (e.g. "de" or "de_DE") - the rule applies to all locales if empty
+		Locales []string `json:"locales"`
+		// Redirect is the url the search redirects to
+		Redirect string `json:"redirect"`
+		// Rewrite replaces the query, regex rules can use the groups of the expression (e.g. "$1")
+		Rewrite string `json:"rewrite"`
+	}
+
+	// Result of the rules for a query
+	Result struct {
+		// Query is the query after removing the stop words, replacing the synonyms and applying the rewrite of the matched rule
+		Query string
+		// Redirect is the redirect of the matched rule
+		Redirect string
+		// Rule is the matched rule
+		Rule *Rule
+	}
+
+	compiledRule struct {
+		rule   Rule
+		regexp *regexp.Regexp
+	}
+)
+
+const (
+	// MatchExact matches queries equal to the rule query
+	MatchExact = "exact"
+	// MatchPrefix matches queries starting with the rule query
+	MatchPrefix = "prefix"
+	// MatchRegex matches queries matching the regular expression of the rule query
+	MatchRegex = "regex"
+)
+
+func compileRule(rule Rule) (*compiledRule, error) {
+	compiled := &compiledRule{rule: rule}
+	switch rule.Match {
+	case "", MatchExact, MatchPrefix:
+		compiled.rule.Query = normalize(rule.Query)
+	case MatchRegex:
+		expression, err := regexp.Compile("(?i)" + rule.Query)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regular expression of rule %q", rule.Query)
+		}
+		compiled.regexp = expression
+	default:
+		return nil, errors.Errorf("unknown match %q of rule %q", rule.Match, rule.Query)
+	}
+
+	if rule.Redirect == "" && rule.Rewrite == "" {
+		return nil, errors.Errorf("rule %q has neither redirect nor rewrite", rule.Query)
+	}
+	if rule.Redirect != "" {
+		if _, err := url.Parse(rule.Redirect); err != nil {
+			return nil, errors.Wrapf(err, "invalid redirect of rule %q", rule.Query)
+		}
+	}
+	return compiled, nil
+}
+
+// matches checks the normalized query and the locale
+func (r *compiledRule) matches(query string, locale string) bool {
+	if !r.appliesTo(locale) {
+		return false
+	}
+
+	switch {
+	case r.regexp != nil:
+		return r.regexp.MatchString(query)
+	case r.rule.Match == MatchPrefix:
+		return strings.HasPrefix(query, r.rule.Query)
+	default:
+		return query == r.rule.Query
+	}
+}
+
+// appliesTo checks if the locale or its language is one of the locales of the rule
+func (r *compiledRule) appliesTo(locale string) bool {
+	if len(r.rule.Locales) == 0 {
+		return true
+	}
+
+	language := locale
+	if i := strings.IndexAny(locale, "_-"); i > 0 {
+		language = locale[:i]
+	}
+	for _, ruleLocale := range r.rule.Locales {
+		ruleLocale = strings.Replace(ruleLocale, "-", "_", -1)
+		if strings.EqualFold(ruleLocale, strings.Replace(locale, "-", "_", -1)) || strings.EqualFold(ruleLocale, language) {
+			return true
+		}
+	}
+	return false
+}
+
+// rewrite returns the rewritten query
+func (r *compiledRule) rewrite(query string) string {
+	if r.rule.Rewrite == "" {
+		return query
+	}
+	if r.regexp != nil {
+		return normalize(r.regexp.ReplaceAllString(query, r.rule.Rewrite))
+	}
+	return normalize(r.rule.Rewrite)
+}
+
+// normalize lower cases the query and collapses the white space
+func normalize(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
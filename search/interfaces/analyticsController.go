@@ -0,0 +1,106 @@
+package interfaces
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// AnalyticsController tracks clicks on search results and returns the search analytics report as json
+	AnalyticsController struct {
+		responder    *web.Responder
+		eventRouter  flamingo.EventRouter
+		analytics    domain.SearchAnalytics
+		logger       flamingo.Logger
+		defaultLimit int
+	}
+
+	// APIAnalyticsResult is the json response of the analytics api - Report is set by the report action
+	APIAnalyticsResult struct {
+		Success bool                    `json:"success"`
+		Error   *APIError               `json:"error,omitempty"`
+		Report  *domain.AnalyticsReport `json:"report,omitempty"`
+	}
+)
+
+const (
+	apiErrorInvalidClick       = "search_click_invalid"
+	apiErrorAnalyticsDisabled  = "search_analytics_disabled"
+	defaultAnalyticsReportSize = 20
+)
+
+// Inject dependencies
+func (c *AnalyticsController) Inject(
+	responder *web.Responder,
+	logger flamingo.Logger,
+	config *struct {
+		ReportLimit float64 `inject:"config:commerce.search.analytics.reportLimit,optional"`
+	},
+	optionals *struct {
+		EventRouter flamingo.EventRouter   `inject:",optional"`
+		Analytics   domain.SearchAnalytics `inject:",optional"`
+	},
+) {
+	c.responder = responder
+	c.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "AnalyticsController")
+	c.defaultLimit = defaultAnalyticsReportSize
+	if config != nil && config.ReportLimit > 0 {
+		c.defaultLimit = int(config.ReportLimit)
+	}
+	if optionals != nil {
+		c.eventRouter = optionals.EventRouter
+		c.analytics = optionals.Analytics
+	}
+}
+
+// ClickAction dispatches the domain.SearchResultClickedEvent of the form values "q" (the query), "type", "id" and "position" of the clicked result
+func (c *AnalyticsController) ClickAction(ctx context.Context, r *web.Request) web.Result {
+	query, _ := r.Form1("q")
+	documentType, _ := r.Form1("type")
+	documentID, _ := r.Form1("id")
+	position, _ := r.Form1("position")
+
+	event := &domain.SearchResultClickedEvent{
+		Query:        domain.NormalizeQuery(query),
+		DocumentType: documentType,
+		DocumentID:   documentID,
+	}
+	var err error
+	if position != "" {
+		event.Position, err = strconv.Atoi(position)
+	}
+	if documentID == "" || err != nil || event.Position < 0 {
+		return c.responder.Data(APIAnalyticsResult{Error: &APIError{Message: "the id and a valid position of the clicked result are required", Code: apiErrorInvalidClick}}).Status(http.StatusBadRequest)
+	}
+
+	if c.eventRouter != nil {
+		c.eventRouter.Dispatch(ctx, event)
+	}
+	return c.responder.Data(APIAnalyticsResult{Success: true}).SetNoCache()
+}
+
+// ReportAction returns the analytics report with at most "limit" queries per list
+func (c *AnalyticsController) ReportAction(ctx context.Context, r *web.Request) web.Result {
+	if c.analytics == nil {
+		return c.responder.Data(APIAnalyticsResult{Error: &APIError{Message: "search analytics are disabled", Code: apiErrorAnalyticsDisabled}}).Status(http.StatusNotFound)
+	}
+
+	limit := c.defaultLimit
+	if value, err := r.Query1("limit"); err == nil {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	report, err := c.analytics.Report(ctx, limit)
+	if err != nil {
+		c.logger.WithContext(ctx).Error(err)
+		return c.responder.Data(APIAnalyticsResult{Error: &APIError{Message: err.Error(), Code: apiErrorGeneral}}).Status(http.StatusInternalServerError)
+	}
+	return c.responder.Data(APIAnalyticsResult{Success: true, Report: report}).SetNoCache()
+}
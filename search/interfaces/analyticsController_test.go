@@ -0,0 +1,103 @@
+package interfaces_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/interfaces"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	clickRouterStub struct {
+		events []flamingo.Event
+	}
+
+	reportStub struct {
+		limit int
+	}
+)
+
+func (r *clickRouterStub) Dispatch(_ context.Context, event flamingo.Event) {
+	r.events = append(r.events, event)
+}
+
+func (*reportStub) RecordSearch(context.Context, domain.SearchPerformedEvent) error {
+	return nil
+}
+
+func (*reportStub) RecordClick(context.Context, domain.SearchResultClickedEvent) error {
+	return nil
+}
+
+func (s *reportStub) Report(_ context.Context, limit int) (*domain.AnalyticsReport, error) {
+	s.limit = limit
+	return &domain.AnalyticsReport{TopQueries: []domain.QueryStatistics{{Query: "shirt", Searches: 1}}}, nil
+}
+
+func analyticsController(router flamingo.EventRouter, analytics domain.SearchAnalytics) *interfaces.AnalyticsController {
+	controller := new(interfaces.AnalyticsController)
+	controller.Inject(new(web.Responder), flamingo.NullLogger{}, &struct {
+		ReportLimit float64 `inject:"config:commerce.search.analytics.reportLimit,optional"`
+	}{ReportLimit: 10}, &struct {
+		EventRouter flamingo.EventRouter   `inject:",optional"`
+		Analytics   domain.SearchAnalytics `inject:",optional"`
+	}{EventRouter: router, Analytics: analytics})
+	return controller
+}
+
+func clickRequest(form url.Values) *web.Request {
+	request, _ := http.NewRequest(http.MethodPost, "/api/search-analytics/click", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return web.CreateRequest(request, nil)
+}
+
+func TestAnalyticsController_ClickAction(t *testing.T) {
+	router := new(clickRouterStub)
+	controller := analyticsController(router, nil)
+
+	result := controller.ClickAction(context.Background(), clickRequest(url.Values{"q": {" Red Shirt"}, "type": {"product"}, "id": {"shirt-1"}, "position": {"3"}}))
+	if response, ok := result.(*web.DataResponse); assert.True(t, ok) {
+		assert.Equal(t, uint(200), response.Response.Status)
+		assert.Equal(t, interfaces.APIAnalyticsResult{Success: true}, response.Data)
+	}
+	assert.Equal(t, []flamingo.Event{&domain.SearchResultClickedEvent{Query: "red shirt", DocumentType: "product", DocumentID: "shirt-1", Position: 3}}, router.events)
+
+	for _, form := range []url.Values{{"q": {"shirt"}}, {"id": {"shirt-1"}, "position": {"first"}}} {
+		result = controller.ClickAction(context.Background(), clickRequest(form))
+		if response, ok := result.(*web.DataResponse); assert.True(t, ok) {
+			assert.Equal(t, uint(400), response.Response.Status)
+			assert.Equal(t, "search_click_invalid", response.Data.(interfaces.APIAnalyticsResult).Error.Code)
+		}
+	}
+	assert.Len(t, router.events, 1, "invalid clicks are not dispatched")
+}
+
+func TestAnalyticsController_ReportAction(t *testing.T) {
+	store := new(reportStub)
+	controller := analyticsController(nil, store)
+
+	request, _ := http.NewRequest(http.MethodGet, "/api/search-analytics", nil)
+	result := controller.ReportAction(context.Background(), web.CreateRequest(request, nil))
+	if response, ok := result.(*web.DataResponse); assert.True(t, ok) {
+		assert.Equal(t, uint(200), response.Response.Status)
+		assert.Equal(t, "shirt", response.Data.(interfaces.APIAnalyticsResult).Report.TopQueries[0].Query)
+	}
+	assert.Equal(t, 10, store.limit, "the configured limit is the default")
+
+	request, _ = http.NewRequest(http.MethodGet, "/api/search-analytics?limit=3", nil)
+	controller.ReportAction(context.Background(), web.CreateRequest(request, nil))
+	assert.Equal(t, 3, store.limit)
+
+	result = analyticsController(nil, nil).ReportAction(context.Background(), web.CreateRequest(request, nil))
+	if response, ok := result.(*web.DataResponse); assert.True(t, ok) {
+		assert.Equal(t, uint(404), response.Response.Status)
+	}
+}
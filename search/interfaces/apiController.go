@@ -0,0 +1,414 @@
+package interfaces
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/search/application"
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/utils"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// APIController returns search results as json
+	APIController struct {
+		responder     *web.Responder
+		searchService *application.SearchService
+		logger        flamingo.Logger
+		hitMappers    map[string]application.APIHitMapper
+		queryHooks    []domain.RequestQueryHook
+		federated     bool
+		typeWeights   config.Map
+	}
+
+	// APISearchResult is the json response of the search api - Result is set for a search of one type, Results for a search of all types
+	// and Federated additionally for a federated search of all types
+	APISearchResult struct {
+		Success   bool                  `json:"success"`
+		Error     *APIError             `json:"error,omitempty"`
+		Redirect  *APIRedirect          `json:"redirect,omitempty"`
+		Result    *APIResult            `json:"result,omitempty"`
+		Results   map[string]*APIResult `json:"results,omitempty"`
+		Federated *APIFederatedResult   `json:"federated,omitempty"`
+	}
+
+	// APIError contains details if success is false
+	APIError struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	}
+
+	// APIRedirect is returned instead of a result if the search backend enforces a redirect (domain.RedirectError)
+	APIRedirect struct {
+		To string `json:"to"`
+	}
+
+	// APIResult is the search result of one document type
+	APIResult struct {
+		Meta        APISearchMeta       `json:"meta"`
+		Hits        []interface{}       `json:"hits"`
+		Facets      []APIFacet          `json:"facets"`
+		SortOptions []APISortOption     `json:"sortOptions"`
+		Pagination  APIPagination       `json:"pagination"`
+		Suggestions []domain.Suggestion `json:"suggestions"`
+	}
+
+	// APIFederatedResult is the representation of application.FederatedResult - the hits of all types in one ranked list with the combined facets
+	APIFederatedResult struct {
+		Meta        APISearchMeta       `json:"meta"`
+		Hits        []APIFederatedHit   `json:"hits"`
+		Facets      []APIFacet          `json:"facets"`
+		Pagination  APIPagination       `json:"pagination"`
+		Suggestions []domain.Suggestion `json:"suggestions"`
+	}
+
+	// APIFederatedHit is a hit of the federated result with its document type and score
+	APIFederatedHit struct {
+		Type  string      `json:"type"`
+		Score float64     `json:"score"`
+		Hit   interface{} `json:"hit"`
+	}
+
+	// APISearchMeta is the representation of domain.SearchMeta
+	APISearchMeta struct {
+		Query          string     `json:"query"`
+		OriginalQuery  string     `json:"originalQuery"`
+		Page           int        `json:"page"`
+		NumPages       int        `json:"numPages"`
+		NumResults     int        `json:"numResults"`
+		SelectedFacets []APIFacet `json:"selectedFacets"`
+		NextCursor     string     `json:"nextCursor,omitempty"`
+		PreviousCursor string     `json:"previousCursor,omitempty"`
+	}
+
+	// APIFacet is the representation of domain.Facet
+	APIFacet struct {
+		Type     string         `json:"type"`
+		Name     string         `json:"name"`
+		Label    string         `json:"label"`
+		Position int            `json:"position"`
+		Items    []APIFacetItem `json:"items"`
+	}
+
+	// APIFacetItem is the representation of domain.FacetItem - Items are set for tree facets, the min and max values for range facets
+	APIFacetItem struct {
+		Label       string         `json:"label"`
+		Value       string         `json:"value"`
+		Active      bool           `json:"active"`
+		Selected    bool           `json:"selected"`
+		Count       int64          `json:"count"`
+		Items       []APIFacetItem `json:"items,omitempty"`
+		Min         *float64       `json:"min,omitempty"`
+		Max         *float64       `json:"max,omitempty"`
+		SelectedMin *float64       `json:"selectedMin,omitempty"`
+		SelectedMax *float64       `json:"selectedMax,omitempty"`
+	}
+
+	// APISortOption is the representation of domain.SortOption
+	APISortOption struct {
+		Label        string `json:"label"`
+		Asc          string `json:"asc"`
+		Desc         string `json:"desc"`
+		SelectedAsc  bool   `json:"selectedAsc"`
+		SelectedDesc bool   `json:"selectedDesc"`
+	}
+
+	// APIPagination is the representation of utils.PaginationInfo - loadMore and loadPrevious are set if the search backend supports cursor pagination
+	APIPagination struct {
+		TotalHits    int            `json:"totalHits"`
+		NextPage     *APIPage       `json:"nextPage,omitempty"`
+		PreviousPage *APIPage       `json:"previousPage,omitempty"`
+		Pages        []APIPage      `json:"pages"`
+		LoadMore     *APICursorPage `json:"loadMore,omitempty"`
+		LoadPrevious *APICursorPage `json:"loadPrevious,omitempty"`
+	}
+
+	// APICursorPage is a cursor link of the pagination
+	APICursorPage struct {
+		Cursor string `json:"cursor"`
+		URL    string `json:"url"`
+	}
+
+	// APIPage is a page link of the pagination - spacers have no page and url
+	APIPage struct {
+		Page     int    `json:"page,omitempty"`
+		URL      string `json:"url,omitempty"`
+		IsActive bool   `json:"isActive"`
+		IsSpacer bool   `json:"isSpacer"`
+	}
+)
+
+const (
+	apiErrorNotFound = "search_not_found"
+	apiErrorGeneral  = "search_error"
+
+	// federatedParameter enables or disables the federated search of all types
+	federatedParameter = "federated"
+)
+
+// Inject dependencies
+func (c *APIController) Inject(
+	responder *web.Responder,
+	searchService *application.SearchService,
+	logger flamingo.Logger,
+	cfg *struct {
+		Federated   bool       `inject:"config:commerce.search.federation.enabled,optional"`
+		TypeWeights config.Map `inject:"config:commerce.search.federation.typeWeights,optional"`
+	},
+	optionals *struct {
+		HitMappers []application.APIHitMapper `inject:",optional"`
+		QueryHooks []domain.RequestQueryHook  `inject:",optional"`
+	},
+) {
+	c.responder = responder
+	c.searchService = searchService
+	c.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "APIController")
+	c.hitMappers = make(map[string]application.APIHitMapper)
+	if cfg != nil {
+		c.federated = cfg.Federated
+		c.typeWeights = cfg.TypeWeights
+	}
+	if optionals != nil {
+		for _, mapper := range optionals.HitMappers {
+			c.hitMappers[mapper.DocumentType()] = mapper
+		}
+		c.queryHooks = optionals.QueryHooks
+	}
+}
+
+// SearchAction returns the results of all types or of the type given by the param "type".
+// The url parameters are interpreted like in the search page (query "q", pagination and filters), the query hooks may redirect or rewrite the query.
+// The param "federated" (true or false) overrides the configuration of the federated search of all types
+func (c *APIController) SearchAction(ctx context.Context, r *web.Request) web.Result {
+	originalQuery, _ := r.Query1("q")
+	params, err := application.RunQueryHooks(ctx, c.queryHooks, r.Request().URL.Path, r.QueryAll())
+	if err != nil {
+		return c.errorResponse(ctx, err)
+	}
+
+	federated := c.federated
+	if value := params.Get(federatedParameter); value != "" {
+		federated, _ = strconv.ParseBool(value)
+	}
+	params.Del(federatedParameter)
+
+	query := params.Get("q")
+	searchRequest := application.SearchRequest{
+		Query:        query,
+		FilterParams: params,
+	}
+
+	if documentType, ok := r.Params["type"]; ok {
+		result, err := c.searchService.FindBy(ctx, documentType, searchRequest)
+		if err != nil {
+			return c.errorResponse(ctx, err)
+		}
+		apiResult := c.apiResult(ctx, documentType, result)
+		setRewrittenQuery(apiResult, query, originalQuery)
+		return c.responder.Data(APISearchResult{Success: true, Result: apiResult})
+	}
+
+	if federated {
+		searchRequest.Federation = federationOptions(c.typeWeights)
+		federatedResult, err := c.searchService.FindFederated(ctx, searchRequest)
+		if err != nil {
+			return c.errorResponse(ctx, err)
+		}
+		apiResults := make(map[string]*APIResult, len(federatedResult.Results))
+		for documentType, result := range federatedResult.Results {
+			apiResults[documentType] = c.apiResult(ctx, documentType, result)
+			setRewrittenQuery(apiResults[documentType], query, originalQuery)
+		}
+		apiFederated := c.apiFederatedResult(ctx, federatedResult)
+		if query != originalQuery {
+			apiFederated.Meta.Query = query
+			apiFederated.Meta.OriginalQuery = originalQuery
+		}
+		return c.responder.Data(APISearchResult{Success: true, Results: apiResults, Federated: apiFederated})
+	}
+
+	results, err := c.searchService.Find(ctx, searchRequest)
+	if err != nil {
+		return c.errorResponse(ctx, err)
+	}
+	apiResults := make(map[string]*APIResult, len(results))
+	for documentType, result := range results {
+		apiResults[documentType] = c.apiResult(ctx, documentType, result)
+		setRewrittenQuery(apiResults[documentType], query, originalQuery)
+	}
+	return c.responder.Data(APISearchResult{Success: true, Results: apiResults})
+}
+
+// setRewrittenQuery shows the rewritten query and the query of the user in the meta data if a query hook rewrote the query
+func setRewrittenQuery(result *APIResult, query string, originalQuery string) {
+	if query == originalQuery {
+		return
+	}
+	result.Meta.Query = query
+	result.Meta.OriginalQuery = originalQuery
+}
+
+// errorResponse returns redirects as typed redirect response, unknown document types as 404
+func (c *APIController) errorResponse(ctx context.Context, err error) web.Result {
+	if redirect, ok := errors.Cause(err).(*domain.RedirectError); ok {
+		return c.responder.Data(APISearchResult{Redirect: &APIRedirect{To: redirect.To}})
+	}
+	if errors.Cause(err) == domain.ErrNotFound {
+		return c.responder.Data(APISearchResult{Error: &APIError{Message: err.Error(), Code: apiErrorNotFound}}).Status(404)
+	}
+
+	c.logger.WithContext(ctx).Error(err)
+	return c.responder.Data(APISearchResult{Error: &APIError{Message: err.Error(), Code: apiErrorGeneral}}).Status(500)
+}
+
+func (c *APIController) apiResult(ctx context.Context, documentType string, result *application.SearchResult) *APIResult {
+	apiResult := &APIResult{
+		Meta:        apiSearchMeta(result.SearchMeta),
+		Hits:        make([]interface{}, 0, len(result.Hits)),
+		Facets:      apiFacets(result.Facets),
+		SortOptions: make([]APISortOption, 0, len(result.SearchMeta.SortOptions)),
+		Pagination:  apiPagination(result.PaginationInfo),
+		Suggestions: result.Suggestions,
+	}
+	if apiResult.Suggestions == nil {
+		apiResult.Suggestions = []domain.Suggestion{}
+	}
+
+	for _, option := range result.SearchMeta.SortOptions {
+		apiResult.SortOptions = append(apiResult.SortOptions, APISortOption(option))
+	}
+
+	for _, hit := range result.Hits {
+		if mapped, ok := c.mapHit(ctx, documentType, hit); ok {
+			apiResult.Hits = append(apiResult.Hits, mapped)
+		}
+	}
+
+	return apiResult
+}
+
+func (c *APIController) apiFederatedResult(ctx context.Context, result *application.FederatedResult) *APIFederatedResult {
+	apiResult := &APIFederatedResult{
+		Meta:        apiSearchMeta(result.SearchMeta),
+		Hits:        make([]APIFederatedHit, 0, len(result.Hits)),
+		Facets:      apiFacets(result.Facets),
+		Pagination:  apiPagination(result.PaginationInfo),
+		Suggestions: result.Suggestions,
+	}
+	if apiResult.Suggestions == nil {
+		apiResult.Suggestions = []domain.Suggestion{}
+	}
+
+	for _, hit := range result.Hits {
+		if mapped, ok := c.mapHit(ctx, hit.Type, hit.Document); ok {
+			apiResult.Hits = append(apiResult.Hits, APIFederatedHit{Type: hit.Type, Score: hit.Score, Hit: mapped})
+		}
+	}
+
+	return apiResult
+}
+
+// mapHit maps the document with the mapper of its type - hits that can't be mapped are logged and left out
+func (c *APIController) mapHit(ctx context.Context, documentType string, document domain.Document) (interface{}, bool) {
+	mapper, hasMapper := c.hitMappers[documentType]
+	if !hasMapper {
+		return document, true
+	}
+	mapped, err := mapper.MapHit(ctx, document)
+	if err != nil {
+		c.logger.WithContext(ctx).Warn("search hit could not be mapped: ", err)
+		return nil, false
+	}
+	return mapped, true
+}
+
+func apiSearchMeta(meta domain.SearchMeta) APISearchMeta {
+	apiMeta := APISearchMeta{
+		Query:          meta.Query,
+		OriginalQuery:  meta.OriginalQuery,
+		Page:           meta.Page,
+		NumPages:       meta.NumPages,
+		NumResults:     meta.NumResults,
+		SelectedFacets: make([]APIFacet, 0, len(meta.SelectedFacets)),
+		NextCursor:     meta.NextCursor,
+		PreviousCursor: meta.PreviousCursor,
+	}
+	for _, facet := range meta.SelectedFacets {
+		apiMeta.SelectedFacets = append(apiMeta.SelectedFacets, apiFacet(facet))
+	}
+	return apiMeta
+}
+
+// apiFacets returns the facets in the order of their position
+func apiFacets(facets domain.FacetCollection) []APIFacet {
+	byName := make(map[string]domain.Facet, len(facets))
+	for _, facet := range facets {
+		byName[facet.Name] = facet
+	}
+
+	result := make([]APIFacet, 0, len(facets))
+	for _, name := range facets.Order() {
+		result = append(result, apiFacet(byName[name]))
+	}
+	return result
+}
+
+func apiFacet(facet domain.Facet) APIFacet {
+	return APIFacet{
+		Type:     facet.Type,
+		Name:     facet.Name,
+		Label:    facet.Label,
+		Position: facet.Position,
+		Items:    apiFacetItems(facet.Type, facet.Items),
+	}
+}
+
+func apiFacetItems(facetType string, items []*domain.FacetItem) []APIFacetItem {
+	result := make([]APIFacetItem, 0, len(items))
+	for _, item := range items {
+		apiItem := APIFacetItem{
+			Label:    item.Label,
+			Value:    item.Value,
+			Active:   item.Active,
+			Selected: item.Selected,
+			Count:    item.Count,
+		}
+		if len(item.Items) > 0 {
+			apiItem.Items = apiFacetItems(facetType, item.Items)
+		}
+		if facetType == domain.RangeFacet {
+			min, max, selectedMin, selectedMax := item.Min, item.Max, item.SelectedMin, item.SelectedMax
+			apiItem.Min, apiItem.Max, apiItem.SelectedMin, apiItem.SelectedMax = &min, &max, &selectedMin, &selectedMax
+		}
+		result = append(result, apiItem)
+	}
+	return result
+}
+
+func apiPagination(info utils.PaginationInfo) APIPagination {
+	pagination := APIPagination{
+		TotalHits: info.TotalHits,
+		Pages:     make([]APIPage, 0, len(info.PageNavigation)),
+	}
+	if info.NextPage.Page > 0 {
+		pagination.NextPage = &APIPage{Page: info.NextPage.Page, URL: info.NextPage.URL}
+	}
+	if info.PreviousPage.Page > 0 {
+		pagination.PreviousPage = &APIPage{Page: info.PreviousPage.Page, URL: info.PreviousPage.URL}
+	}
+	if info.LoadMore.Cursor != "" {
+		pagination.LoadMore = &APICursorPage{Cursor: info.LoadMore.Cursor, URL: info.LoadMore.URL}
+	}
+	if info.LoadPrevious.Cursor != "" {
+		pagination.LoadPrevious = &APICursorPage{Cursor: info.LoadPrevious.Cursor, URL: info.LoadPrevious.URL}
+	}
+	for _, page := range info.PageNavigation {
+		pagination.Pages = append(pagination.Pages, APIPage(page))
+	}
+	return pagination
+}
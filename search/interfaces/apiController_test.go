@@ -0,0 +1,271 @@
+package interfaces_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/application"
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/interfaces"
+	"flamingo.me/flamingo-commerce/v3/search/utils"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	searchServiceStub struct {
+		results map[string]domain.Result
+		err     error
+		filters []domain.Filter
+	}
+
+	upperCaseHitMapper struct{}
+
+	rewriteHook struct {
+		from, to string
+	}
+
+	redirectHook struct {
+		to string
+	}
+)
+
+func (s *searchServiceStub) Search(context.Context, ...domain.Filter) (map[string]domain.Result, error) {
+	return s.results, s.err
+}
+
+func (s *searchServiceStub) SearchFor(_ context.Context, typ string, filters ...domain.Filter) (*domain.Result, error) {
+	s.filters = filters
+	if s.err != nil {
+		return nil, s.err
+	}
+	result, ok := s.results[typ]
+	if !ok {
+		return nil, errors.Wrapf(domain.ErrNotFound, "type %q", typ)
+	}
+	return &result, nil
+}
+
+func (upperCaseHitMapper) DocumentType() string {
+	return "product"
+}
+
+func (upperCaseHitMapper) MapHit(_ context.Context, document domain.Document) (interface{}, error) {
+	if document == "invalid" {
+		return nil, errors.New("invalid hit")
+	}
+	return map[string]string{"code": document.(string)}, nil
+}
+
+func (h rewriteHook) Hook(_ context.Context, _ string, query *url.Values) error {
+	if query.Get("q") == h.from {
+		query.Set("q", h.to)
+	}
+	return nil
+}
+
+func (h redirectHook) Hook(context.Context, string, *url.Values) error {
+	return &domain.RedirectError{To: h.to}
+}
+
+func apiController(service domain.SearchService, hooks ...domain.RequestQueryHook) *interfaces.APIController {
+	controller := new(interfaces.APIController)
+	controller.Inject(new(web.Responder), &application.SearchService{
+		SearchService:         service,
+		PaginationInfoFactory: &utils.PaginationInfoFactory{DefaultConfig: &utils.PaginationConfig{ShowAroundActivePageAmount: 1}},
+		DefaultPageSize:       2,
+		Logger:                flamingo.NullLogger{},
+	}, flamingo.NullLogger{}, &struct {
+		Federated   bool       `inject:"config:commerce.search.federation.enabled,optional"`
+		TypeWeights config.Map `inject:"config:commerce.search.federation.typeWeights,optional"`
+	}{TypeWeights: config.Map{"page": 0.5}}, &struct {
+		HitMappers []application.APIHitMapper `inject:",optional"`
+		QueryHooks []domain.RequestQueryHook  `inject:",optional"`
+	}{HitMappers: []application.APIHitMapper{upperCaseHitMapper{}}, QueryHooks: hooks})
+	return controller
+}
+
+func searchRequest(path string, documentType string) (context.Context, *web.Request) {
+	httpRequest, _ := http.NewRequest(http.MethodGet, path, nil)
+	request := web.CreateRequest(httpRequest, nil)
+	if documentType != "" {
+		request.Params["type"] = documentType
+	}
+	return web.ContextWithRequest(context.Background(), request), request
+}
+
+func TestAPIController_SearchAction(t *testing.T) {
+	service := &searchServiceStub{results: map[string]domain.Result{
+		"product": {
+			SearchMeta: domain.SearchMeta{
+				Query:       "shirt",
+				Page:        1,
+				NumPages:    2,
+				NumResults:  3,
+				NextCursor:  "next",
+				SortOptions: []domain.SortOption{{Label: "price", Asc: "price", Desc: "price", SelectedAsc: true}},
+			},
+			Hits: []domain.Document{"shirt-1", "invalid", "shirt-2"},
+			Facets: domain.FacetCollection{
+				"price": {Type: domain.RangeFacet, Name: "price", Position: 2, Items: []*domain.FacetItem{{Min: 10, Max: 50, SelectedMin: 10, SelectedMax: 50}}},
+				"color": {Type: string(domain.ListFacet), Name: "color", Position: 1, Items: []*domain.FacetItem{{Label: "Red", Value: "red", Count: 2}}},
+			},
+		},
+		"page": {
+			SearchMeta: domain.SearchMeta{Page: 1, NumPages: 1, NumResults: 1},
+			Hits:       []domain.Document{map[string]string{"title": "Shirt guide"}},
+		},
+	}}
+	controller := apiController(service)
+
+	ctx, request := searchRequest("/api/search/product?q=shirt", "product")
+	response, ok := controller.SearchAction(ctx, request).(*web.DataResponse)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, uint(200), response.Response.Status)
+	data := response.Data.(interfaces.APISearchResult)
+	assert.True(t, data.Success)
+	if assert.NotNil(t, data.Result) {
+		result := data.Result
+		assert.Equal(t, "shirt", result.Meta.Query)
+		assert.Equal(t, 3, result.Meta.NumResults)
+		assert.Equal(t, []interface{}{map[string]string{"code": "shirt-1"}, map[string]string{"code": "shirt-2"}}, result.Hits, "the hits are mapped, invalid hits are skipped")
+		if assert.Len(t, result.Facets, 2) {
+			assert.Equal(t, "color", result.Facets[0].Name, "the facets are ordered by position")
+			assert.Nil(t, result.Facets[0].Items[0].Min)
+			assert.Equal(t, "price", result.Facets[1].Name)
+			assert.Equal(t, float64(50), *result.Facets[1].Items[0].Max)
+		}
+		assert.Equal(t, []interfaces.APISortOption{{Label: "price", Asc: "price", Desc: "price", SelectedAsc: true}}, result.SortOptions)
+		if assert.NotNil(t, result.Pagination.NextPage) {
+			assert.Equal(t, 2, result.Pagination.NextPage.Page)
+			assert.Contains(t, result.Pagination.NextPage.URL, "page=2")
+		}
+		assert.Nil(t, result.Pagination.PreviousPage)
+		assert.Equal(t, "next", result.Meta.NextCursor)
+		if assert.NotNil(t, result.Pagination.LoadMore) {
+			assert.Equal(t, "next", result.Pagination.LoadMore.Cursor)
+			assert.Equal(t, "?cursor=next&q=shirt", result.Pagination.LoadMore.URL)
+		}
+		assert.Nil(t, result.Pagination.LoadPrevious)
+		assert.NotEmpty(t, result.Pagination.Pages)
+		assert.Equal(t, []domain.Suggestion{}, result.Suggestions)
+	}
+
+	ctx, request = searchRequest("/api/search?q=shirt", "")
+	response = controller.SearchAction(ctx, request).(*web.DataResponse)
+	data = response.Data.(interfaces.APISearchResult)
+	assert.True(t, data.Success)
+	if assert.Len(t, data.Results, 2) {
+		assert.Equal(t, []interface{}{map[string]string{"title": "Shirt guide"}}, data.Results["page"].Hits, "hits without mapper are returned as they are")
+	}
+
+	ctx, request = searchRequest("/api/search/unknown", "unknown")
+	response = controller.SearchAction(ctx, request).(*web.DataResponse)
+	assert.Equal(t, uint(404), response.Response.Status)
+	assert.Equal(t, "search_not_found", response.Data.(interfaces.APISearchResult).Error.Code)
+
+	service.err = &domain.RedirectError{To: "/category/shirts"}
+	ctx, request = searchRequest("/api/search/product?q=shirts", "product")
+	response = controller.SearchAction(ctx, request).(*web.DataResponse)
+	assert.Equal(t, uint(200), response.Response.Status)
+	assert.Equal(t, interfaces.APISearchResult{Redirect: &interfaces.APIRedirect{To: "/category/shirts"}}, response.Data)
+
+	service.err = errors.New("backend down")
+	ctx, request = searchRequest("/api/search", "")
+	response = controller.SearchAction(ctx, request).(*web.DataResponse)
+	assert.Equal(t, uint(500), response.Response.Status)
+	assert.Equal(t, "search_error", response.Data.(interfaces.APISearchResult).Error.Code)
+}
+
+func TestAPIController_SearchActionFilters(t *testing.T) {
+	service := &searchServiceStub{results: map[string]domain.Result{"product": {}}}
+	controller := apiController(service)
+
+	ctx, request := searchRequest("/api/search/product?q=shirt&price=[10,50)&category:tree=clothing/shirts", "product")
+	controller.SearchAction(ctx, request)
+
+	assert.Contains(t, service.filters, domain.NewQueryFilter("shirt"))
+	assert.Contains(t, service.filters, domain.NewTreeFilter("category", "clothing", "shirts"))
+	var rangeFilter *domain.RangeFilter
+	for _, filter := range service.filters {
+		if f, ok := filter.(*domain.RangeFilter); ok {
+			rangeFilter = f
+		}
+	}
+	if assert.NotNil(t, rangeFilter, "the url parameters are parsed to typed filters") {
+		assert.Equal(t, "price", rangeFilter.Key())
+	}
+}
+
+func TestAPIController_SearchActionQueryHooks(t *testing.T) {
+	service := &searchServiceStub{results: map[string]domain.Result{
+		"product": {SearchMeta: domain.SearchMeta{Query: "shirt", Page: 1, NumPages: 1, NumResults: 1}, Hits: []domain.Document{"shirt-1"}},
+	}}
+
+	controller := apiController(service, rewriteHook{from: "tee", to: "shirt"})
+	ctx, request := searchRequest("/api/search/product?q=tee", "product")
+	data := controller.SearchAction(ctx, request).(*web.DataResponse).Data.(interfaces.APISearchResult)
+	if assert.NotNil(t, data.Result) {
+		assert.Equal(t, "shirt", data.Result.Meta.Query)
+		assert.Equal(t, "tee", data.Result.Meta.OriginalQuery)
+	}
+
+	controller = apiController(service, redirectHook{to: "/category/shirts"})
+	ctx, request = searchRequest("/api/search?q=tee", "")
+	response := controller.SearchAction(ctx, request).(*web.DataResponse)
+	assert.Equal(t, uint(200), response.Response.Status)
+	assert.Equal(t, interfaces.APISearchResult{Redirect: &interfaces.APIRedirect{To: "/category/shirts"}}, response.Data)
+}
+
+func TestAPIController_SearchActionFederated(t *testing.T) {
+	service := &searchServiceStub{results: map[string]domain.Result{
+		"product": {
+			SearchMeta: domain.SearchMeta{Query: "shirt", Page: 1, NumPages: 2, NumResults: 3},
+			Hits:       []domain.Document{"shirt-1", "shirt-2"},
+			Facets: domain.FacetCollection{
+				"color": {Type: string(domain.ListFacet), Name: "color", Items: []*domain.FacetItem{{Value: "red", Count: 2}}},
+			},
+		},
+		"page": {
+			SearchMeta: domain.SearchMeta{Query: "shirt", Page: 1, NumPages: 1, NumResults: 1},
+			Hits:       []domain.Document{"guide"},
+			Facets: domain.FacetCollection{
+				"color": {Type: string(domain.ListFacet), Name: "color", Items: []*domain.FacetItem{{Value: "red", Count: 1}}},
+			},
+		},
+	}}
+	controller := apiController(service)
+
+	ctx, request := searchRequest("/api/search?q=shirt&federated=true", "")
+	response := controller.SearchAction(ctx, request).(*web.DataResponse)
+	data := response.Data.(interfaces.APISearchResult)
+	assert.True(t, data.Success)
+	assert.Len(t, data.Results, 2)
+	if assert.NotNil(t, data.Federated) {
+		federated := data.Federated
+		assert.Equal(t, 4, federated.Meta.NumResults)
+		assert.Equal(t, 2, federated.Meta.NumPages)
+		if assert.Len(t, federated.Hits, 3) {
+			assert.Equal(t, "product", federated.Hits[0].Type)
+			assert.Equal(t, map[string]string{"code": "shirt-1"}, federated.Hits[0].Hit, "the hits are mapped with the mapper of their type")
+			assert.Equal(t, "product", federated.Hits[1].Type)
+			assert.Equal(t, interfaces.APIFederatedHit{Type: "page", Score: 0.5 / 11, Hit: "guide"}, federated.Hits[2], "the configured weight lowers the score")
+		}
+		if assert.Len(t, federated.Facets, 1) {
+			assert.Equal(t, int64(3), federated.Facets[0].Items[0].Count)
+		}
+		assert.Equal(t, []domain.Suggestion{}, federated.Suggestions)
+	}
+
+	ctx, request = searchRequest("/api/search?q=shirt", "")
+	response = controller.SearchAction(ctx, request).(*web.DataResponse)
+	assert.Nil(t, response.Data.(interfaces.APISearchResult).Federated, "the federated search is disabled by default")
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"net/url"
 
+	"github.com/pkg/errors"
+
 	"flamingo.me/flamingo-commerce/v3/search/application"
 	"flamingo.me/flamingo-commerce/v3/search/domain"
 	"flamingo.me/flamingo-commerce/v3/search/utils"
+	"flamingo.me/flamingo/v3/framework/config"
 	"flamingo.me/flamingo/v3/framework/web"
 )
 
@@ -16,29 +19,48 @@ type (
 		Responder             *web.Responder               `inject:""`
 		SearchService         *application.SearchService   `inject:""`
 		PaginationInfoFactory *utils.PaginationInfoFactory `inject:""`
+		QueryHooks            []domain.RequestQueryHook    `inject:",optional"`
+		Federated             bool                         `inject:"config:commerce.search.federation.enabled,optional"`
+		TypeWeights           config.Map                   `inject:"config:commerce.search.federation.typeWeights,optional"`
 	}
 
 	viewData struct {
 		SearchMeta     domain.SearchMeta
 		SearchResult   map[string]*application.SearchResult
 		PaginationInfo utils.PaginationInfo
+		// FederatedResult contains the hits of all types in one ranked list if the federated search is enabled
+		FederatedResult *application.FederatedResult
 	}
 )
 
-// Get Response for search
+// Get Response for search - the query hooks may redirect (temporary) or rewrite the query before searching
 func (vc *ViewController) Get(c context.Context, r *web.Request) web.Result {
-	query, _ := r.Query1("q")
+	originalQuery, _ := r.Query1("q")
+	params, err := application.RunQueryHooks(c, vc.QueryHooks, r.Request().URL.Path, r.QueryAll())
+	if err != nil {
+		if re, ok := errors.Cause(err).(*domain.RedirectError); ok {
+			u, parseErr := url.Parse(re.To)
+			if parseErr != nil {
+				return vc.Responder.ServerError(errors.Wrap(parseErr, "invalid redirect"))
+			}
+			return vc.Responder.URLRedirect(u)
+		}
+
+		return vc.Responder.ServerError(err)
+	}
+	query := params.Get("q")
 
 	vd := viewData{
 		SearchMeta: domain.SearchMeta{
-			Query: query,
+			Query:         query,
+			OriginalQuery: originalQuery,
 		},
 	}
 
 	searchRequest := application.SearchRequest{
-		Query: query,
+		Query:        query,
+		FilterParams: params,
 	}
-	searchRequest.AddAdditionalFilters(domain.NewKeyValueFilters(r.QueryAll())...)
 
 	if typ, ok := r.Params["type"]; ok {
 		searchResult, err := vc.SearchService.FindBy(c, typ, searchRequest)
@@ -52,6 +74,7 @@ func (vc *ViewController) Get(c context.Context, r *web.Request) web.Result {
 		}
 		vd.SearchMeta = searchResult.SearchMeta
 		vd.SearchMeta.Query = query
+		vd.SearchMeta.OriginalQuery = originalQuery
 		vd.SearchResult = map[string]*application.SearchResult{typ: searchResult}
 		vd.PaginationInfo = vc.PaginationInfoFactory.Build(
 			searchResult.SearchMeta.Page,
@@ -63,6 +86,26 @@ func (vc *ViewController) Get(c context.Context, r *web.Request) web.Result {
 		return vc.Responder.Render("search/"+typ, vd)
 	}
 
+	if vc.Federated {
+		searchRequest.Federation = federationOptions(vc.TypeWeights)
+		federatedResult, err := vc.SearchService.FindFederated(c, searchRequest)
+		if err != nil {
+			if re, ok := err.(*domain.RedirectError); ok {
+				u, _ := url.Parse(re.To)
+				return vc.Responder.URLRedirect(u).Permanent()
+			}
+
+			return vc.Responder.ServerError(err)
+		}
+		vd.SearchMeta = federatedResult.SearchMeta
+		vd.SearchMeta.Query = query
+		vd.SearchMeta.OriginalQuery = originalQuery
+		vd.SearchResult = federatedResult.Results
+		vd.PaginationInfo = federatedResult.PaginationInfo
+		vd.FederatedResult = federatedResult
+		return vc.Responder.Render("search/search", vd)
+	}
+
 	searchResult, err := vc.SearchService.Find(c, searchRequest)
 	if err != nil {
 		if re, ok := err.(*domain.RedirectError); ok {
@@ -75,3 +118,14 @@ func (vc *ViewController) Get(c context.Context, r *web.Request) web.Result {
 	vd.SearchResult = searchResult
 	return vc.Responder.Render("search/search", vd)
 }
+
+// federationOptions returns the federation options with the configured type weights, weights that are no numbers are ignored
+func federationOptions(typeWeights config.Map) *application.FederationOptions {
+	options := &application.FederationOptions{TypeWeights: make(map[string]float64, len(typeWeights))}
+	for documentType, value := range typeWeights {
+		if weight, ok := value.(float64); ok {
+			options.TypeWeights[documentType] = weight
+		}
+	}
+	return options
+}
@@ -0,0 +1,101 @@
+package interfaces
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// SuggestController returns the suggestions for a search box as json
+	SuggestController struct {
+		responder      *web.Responder
+		logger         flamingo.Logger
+		suggestService domain.SuggestService
+		defaultLimit   int
+		maxLimit       int
+		maxAge         int
+	}
+
+	// APISuggestResult is the json response of the suggest api
+	APISuggestResult struct {
+		Success     bool                  `json:"success"`
+		Error       *APIError             `json:"error,omitempty"`
+		Suggestions *domain.SuggestResult `json:"suggestions,omitempty"`
+	}
+)
+
+// defaultSuggestLimit is used if no commerce.search.suggest.defaultLimit is configured
+const defaultSuggestLimit = 5
+
+// Inject dependencies
+func (c *SuggestController) Inject(
+	responder *web.Responder,
+	logger flamingo.Logger,
+	config *struct {
+		DefaultLimit float64 `inject:"config:commerce.search.suggest.defaultLimit,optional"`
+		MaxLimit     float64 `inject:"config:commerce.search.suggest.maxLimit,optional"`
+		MaxAge       float64 `inject:"config:commerce.search.suggest.maxAge,optional"`
+	},
+	optionals *struct {
+		SuggestService domain.SuggestService `inject:",optional"`
+	},
+) {
+	c.responder = responder
+	c.logger = logger.WithField(flamingo.LogKeyModule, "search").WithField(flamingo.LogKeyCategory, "SuggestController")
+	c.defaultLimit = defaultSuggestLimit
+	if config != nil {
+		if config.DefaultLimit > 0 {
+			c.defaultLimit = int(config.DefaultLimit)
+		}
+		c.maxLimit = int(config.MaxLimit)
+		c.maxAge = int(config.MaxAge)
+	}
+	if optionals != nil {
+		c.suggestService = optionals.SuggestService
+	}
+}
+
+// SuggestAction returns the suggestions for the query parameter "q" - "limit" restricts the number of entries of each kind.
+// Successful responses may be cached for maxAge seconds, so that repeated requests of a debounced search box are answered by the browser or a proxy cache
+func (c *SuggestController) SuggestAction(ctx context.Context, r *web.Request) web.Result {
+	if c.suggestService == nil {
+		return c.responder.Data(APISuggestResult{
+			Error: &APIError{Message: "no suggest service available", Code: "suggest_not_available"},
+		}).Status(501).SetNoCache()
+	}
+
+	query, _ := r.Query1("q")
+	limit := c.defaultLimit
+	if value, err := r.Query1("limit"); err == nil {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return c.responder.Data(APISuggestResult{
+				Error: &APIError{Message: "limit needs to be a positive number", Code: "invalid_limit"},
+			}).Status(400).SetNoCache()
+		}
+		limit = parsed
+	}
+	if c.maxLimit > 0 && limit > c.maxLimit {
+		limit = c.maxLimit
+	}
+
+	result, err := c.suggestService.Suggest(ctx, strings.TrimLeft(query, " "), limit)
+	if err != nil {
+		c.logger.WithContext(ctx).Error(err)
+		return c.responder.Data(APISuggestResult{
+			Error: &APIError{Message: err.Error(), Code: "suggest_error"},
+		}).Status(500).SetNoCache()
+	}
+
+	response := c.responder.Data(APISuggestResult{Success: true, Suggestions: result})
+	if c.maxAge > 0 {
+		response.Header.Set("Cache-Control", "public, max-age="+strconv.Itoa(c.maxAge))
+		response.Header.Set("Vary", "Accept-Language")
+	}
+	return response
+}
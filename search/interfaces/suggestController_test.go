@@ -0,0 +1,70 @@
+package interfaces_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/interfaces"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type suggestServiceStub struct {
+	query string
+	limit int
+}
+
+func (s *suggestServiceStub) Suggest(_ context.Context, query string, limit int) (*domain.SuggestResult, error) {
+	s.query, s.limit = query, limit
+	return &domain.SuggestResult{Query: query}, nil
+}
+
+func suggestController(service domain.SuggestService) *interfaces.SuggestController {
+	controller := new(interfaces.SuggestController)
+	controller.Inject(new(web.Responder), flamingo.NullLogger{}, &struct {
+		DefaultLimit float64 `inject:"config:commerce.search.suggest.defaultLimit,optional"`
+		MaxLimit     float64 `inject:"config:commerce.search.suggest.maxLimit,optional"`
+		MaxAge       float64 `inject:"config:commerce.search.suggest.maxAge,optional"`
+	}{DefaultLimit: 5, MaxLimit: 10, MaxAge: 60}, &struct {
+		SuggestService domain.SuggestService `inject:",optional"`
+	}{SuggestService: service})
+	return controller
+}
+
+func suggestRequest(query string) *web.Request {
+	request, _ := http.NewRequest(http.MethodGet, "/api/suggest?"+query, nil)
+	return web.CreateRequest(request, nil)
+}
+
+func TestSuggestController_SuggestAction(t *testing.T) {
+	service := new(suggestServiceStub)
+	controller := suggestController(service)
+
+	result := controller.SuggestAction(context.Background(), suggestRequest("q=flam"))
+	if response, ok := result.(*web.DataResponse); assert.True(t, ok) {
+		assert.Equal(t, uint(200), response.Response.Status)
+		assert.Equal(t, "public, max-age=60", response.Header.Get("Cache-Control"))
+		assert.Equal(t, interfaces.APISuggestResult{Success: true, Suggestions: &domain.SuggestResult{Query: "flam"}}, response.Data)
+	}
+	assert.Equal(t, 5, service.limit, "the default limit is used")
+
+	controller.SuggestAction(context.Background(), suggestRequest("q=flam&limit=50"))
+	assert.Equal(t, 10, service.limit, "the limit is restricted to the max limit")
+
+	result = controller.SuggestAction(context.Background(), suggestRequest("q=flam&limit=abc"))
+	if response, ok := result.(*web.DataResponse); assert.True(t, ok) {
+		assert.Equal(t, uint(400), response.Response.Status)
+		assert.Equal(t, "invalid_limit", response.Data.(interfaces.APISuggestResult).Error.Code)
+		assert.Empty(t, response.Header.Get("Cache-Control"))
+	}
+
+	result = suggestController(nil).SuggestAction(context.Background(), suggestRequest("q=flam"))
+	if response, ok := result.(*web.DataResponse); assert.True(t, ok) {
+		assert.Equal(t, uint(501), response.Response.Status)
+		assert.Equal(t, "suggest_not_available", response.Data.(interfaces.APISuggestResult).Error.Code)
+	}
+}
@@ -1,29 +1,167 @@
 package search
 
 import (
-	"flamingo.me/flamingo-commerce/v3/search/interfaces"
 	"flamingo.me/dingo"
+	"flamingo.me/flamingo-commerce/v3/search/application"
+	"flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/analytics"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/cache"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/inmemory"
+	"flamingo.me/flamingo-commerce/v3/search/infrastructure/rules"
+	"flamingo.me/flamingo-commerce/v3/search/interfaces"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/flamingo/v3/framework/web"
 )
 
 // Module registers our search package
-type Module struct{}
+type Module struct {
+	useInMemoryService bool
+	useRules           bool
+	useCache           bool
+	useAnalytics       bool
+}
+
+// Inject dependencies
+func (m *Module) Inject(
+	config *struct {
+		UseInMemoryService bool `inject:"config:commerce.search.inmemory.enabled,optional"`
+		UseRules           bool `inject:"config:commerce.search.rules.enabled,optional"`
+		UseCache           bool `inject:"config:commerce.search.cache.enabled,optional"`
+		UseAnalytics       bool `inject:"config:commerce.search.analytics.enabled,optional"`
+	},
+) {
+	if config != nil {
+		m.useInMemoryService = config.UseInMemoryService
+		m.useRules = config.UseRules
+		m.useCache = config.UseCache
+		m.useAnalytics = config.UseAnalytics
+	}
+}
 
 // Configure the search URL
 func (m *Module) Configure(injector *dingo.Injector) {
+	if m.useInMemoryService {
+		injector.Bind(new(inmemory.DocumentIndex)).AsEagerSingleton()
+		injector.Bind((*domain.SearchService)(nil)).To(inmemory.SearchService{})
+		injector.Bind((*domain.SuggestService)(nil)).To(inmemory.SuggestService{}).AsEagerSingleton()
+	}
+
+	if m.useRules {
+		injector.BindMulti((*domain.RequestQueryHook)(nil)).To(rules.Engine{}).AsEagerSingleton()
+	}
+
+	if m.useCache {
+		injector.Bind(new(cache.SearchCache)).AsEagerSingleton()
+		injector.BindInterceptor((*domain.SearchService)(nil), cache.CachingSearchService{})
+	}
+
+	if m.useAnalytics {
+		injector.Bind((*domain.SearchAnalytics)(nil)).To(analytics.InMemoryAnalytics{}).AsEagerSingleton()
+		flamingo.BindEventSubscriber(injector).To(application.AnalyticsEventReceiver{})
+	}
+
 	web.BindRoutes(injector, new(routes))
 }
 
+// DefaultConfig for this module
+func (m *Module) DefaultConfig() config.Map {
+	return config.Map{
+		"commerce.search.inmemory": config.Map{
+			"enabled":         false,
+			"locale":          "en",
+			"defaultPageSize": float64(20),
+			"maxPageSize":     float64(100),
+			"boosts": config.Map{
+				"title":    float64(3),
+				"keywords": float64(2),
+				"code":     float64(2),
+			},
+			"facets": config.Slice{
+				config.Map{"name": "category", "label": "Category", "type": "TreeFacet", "position": float64(1)},
+				config.Map{"name": "price", "label": "Price", "type": "RangeFacet", "position": float64(2)},
+				config.Map{"name": "brandCode", "label": "Brand", "type": "ListFacet", "position": float64(3)},
+			},
+			"sortFields": config.Slice{"price", "title", "createdAt"},
+			"suggest": config.Map{
+				"completionFields": config.Slice{"title"},
+				"termFields":       config.Slice{"category", "brandCode"},
+			},
+		},
+		"commerce.search.suggest": config.Map{
+			"defaultLimit": float64(5),
+			"maxLimit":     float64(20),
+			"maxAge":       float64(60),
+		},
+		"commerce.search.rules": config.Map{
+			"enabled": false,
+		},
+		"commerce.search.cache": config.Map{
+			"enabled": false,
+			"size":    float64(1000),
+			"ttl":     "1m",
+		},
+		"commerce.search.analytics": config.Map{
+			"enabled":     false,
+			"maxQueries":  float64(10000),
+			"reportLimit": float64(20),
+			"reportRoute": false,
+		},
+		"commerce.search.facets": config.Map{
+			"default":    config.Map{},
+			"categories": config.Map{},
+		},
+		"commerce.search.federation": config.Map{
+			"enabled":     false,
+			"typeWeights": config.Map{},
+		},
+	}
+}
+
 type routes struct {
-	controller *interfaces.ViewController
+	controller          *interfaces.ViewController
+	apiController       *interfaces.APIController
+	suggestController   *interfaces.SuggestController
+	analyticsController *interfaces.AnalyticsController
+	useReportRoute      bool
 }
 
-func (r *routes) Inject(controller *interfaces.ViewController) {
+func (r *routes) Inject(
+	controller *interfaces.ViewController,
+	apiController *interfaces.APIController,
+	suggestController *interfaces.SuggestController,
+	analyticsController *interfaces.AnalyticsController,
+	config *struct {
+		UseReportRoute bool `inject:"config:commerce.search.analytics.reportRoute,optional"`
+	},
+) {
 	r.controller = controller
+	r.apiController = apiController
+	r.suggestController = suggestController
+	r.analyticsController = analyticsController
+	if config != nil {
+		r.useReportRoute = config.UseReportRoute
+	}
 }
 
 func (r *routes) Routes(registry *web.RouterRegistry) {
 	registry.HandleGet("search.search", r.controller.Get)
 	registry.Route("/search/:type", `search.search(type, *)`)
 	registry.Route("/search", `search.search`)
+
+	registry.HandleGet("search.api.search", r.apiController.SearchAction)
+	registry.Route("/api/search/:type", `search.api.search(type, *)`)
+	registry.Route("/api/search", `search.api.search`)
+
+	registry.HandleGet("search.suggest", r.suggestController.SuggestAction)
+	registry.Route("/api/suggest", `search.suggest`)
+
+	registry.HandlePost("search.api.click", r.analyticsController.ClickAction)
+	registry.Route("/api/search-analytics/click", `search.api.click`)
+
+	// the report contains the queries of all shoppers, it is only public if explicitly enabled
+	if r.useReportRoute {
+		registry.HandleGet("search.api.analytics", r.analyticsController.ReportAction)
+		registry.Route("/api/search-analytics", `search.api.analytics`)
+	}
 }
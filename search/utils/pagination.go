@@ -4,6 +4,8 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+
+	"flamingo.me/flamingo-commerce/v3/search/domain"
 )
 
 type (
@@ -15,20 +17,24 @@ type (
 		ShowAroundActivePageAmount float64 `inject:"config:pagination.showAroundActivePageAmount"`
 	}
 
-	// CurrentResultInfos page information
+	// CurrentResultInfos page information - the cursors are set if the search supports cursor pagination
 	CurrentResultInfos struct {
-		ActivePage int
-		TotalHits  int
-		PageSize   int
-		LastPage   int
+		ActivePage     int
+		TotalHits      int
+		PageSize       int
+		LastPage       int
+		NextCursor     string
+		PreviousCursor string
 	}
 
-	// PaginationInfo meta information
+	// PaginationInfo meta information - LoadMore and LoadPrevious are the cursor links for "load more" buttons and infinite scroll listings
 	PaginationInfo struct {
 		NextPage       Page
 		PreviousPage   Page
 		TotalHits      int
 		PageNavigation []Page
+		LoadMore       CursorPage
+		LoadPrevious   CursorPage
 	}
 
 	// Page page data
@@ -39,6 +45,12 @@ type (
 		IsSpacer bool
 	}
 
+	// CursorPage link to the results at the cursor - empty if there are no further results
+	CursorPage struct {
+		Cursor string
+		URL    string
+	}
+
 	// PaginationInfoFactory - used to build a configuration based on configured defaults
 	PaginationInfoFactory struct {
 		DefaultConfig *PaginationConfig `inject:""`
@@ -75,6 +87,18 @@ func BuildWith(currentResult CurrentResultInfos, paginationConfig PaginationConf
 			URL:  makeURL(urlBase, currentResult.ActivePage+1),
 		}
 	}
+	if currentResult.NextCursor != "" {
+		paginationInfo.LoadMore = CursorPage{
+			Cursor: currentResult.NextCursor,
+			URL:    makeCursorURL(urlBase, currentResult.NextCursor),
+		}
+	}
+	if currentResult.PreviousCursor != "" {
+		paginationInfo.LoadPrevious = CursorPage{
+			Cursor: currentResult.PreviousCursor,
+			URL:    makeCursorURL(urlBase, currentResult.PreviousCursor),
+		}
+	}
 
 	pagesToAdd = append(pagesToAdd, currentResult.ActivePage)
 	showAroundActivePageAmount := int(paginationConfig.ShowAroundActivePageAmount)
@@ -131,5 +155,14 @@ func (f *PaginationInfoFactory) Build(activePage int, totalHits int, pageSize in
 func makeURL(base *url.URL, page int) string {
 	q := base.Query()
 	q.Set("page", strconv.Itoa(page))
+	q.Del(domain.CursorParameter)
+	return (&url.URL{RawQuery: q.Encode()}).String()
+}
+
+// makeCursorURL replaces the page with the cursor
+func makeCursorURL(base *url.URL, cursor string) string {
+	q := base.Query()
+	q.Set(domain.CursorParameter, cursor)
+	q.Del("page")
 	return (&url.URL{RawQuery: q.Encode()}).String()
 }
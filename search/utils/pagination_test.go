@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWith_Cursor(t *testing.T) {
+	config := PaginationConfig{ShowAroundActivePageAmount: 1}
+
+	tests := []struct {
+		name             string
+		url              string
+		current          CurrentResultInfos
+		wantLoadMore     CursorPage
+		wantLoadPrevious CursorPage
+	}{
+		{
+			name:         "first page",
+			url:          "/search?q=shirt",
+			current:      CurrentResultInfos{ActivePage: 1, LastPage: 3, NextCursor: "c2"},
+			wantLoadMore: CursorPage{Cursor: "c2", URL: "?cursor=c2&q=shirt"},
+		},
+		{
+			name:             "middle page",
+			url:              "/search?q=shirt&cursor=c2",
+			current:          CurrentResultInfos{ActivePage: 2, LastPage: 3, NextCursor: "c3", PreviousCursor: "c1"},
+			wantLoadMore:     CursorPage{Cursor: "c3", URL: "?cursor=c3&q=shirt"},
+			wantLoadPrevious: CursorPage{Cursor: "c1", URL: "?cursor=c1&q=shirt"},
+		},
+		{
+			name:             "last page without next cursor",
+			url:              "/search?q=shirt&cursor=c3",
+			current:          CurrentResultInfos{ActivePage: 3, LastPage: 3, PreviousCursor: "c2"},
+			wantLoadPrevious: CursorPage{Cursor: "c2", URL: "?cursor=c2&q=shirt"},
+		},
+		{
+			name:             "page parameter is dropped from the cursor urls",
+			url:              "/search?q=shirt&page=2",
+			current:          CurrentResultInfos{ActivePage: 2, LastPage: 3, NextCursor: "c3", PreviousCursor: "c1"},
+			wantLoadMore:     CursorPage{Cursor: "c3", URL: "?cursor=c3&q=shirt"},
+			wantLoadPrevious: CursorPage{Cursor: "c1", URL: "?cursor=c1&q=shirt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, err := url.Parse(tt.url)
+			assert.NoError(t, err)
+
+			info := BuildWith(tt.current, config, base)
+			assert.Equal(t, tt.wantLoadMore, info.LoadMore)
+			assert.Equal(t, tt.wantLoadPrevious, info.LoadPrevious)
+		})
+	}
+}
+
+func TestBuildWith_PageURLsDropCursor(t *testing.T) {
+	base, err := url.Parse("/search?q=shirt&cursor=c2")
+	assert.NoError(t, err)
+
+	info := BuildWith(CurrentResultInfos{ActivePage: 2, LastPage: 3, NextCursor: "c3"}, PaginationConfig{ShowAroundActivePageAmount: 1}, base)
+	assert.Equal(t, Page{Page: 3, URL: "?page=3&q=shirt"}, info.NextPage)
+	assert.Equal(t, Page{Page: 1, URL: "?page=1&q=shirt"}, info.PreviousPage)
+	for _, page := range info.PageNavigation {
+		if !page.IsSpacer {
+			assert.NotContains(t, page.URL, "cursor")
+		}
+	}
+}
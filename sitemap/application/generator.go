@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+
+	"flamingo.me/flamingo-commerce/v3/internal/atomicfile"
+	"flamingo.me/flamingo-commerce/v3/sitemap/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// Generator builds the sitemap index and the sharded sitemap files from the bound entry providers
+	Generator struct {
+		providers      []domain.EntryProvider
+		logger         flamingo.Logger
+		maxURLsPerFile int
+		cacheTTL       time.Duration
+		mu             sync.Mutex
+		cache          *cachedSitemaps
+		loader         singleflight.Group
+	}
+
+	// Sitemaps contains the sitemap index and the sitemap files by file name
+	Sitemaps struct {
+		Index domain.Index
+		Files map[string]domain.URLSet
+	}
+
+	cachedSitemaps struct {
+		baseURL  string
+		sitemaps *Sitemaps
+		expires  time.Time
+	}
+)
+
+const (
+	// IndexFileName is the file name of the sitemap index
+	IndexFileName = "sitemap.xml"
+	// FilesPath is the path of the sitemap files relative to the base url
+	FilesPath = "sitemap"
+
+	// maxURLsPerFile is the limit of the sitemap protocol
+	maxURLsPerFile = 50000
+)
+
+// ErrFileNotFound is returned for unknown sitemap files
+var ErrFileNotFound = errors.New("sitemap file not found")
+
+// Inject dependencies
+func (g *Generator) Inject(
+	logger flamingo.Logger,
+	config *struct {
+		MaxURLsPerFile float64 `inject:"config:commerce.sitemap.maxUrlsPerFile,optional"`
+		CacheTTL       string  `inject:"config:commerce.sitemap.cacheTTL,optional"`
+	},
+	optionals *struct {
+		Providers []domain.EntryProvider `inject:",optional"`
+	},
+) {
+	g.logger = logger.WithField(flamingo.LogKeyModule, "sitemap").WithField(flamingo.LogKeyCategory, "application.Generator")
+	g.maxURLsPerFile = maxURLsPerFile
+	if config != nil {
+		if config.MaxURLsPerFile > 0 && int(config.MaxURLsPerFile) < maxURLsPerFile {
+			g.maxURLsPerFile = int(config.MaxURLsPerFile)
+		}
+		if ttl, err := time.ParseDuration(config.CacheTTL); err == nil {
+			g.cacheTTL = ttl
+		} else if config.CacheTTL != "" {
+			g.logger.Warn("invalid commerce.sitemap.cacheTTL: ", err)
+		}
+	}
+	if optionals != nil {
+		g.providers = optionals.Providers
+	}
+}
+
+// Generate builds the sitemaps for the given base url (e.g. "https://example.com")
+func (g *Generator) Generate(ctx context.Context, baseURL string) (*Sitemaps, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	sitemaps := &Sitemaps{
+		Index: domain.Index{Xmlns: domain.Namespace},
+		Files: make(map[string]domain.URLSet),
+	}
+
+	for _, provider := range g.providers {
+		entries, err := provider.Entries(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "sitemap provider %q", provider.Name())
+		}
+
+		for shard := 0; shard*g.maxURLsPerFile < len(entries); shard++ {
+			end := (shard + 1) * g.maxURLsPerFile
+			if end > len(entries) {
+				end = len(entries)
+			}
+
+			fileName := fmt.Sprintf("sitemap-%s-%d.xml", provider.Name(), shard+1)
+			urlSet := domain.URLSet{Xmlns: domain.Namespace, URLs: make([]domain.URL, 0, end-shard*g.maxURLsPerFile)}
+			var lastModified time.Time
+			for _, entry := range entries[shard*g.maxURLsPerFile : end] {
+				urlSet.URLs = append(urlSet.URLs, domain.URL{
+					Loc:     baseURL + "/" + strings.TrimLeft(entry.Path, "/"),
+					LastMod: domain.FormatLastMod(entry.LastModified),
+				})
+				if entry.LastModified.After(lastModified) {
+					lastModified = entry.LastModified
+				}
+			}
+
+			sitemaps.Files[fileName] = urlSet
+			sitemaps.Index.Sitemaps = append(sitemaps.Index.Sitemaps, domain.Sitemap{
+				Loc:     baseURL + "/" + FilesPath + "/" + fileName,
+				LastMod: domain.FormatLastMod(lastModified),
+			})
+		}
+	}
+
+	return sitemaps, nil
+}
+
+// Get returns the sitemaps for the base url - the generated sitemaps of the last base url are cached for the configured cacheTTL.
+// Concurrent calls result in one generation, the cache is not locked while generating
+func (g *Generator) Get(ctx context.Context, baseURL string) (*Sitemaps, error) {
+	if g.cacheTTL <= 0 {
+		return g.Generate(ctx, baseURL)
+	}
+
+	g.mu.Lock()
+	cached := g.cache
+	g.mu.Unlock()
+	if cached != nil && cached.baseURL == baseURL && time.Now().Before(cached.expires) {
+		return cached.sitemaps, nil
+	}
+
+	sitemaps, err, _ := g.loader.Do(baseURL, func() (interface{}, error) {
+		sitemaps, err := g.Generate(ctx, baseURL)
+		if err != nil {
+			return nil, err
+		}
+		g.mu.Lock()
+		g.cache = &cachedSitemaps{baseURL: baseURL, sitemaps: sitemaps, expires: time.Now().Add(g.cacheTTL)}
+		g.mu.Unlock()
+		return sitemaps, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sitemaps.(*Sitemaps), nil
+}
+
+// File returns the xml of a sitemap file - or of the index for IndexFileName
+func (s *Sitemaps) File(name string) ([]byte, error) {
+	if name == IndexFileName {
+		return domain.Marshal(s.Index)
+	}
+	urlSet, ok := s.Files[name]
+	if !ok {
+		return nil, errors.Wrap(ErrFileNotFound, name)
+	}
+	return domain.Marshal(urlSet)
+}
+
+// Write stores the sitemap index in the directory and the sitemap files in its FilesPath subdirectory.
+// Each file is replaced only after it has been written completely, the index is written last
+func (s *Sitemaps) Write(dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, FilesPath), 0755); err != nil {
+		return err
+	}
+
+	write := func(path string, name string) error {
+		body, err := s.File(name)
+		if err != nil {
+			return err
+		}
+		return atomicfile.WriteFile(path, body, 0644)
+	}
+
+	for name := range s.Files {
+		if err := write(filepath.Join(dir, FilesPath, name), name); err != nil {
+			return err
+		}
+	}
+	return write(filepath.Join(dir, IndexFileName), IndexFileName)
+}
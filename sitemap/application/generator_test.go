@@ -0,0 +1,147 @@
+package application_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/sitemap/application"
+	"flamingo.me/flamingo-commerce/v3/sitemap/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type providerStub struct {
+	name    string
+	entries []domain.Entry
+	calls   int
+}
+
+func (p *providerStub) Name() string {
+	return p.name
+}
+
+func (p *providerStub) Entries(context.Context) ([]domain.Entry, error) {
+	p.calls++
+	return p.entries, nil
+}
+
+type blockingProviderStub struct {
+	providerStub
+	release chan struct{}
+}
+
+func (p *blockingProviderStub) Entries(ctx context.Context) ([]domain.Entry, error) {
+	<-p.release
+	return p.providerStub.Entries(ctx)
+}
+
+func generator(maxURLsPerFile float64, cacheTTL string, providers ...domain.EntryProvider) *application.Generator {
+	g := new(application.Generator)
+	g.Inject(
+		flamingo.NullLogger{},
+		&struct {
+			MaxURLsPerFile float64 `inject:"config:commerce.sitemap.maxUrlsPerFile,optional"`
+			CacheTTL       string  `inject:"config:commerce.sitemap.cacheTTL,optional"`
+		}{MaxURLsPerFile: maxURLsPerFile, CacheTTL: cacheTTL},
+		&struct {
+			Providers []domain.EntryProvider `inject:",optional"`
+		}{Providers: providers},
+	)
+	return g
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	updated := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	products := &providerStub{name: "products"}
+	for i := 1; i <= 5; i++ {
+		products.entries = append(products.entries, domain.Entry{Path: fmt.Sprintf("/product/%d/p.html", i), LastModified: updated.AddDate(0, 0, -i)})
+	}
+	categories := &providerStub{name: "categories", entries: []domain.Entry{{Path: "category/shoes"}}}
+
+	sitemaps, err := generator(2, "", categories, products).Generate(context.Background(), "https://example.com/")
+	assert.NoError(t, err)
+
+	assert.Len(t, sitemaps.Files, 4)
+	assert.Equal(t, []domain.Sitemap{
+		{Loc: "https://example.com/sitemap/sitemap-categories-1.xml"},
+		{Loc: "https://example.com/sitemap/sitemap-products-1.xml", LastMod: "2019-04-30T12:00:00Z"},
+		{Loc: "https://example.com/sitemap/sitemap-products-2.xml", LastMod: "2019-04-28T12:00:00Z"},
+		{Loc: "https://example.com/sitemap/sitemap-products-3.xml", LastMod: "2019-04-26T12:00:00Z"},
+	}, sitemaps.Index.Sitemaps)
+	assert.Equal(t, []domain.URL{{Loc: "https://example.com/category/shoes"}}, sitemaps.Files["sitemap-categories-1.xml"].URLs)
+	assert.Equal(t, domain.URL{Loc: "https://example.com/product/5/p.html", LastMod: "2019-04-26T12:00:00Z"}, sitemaps.Files["sitemap-products-3.xml"].URLs[0])
+
+	index, err := sitemaps.File(application.IndexFileName)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(index), `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, string(index), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+
+	_, err = sitemaps.File("sitemap-unknown-1.xml")
+	assert.Equal(t, application.ErrFileNotFound, errors.Cause(err))
+}
+
+func TestGenerator_Get(t *testing.T) {
+	products := &providerStub{name: "products", entries: []domain.Entry{{Path: "/product/1/p.html"}}}
+
+	cached := generator(0, "1h", products)
+	_, _ = cached.Get(context.Background(), "https://example.com")
+	_, _ = cached.Get(context.Background(), "https://example.com")
+	assert.Equal(t, 1, products.calls)
+
+	_, _ = cached.Get(context.Background(), "https://other.example.com")
+	_, _ = cached.Get(context.Background(), "https://example.com")
+	assert.Equal(t, 3, products.calls, "only the sitemaps of the last base url are cached")
+
+	uncached := generator(0, "", products)
+	_, _ = uncached.Get(context.Background(), "https://example.com")
+	assert.Equal(t, 4, products.calls)
+}
+
+func TestGenerator_GetConcurrent(t *testing.T) {
+	release := make(chan struct{})
+	products := &blockingProviderStub{providerStub: providerStub{name: "products", entries: []domain.Entry{{Path: "/product/1/p.html"}}}, release: release}
+	cached := generator(0, "1h", products)
+
+	var wg sync.WaitGroup
+	results := make([]*application.Sitemaps, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = cached.Get(context.Background(), "https://example.com")
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, 1, products.calls, "concurrent calls result in one generation")
+	for _, result := range results {
+		assert.True(t, results[0] == result)
+	}
+}
+
+func TestSitemaps_Write(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sitemap")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	products := &providerStub{name: "products", entries: []domain.Entry{{Path: "/product/1/p.html"}}}
+	sitemaps, err := generator(0, "", products).Generate(context.Background(), "https://example.com")
+	assert.NoError(t, err)
+	assert.NoError(t, sitemaps.Write(dir))
+
+	assert.FileExists(t, filepath.Join(dir, application.IndexFileName))
+	content, err := ioutil.ReadFile(filepath.Join(dir, application.FilesPath, "sitemap-products-1.xml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "<loc>https://example.com/product/1/p.html</loc>")
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"encoding/xml"
+	"time"
+)
+
+// Namespace of the sitemap protocol
+const Namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type (
+	// EntryProvider is a secondary port that provides the pages of one kind (e.g. products) for the sitemap.
+	// Providers are multibound - each provider gets its own sitemap files
+	EntryProvider interface {
+		// Name is used in the file names of the sitemap files (e.g. "products")
+		Name() string
+		// Entries returns all pages that should be part of the sitemap
+		Entries(ctx context.Context) ([]Entry, error)
+	}
+
+	// Entry is a page of the sitemap
+	Entry struct {
+		// Path of the page (without scheme and host)
+		Path string
+		// LastModified is optional
+		LastModified time.Time
+	}
+
+	// URLSet is a sitemap file
+	URLSet struct {
+		XMLName xml.Name `xml:"urlset"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		URLs    []URL    `xml:"url"`
+	}
+
+	// URL is an url in a sitemap file
+	URL struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod,omitempty"`
+	}
+
+	// Index is the sitemap index that references the sitemap files
+	Index struct {
+		XMLName  xml.Name  `xml:"sitemapindex"`
+		Xmlns    string    `xml:"xmlns,attr"`
+		Sitemaps []Sitemap `xml:"sitemap"`
+	}
+
+	// Sitemap is a reference to a sitemap file
+	Sitemap struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod,omitempty"`
+	}
+)
+
+// FormatLastMod returns the W3C datetime format used for lastmod - or an empty string for zero times
+func FormatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Marshal returns the xml document
+func Marshal(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"context"
+
+	categoryApplication "flamingo.me/flamingo-commerce/v3/category/application"
+	categoryDomain "flamingo.me/flamingo-commerce/v3/category/domain"
+	"flamingo.me/flamingo-commerce/v3/sitemap/domain"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// CategoryProvider walks the category tree and provides the category pages
+	CategoryProvider struct {
+		categoryService categoryDomain.CategoryService
+		router          categoryApplication.RouterRouter
+	}
+)
+
+var _ domain.EntryProvider = new(CategoryProvider)
+
+// Inject dependencies
+func (p *CategoryProvider) Inject(categoryService categoryDomain.CategoryService, router categoryApplication.RouterRouter) {
+	p.categoryService = categoryService
+	p.router = router
+}
+
+// Name of the provider
+func (p *CategoryProvider) Name() string {
+	return "categories"
+}
+
+// Entries returns the pages of all categories below the root category (the root itself is not part of the sitemap)
+func (p *CategoryProvider) Entries(ctx context.Context) ([]domain.Entry, error) {
+	tree, err := p.categoryService.Tree(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []domain.Entry
+	var walk func(node categoryDomain.Tree)
+	walk = func(node categoryDomain.Tree) {
+		url, err := p.router.URL(categoryApplication.URLWithName(node.Code(), web.URLTitle(node.Name())))
+		if err == nil {
+			entries = append(entries, domain.Entry{Path: url.String()})
+		}
+		for _, subTree := range node.SubTrees() {
+			walk(subTree)
+		}
+	}
+	if tree != nil {
+		for _, subTree := range tree.SubTrees() {
+			walk(subTree)
+		}
+	}
+
+	return entries, nil
+}
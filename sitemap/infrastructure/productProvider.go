@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	"context"
+
+	productApplication "flamingo.me/flamingo-commerce/v3/product/application"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/sitemap/domain"
+)
+
+type (
+	// ProductProvider pages through the product search results and provides the product detail pages
+	ProductProvider struct {
+		searchService productDomain.SearchService
+		urlService    productURLService
+		pageSize      int
+	}
+
+	productURLService interface {
+		Get(product productDomain.BasicProduct, variantCode string) (string, error)
+	}
+)
+
+const defaultProductPageSize = 100
+
+var _ domain.EntryProvider = new(ProductProvider)
+
+// Inject dependencies
+func (p *ProductProvider) Inject(
+	searchService productDomain.SearchService,
+	urlService *productApplication.URLService,
+	config *struct {
+		PageSize float64 `inject:"config:commerce.sitemap.productPageSize,optional"`
+	},
+) {
+	p.searchService = searchService
+	p.urlService = urlService
+	p.pageSize = defaultProductPageSize
+	if config != nil && config.PageSize > 0 {
+		p.pageSize = int(config.PageSize)
+	}
+}
+
+// Name of the provider
+func (p *ProductProvider) Name() string {
+	return "products"
+}
+
+// Entries returns the product detail pages with the last update of the product
+func (p *ProductProvider) Entries(ctx context.Context) ([]domain.Entry, error) {
+	var entries []domain.Entry
+	seen := make(map[string]bool)
+
+	for page := 1; ; page++ {
+		result, err := p.searchService.Search(ctx, searchDomain.NewPaginationPageFilter(page), searchDomain.NewPaginationPageSizeFilter(p.pageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range result.Hits {
+			url, err := p.urlService.Get(product, "")
+			if err != nil || seen[url] {
+				continue
+			}
+			seen[url] = true
+			entries = append(entries, domain.Entry{Path: url, LastModified: product.BaseData().UpdatedAt})
+		}
+
+		if len(result.Hits) == 0 || page >= result.SearchMeta.NumPages {
+			return entries, nil
+		}
+	}
+}
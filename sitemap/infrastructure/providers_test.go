@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	categoryDomain "flamingo.me/flamingo-commerce/v3/category/domain"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
+	"flamingo.me/flamingo-commerce/v3/sitemap/domain"
+)
+
+type (
+	searchServiceStub struct {
+		products []productDomain.BasicProduct
+		pageSize int
+	}
+
+	urlServiceStub struct{}
+
+	categoryServiceStub struct {
+		tree categoryDomain.Tree
+	}
+
+	routerStub struct{}
+)
+
+func (s *searchServiceStub) Search(_ context.Context, filters ...searchDomain.Filter) (*productDomain.SearchResult, error) {
+	page := 1
+	for _, filter := range filters {
+		if pageFilter, ok := filter.(*searchDomain.PaginationPage); ok {
+			_, values := pageFilter.Value()
+			page, _ = strconv.Atoi(values[0])
+		}
+	}
+	start, end := (page-1)*s.pageSize, page*s.pageSize
+	if end > len(s.products) {
+		end = len(s.products)
+	}
+	result := &productDomain.SearchResult{Hits: s.products[start:end]}
+	result.SearchMeta.Page = page
+	result.SearchMeta.NumPages = (len(s.products) + s.pageSize - 1) / s.pageSize
+	return result, nil
+}
+
+func (s *searchServiceStub) SearchBy(ctx context.Context, _ string, _ []string, filters ...searchDomain.Filter) (*productDomain.SearchResult, error) {
+	return s.Search(ctx, filters...)
+}
+
+func (urlServiceStub) Get(product productDomain.BasicProduct, _ string) (string, error) {
+	return "/product/" + product.BaseData().MarketPlaceCode + ".html", nil
+}
+
+func (c *categoryServiceStub) Tree(context.Context, string) (categoryDomain.Tree, error) {
+	return c.tree, nil
+}
+
+func (c *categoryServiceStub) Get(context.Context, string) (categoryDomain.Category, error) {
+	return nil, categoryDomain.ErrNotFound
+}
+
+func (routerStub) URL(name string, params map[string]string) (*url.URL, error) {
+	return &url.URL{Path: "/category/" + params["code"] + "/" + params["name"] + ".html"}, nil
+}
+
+func TestProductProvider_Entries(t *testing.T) {
+	updated := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	searchService := &searchServiceStub{pageSize: 2}
+	for _, code := range []string{"a", "b", "c", "a"} {
+		searchService.products = append(searchService.products, productDomain.SimpleProduct{
+			BasicProductData: productDomain.BasicProductData{MarketPlaceCode: code, UpdatedAt: updated},
+		})
+	}
+
+	provider := &ProductProvider{searchService: searchService, urlService: urlServiceStub{}, pageSize: 2}
+	entries, err := provider.Entries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Entry{
+		{Path: "/product/a.html", LastModified: updated},
+		{Path: "/product/b.html", LastModified: updated},
+		{Path: "/product/c.html", LastModified: updated},
+	}, entries)
+}
+
+func TestCategoryProvider_Entries(t *testing.T) {
+	tree := &categoryDomain.TreeData{
+		CategoryCode: "root",
+		SubTreesData: []*categoryDomain.TreeData{
+			{CategoryCode: "shoes", CategoryName: "shoes", SubTreesData: []*categoryDomain.TreeData{{CategoryCode: "boots", CategoryName: "boots"}}},
+			{CategoryCode: "shirts", CategoryName: "shirts"},
+		},
+	}
+
+	provider := new(CategoryProvider)
+	provider.Inject(&categoryServiceStub{tree: tree}, routerStub{})
+	entries, err := provider.Entries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Entry{
+		{Path: "/category/shoes/shoes.html"},
+		{Path: "/category/boots/boots.html"},
+		{Path: "/category/shirts/shirts.html"},
+	}, entries)
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"flamingo.me/flamingo-commerce/v3/sitemap/application"
+)
+
+type (
+	// Generate is the command that pre-generates the sitemaps to disk
+	Generate struct {
+		generator *application.Generator
+		baseURL   string
+		outputDir string
+	}
+)
+
+// Inject dependencies
+func (g *Generate) Inject(
+	generator *application.Generator,
+	config *struct {
+		BaseURL   string `inject:"config:commerce.sitemap.baseUrl,optional"`
+		OutputDir string `inject:"config:commerce.sitemap.outputDir,optional"`
+	},
+) {
+	g.generator = generator
+	if config != nil {
+		g.baseURL = config.BaseURL
+		g.outputDir = config.OutputDir
+	}
+}
+
+// Command returns the cobra command "sitemap"
+func (g *Generate) Command() *cobra.Command {
+	baseURL, outputDir := g.baseURL, g.outputDir
+	command := &cobra.Command{
+		Use:   "sitemap",
+		Short: "Generate the sitemap index and the sitemap files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if baseURL == "" {
+				return errors.New("a base url is required (commerce.sitemap.baseUrl or --baseurl)")
+			}
+			sitemaps, err := g.generator.Generate(context.Background(), baseURL)
+			if err != nil {
+				return err
+			}
+			if err := sitemaps.Write(outputDir); err != nil {
+				return err
+			}
+			cmd.Printf("%d sitemap files written to %s\n", len(sitemaps.Files), outputDir)
+			return nil
+		},
+	}
+	command.Flags().StringVar(&baseURL, "baseurl", baseURL, "base url of the shop, e.g. https://example.com")
+	command.Flags().StringVarP(&outputDir, "output", "o", outputDir, "output directory")
+	return command
+}
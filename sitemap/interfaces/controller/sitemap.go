@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/sitemap/application"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// Controller serves the sitemap index and the sitemap files
+	Controller struct {
+		responder    *web.Responder
+		router       *web.Router
+		generator    *application.Generator
+		logger       flamingo.Logger
+		baseURL      string
+		pregenerated bool
+		outputDir    string
+	}
+)
+
+// Inject dependencies
+func (c *Controller) Inject(
+	responder *web.Responder,
+	router *web.Router,
+	generator *application.Generator,
+	logger flamingo.Logger,
+	config *struct {
+		BaseURL      string `inject:"config:commerce.sitemap.baseUrl,optional"`
+		Pregenerated bool   `inject:"config:commerce.sitemap.pregenerated,optional"`
+		OutputDir    string `inject:"config:commerce.sitemap.outputDir,optional"`
+	},
+) {
+	c.responder = responder
+	c.router = router
+	c.generator = generator
+	c.logger = logger.WithField(flamingo.LogKeyModule, "sitemap").WithField(flamingo.LogKeyCategory, "controller.Controller")
+	if config != nil {
+		c.baseURL = config.BaseURL
+		c.pregenerated = config.Pregenerated
+		c.outputDir = config.OutputDir
+	}
+}
+
+// Index serves the sitemap index
+func (c *Controller) Index(ctx context.Context, r *web.Request) web.Result {
+	return c.serve(ctx, application.IndexFileName, filepath.Join(c.outputDir, application.IndexFileName))
+}
+
+// File serves a sitemap file
+func (c *Controller) File(ctx context.Context, r *web.Request) web.Result {
+	name := r.Params["name"]
+	if name == "" || filepath.Base(name) != name || !strings.HasSuffix(name, ".xml") || name == application.IndexFileName {
+		return c.responder.NotFound(errors.Wrap(application.ErrFileNotFound, name))
+	}
+	return c.serve(ctx, name, filepath.Join(c.outputDir, application.FilesPath, name))
+}
+
+func (c *Controller) serve(ctx context.Context, name string, path string) web.Result {
+	var body []byte
+	var err error
+	if c.pregenerated {
+		body, err = ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return c.responder.NotFound(errors.Wrap(application.ErrFileNotFound, name))
+		}
+	} else {
+		var baseURL string
+		var sitemaps *application.Sitemaps
+		baseURL, err = c.getBaseURL()
+		if err == nil {
+			sitemaps, err = c.generator.Get(ctx, baseURL)
+		}
+		if err == nil {
+			body, err = sitemaps.File(name)
+		}
+		if errors.Cause(err) == application.ErrFileNotFound {
+			return c.responder.NotFound(err)
+		}
+	}
+	if err != nil {
+		c.logger.WithContext(ctx).Error(err)
+		return c.responder.ServerError(err)
+	}
+
+	response := c.responder.HTTP(200, bytes.NewReader(body))
+	response.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	return response
+}
+
+// getBaseURL returns the configured base url or the base of the router (flamingo.router.scheme and flamingo.router.host).
+// The request host is not used, so that foreign hosts cannot end up in the sitemaps
+func (c *Controller) getBaseURL() (string, error) {
+	if c.baseURL != "" {
+		return c.baseURL, nil
+	}
+	if c.router != nil {
+		if base := c.router.Base(); base != nil && base.Host != "" {
+			scheme := base.Scheme
+			if scheme == "" {
+				scheme = "http"
+			}
+			return scheme + "://" + base.Host + strings.TrimRight(base.Path, "/"), nil
+		}
+	}
+	return "", errors.New("a base url is required (commerce.sitemap.baseUrl or flamingo.router.host)")
+}
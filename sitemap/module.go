@@ -0,0 +1,96 @@
+package sitemap
+
+import (
+	"flamingo.me/dingo"
+	"github.com/spf13/cobra"
+
+	"flamingo.me/flamingo-commerce/v3/category"
+	"flamingo.me/flamingo-commerce/v3/product"
+	"flamingo.me/flamingo-commerce/v3/sitemap/application"
+	"flamingo.me/flamingo-commerce/v3/sitemap/domain"
+	"flamingo.me/flamingo-commerce/v3/sitemap/infrastructure"
+	"flamingo.me/flamingo-commerce/v3/sitemap/interfaces/commands"
+	"flamingo.me/flamingo-commerce/v3/sitemap/interfaces/controller"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+// Module for sitemaps
+type Module struct {
+	useProducts   bool
+	useCategories bool
+}
+
+// Inject dependencies
+func (m *Module) Inject(
+	config *struct {
+		UseProducts   bool `inject:"config:commerce.sitemap.products.enabled,optional"`
+		UseCategories bool `inject:"config:commerce.sitemap.categories.enabled,optional"`
+	},
+) {
+	if config != nil {
+		m.useProducts = config.UseProducts
+		m.useCategories = config.UseCategories
+	}
+}
+
+// Configure module
+func (m *Module) Configure(injector *dingo.Injector) {
+	if m.useCategories {
+		injector.BindMulti((*domain.EntryProvider)(nil)).To(infrastructure.CategoryProvider{})
+	}
+	if m.useProducts {
+		injector.BindMulti((*domain.EntryProvider)(nil)).To(infrastructure.ProductProvider{})
+	}
+	injector.Bind(new(application.Generator)).AsEagerSingleton()
+
+	injector.BindMulti(new(cobra.Command)).ToProvider(func(generate *commands.Generate) *cobra.Command {
+		return generate.Command()
+	})
+	web.BindRoutes(injector, new(routes))
+}
+
+// DefaultConfig for this module
+func (m *Module) DefaultConfig() config.Map {
+	return config.Map{
+		"commerce.sitemap": config.Map{
+			"baseUrl":         "",
+			"maxUrlsPerFile":  float64(50000),
+			"productPageSize": float64(100),
+			"cacheTTL":        "1h",
+			"pregenerated":    false,
+			"outputDir":       "sitemap",
+			"products": config.Map{
+				"enabled": true,
+			},
+			"categories": config.Map{
+				"enabled": true,
+			},
+		},
+	}
+}
+
+// Depends on other modules
+func (m *Module) Depends() []dingo.Module {
+	return []dingo.Module{
+		new(product.Module),
+		new(category.Module),
+	}
+}
+
+type routes struct {
+	controller *controller.Controller
+}
+
+// Inject required dependencies
+func (r *routes) Inject(controller *controller.Controller) {
+	r.controller = controller
+}
+
+// Routes of the sitemap module
+func (r *routes) Routes(registry *web.RouterRegistry) {
+	registry.HandleGet("sitemap.index", r.controller.Index)
+	registry.Route("/sitemap.xml", "sitemap.index")
+	registry.HandleGet("sitemap.file", r.controller.File)
+	registry.Route("/sitemap/:name", "sitemap.file(name)")
+}
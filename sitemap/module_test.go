@@ -0,0 +1,14 @@
+package sitemap_test
+
+import (
+	"testing"
+
+	"flamingo.me/dingo"
+	"flamingo.me/flamingo-commerce/v3/sitemap"
+)
+
+func TestModule_Configure(t *testing.T) {
+	if err := dingo.TryModule(new(sitemap.Module)); err != nil {
+		t.Error(err)
+	}
+}
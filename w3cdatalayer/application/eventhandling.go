@@ -7,6 +7,7 @@ import (
 
 	"flamingo.me/flamingo/v3/framework/web"
 
+	searchDomain "flamingo.me/flamingo-commerce/v3/search/domain"
 	"flamingo.me/flamingo-commerce/v3/w3cdatalayer/domain"
 	authDomain "flamingo.me/flamingo/v3/core/oauth/domain"
 	"flamingo.me/flamingo/v3/framework/flamingo"
@@ -65,6 +66,43 @@ func (e *EventReceiver) Notify(ctx context.Context, event flamingo.Event) {
 			)
 
 		}
+	case *searchDomain.SearchPerformedEvent:
+		e.logger.WithContext(ctx).WithField("category", "w3cDatalayer").Debug("Receive Event SearchPerformedEvent")
+		request := web.RequestFromContext(ctx)
+		if request != nil {
+			searchInfo := domain.SearchInfo{
+				SearchKeyword: currentEvent.Query,
+				Result: domain.SearchResultInfo{
+					NumResults:   currentEvent.NumResults,
+					Page:         currentEvent.Page,
+					DocumentType: currentEvent.DocumentType,
+					Filters:      currentEvent.Filters,
+				},
+			}
+			request.Values.Store(SearchInfoReqKey, searchInfo)
+			// update the datalayer if it has already been built in this request
+			if layer, ok := request.Values.Load(DatalayerReqKey); ok {
+				if layer, ok := layer.(domain.Datalayer); ok && layer.Page != nil {
+					layer.Page.Search = searchInfo
+					request.Values.Store(DatalayerReqKey, layer)
+				}
+			}
+		}
+	case *searchDomain.SearchResultClickedEvent:
+		e.logger.WithContext(ctx).WithField("category", "w3cDatalayer").Debug("Receive Event SearchResultClickedEvent")
+		session := web.SessionFromContext(ctx)
+		if session != nil {
+			dataLayerEvent := domain.Event{EventInfo: make(map[string]interface{})}
+			dataLayerEvent.EventInfo["eventName"] = "searchResultClick"
+			dataLayerEvent.EventInfo["searchKeyword"] = currentEvent.Query
+			dataLayerEvent.EventInfo["documentType"] = currentEvent.DocumentType
+			dataLayerEvent.EventInfo["documentId"] = currentEvent.DocumentID
+			dataLayerEvent.EventInfo["position"] = currentEvent.Position
+			session.AddFlash(
+				dataLayerEvent,
+				SessionEventsKey,
+			)
+		}
 	case *authDomain.LoginEvent:
 		e.logger.WithContext(ctx).WithField("category", "w3cDatalayer").Debug("Receive Event LoginEvent")
 		session := web.SessionFromContext(ctx)
@@ -100,6 +100,13 @@ func (s Factory) BuildForCurrentRequest(ctx context.Context, request *web.Reques
 
 	layer.Page.Attributes["currency"] = s.defaultCurrency
 
+	// the search info of a search performed in this request (see EventReceiver)
+	if searchInfo, ok := request.Values.Load(SearchInfoReqKey); ok {
+		if searchInfo, ok := searchInfo.(domain.SearchInfo); ok {
+			layer.Page.Search = searchInfo
+		}
+	}
+
 	// Use the handler name as PageId if available
 	if controllerHandler, ok := tag.FromContext(ctx).Value(web.ControllerKey); ok {
 		layer.Page.PageInfo.PageID = controllerHandler
@@ -230,6 +237,16 @@ func (s Factory) buildCartItem(item decorator.DecoratedCartItem, currencyCode st
 	cartItem.Attributes["sourceId"] = item.Item.SourceID
 	cartItem.Attributes["terminal"] = ""
 	cartItem.Attributes["leadtime"] = ""
+
+	for _, bundleItem := range item.Item.BundleItems {
+		cartItem.BundleItems = append(cartItem.BundleItems, domain.CartItemBundleItem{
+			OptionCode:  bundleItem.OptionCode,
+			ProductID:   bundleItem.MarketplaceCode,
+			ProductName: s.regex.ReplaceAllString(bundleItem.ProductName, "-"),
+			Quantity:    bundleItem.TotalQty(item.Item),
+			Price:       bundleItem.SinglePrice.FloatAmount(),
+		})
+	}
 	return cartItem
 }
 
@@ -336,6 +353,14 @@ func (s Factory) getProductInfo(product productDomain.BasicProduct) domain.Produ
 			}
 		}
 	}
+	inStock := baseData.IsInStock()
+	if bundle, ok := product.(productDomain.BundleProductWithActiveChoices); ok {
+		inStock = bundle.IsInStock()
+	}
+	if bundle, ok := product.(productDomain.BundleProduct); ok {
+		inStock = bundle.IsInStock()
+	}
+
 	// Search for some common product attributes to fill the productInfos (This maybe better to be configurable later)
 	color := ""
 	if baseData.HasAttribute("manufacturerColor") {
@@ -379,7 +404,7 @@ func (s Factory) getProductInfo(product productDomain.BasicProduct) domain.Produ
 		Manufacturer:             brand,
 		Color:                    color,
 		Size:                     size,
-		InStock:                  strconv.FormatBool(baseData.IsInStock()),
+		InStock:                  strconv.FormatBool(inStock),
 	}
 }
 
@@ -31,6 +31,7 @@ type (
 const (
 	SessionEventsKey = "w3cdatalayer_events"
 	DatalayerReqKey  = "w3cDatalayer"
+	SearchInfoReqKey = "w3cDatalayerSearch"
 )
 
 // Inject method
@@ -54,6 +54,14 @@ type (
 		Result        interface{} `json:"result,omitempty"`
 	}
 
+	// SearchResultInfo is the search result of the SearchInfo - set for searches of the search module
+	SearchResultInfo struct {
+		NumResults   int                 `json:"numResults"`
+		Page         int                 `json:"page"`
+		DocumentType string              `json:"documentType,omitempty"`
+		Filters      map[string][]string `json:"filters,omitempty"`
+	}
+
 	// PageInfo generall information about the page
 	PageInfo struct {
 		PageID         string `json:"pageID,omitempty"`
@@ -153,6 +161,16 @@ type (
 		Category    *ProductCategory       `json:"category,omitempty"`
 		Price       CartItemPrice          `json:"price"`
 		Attributes  map[string]interface{} `json:"attributes,omitempty"`
+		BundleItems []CartItemBundleItem   `json:"bundleItems,omitempty"`
+	}
+
+	// CartItemBundleItem struct - a component of a bundle cart item
+	CartItemBundleItem struct {
+		OptionCode  string  `json:"optionCode"`
+		ProductID   string  `json:"productID"`
+		ProductName string  `json:"productName"`
+		Quantity    int     `json:"quantity"`
+		Price       float64 `json:"price"`
 	}
 
 	// CartItemPrice struct
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+
+	authDomain "flamingo.me/flamingo/v3/core/oauth/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// EventReceiver merges the guest wishlist into the customer wishlist on login
+	EventReceiver struct {
+		wishlistService *WishlistService
+		logger          flamingo.Logger
+	}
+)
+
+// Inject dependencies
+func (e *EventReceiver) Inject(wishlistService *WishlistService, logger flamingo.Logger) {
+	e.wishlistService = wishlistService
+	e.logger = logger.WithField(flamingo.LogKeyModule, "wishlist").WithField(flamingo.LogKeyCategory, "application.EventReceiver")
+}
+
+// Notify should get called by flamingo Eventlogic
+func (e *EventReceiver) Notify(ctx context.Context, event flamingo.Event) {
+	switch currentEvent := event.(type) {
+	case *authDomain.LoginEvent:
+		if currentEvent == nil || currentEvent.Session == nil {
+			return
+		}
+		if err := e.wishlistService.MergeGuestWishlist(ctx, currentEvent.Session); err != nil {
+			e.logger.WithContext(ctx).Error("guest wishlist could not be merged: ", err)
+		}
+	}
+}
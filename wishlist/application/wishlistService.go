@@ -0,0 +1,275 @@
+package application
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	cartApplication "flamingo.me/flamingo-commerce/v3/cart/application"
+	cartDomain "flamingo.me/flamingo-commerce/v3/cart/domain/cart"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	authApplication "flamingo.me/flamingo/v3/core/oauth/application"
+	authDomain "flamingo.me/flamingo/v3/core/oauth/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// WishlistService provides the wishlist of the guest (stored with the id in the session) or of the logged in customer
+	WishlistService struct {
+		guestWishlistService    domain.GuestWishlistService
+		customerWishlistService domain.CustomerWishlistService
+		decoratedFactory        *domain.DecoratedWishlistFactory
+		productService          productDomain.ProductService
+		authenticator           authenticator
+		userService             authApplication.UserServiceInterface
+		cartService             cartService
+		cartReceiver            cartReceiver
+		logger                  flamingo.Logger
+	}
+
+	authenticator interface {
+		Auth(ctx context.Context, session *web.Session) (authDomain.Auth, error)
+	}
+
+	cartService interface {
+		AddProduct(ctx context.Context, session *web.Session, deliveryCode string, addRequest cartDomain.AddRequest) (productDomain.BasicProduct, error)
+		DeleteItem(ctx context.Context, session *web.Session, itemID string, deliveryCode string) error
+	}
+
+	cartReceiver interface {
+		ViewCart(ctx context.Context, session *web.Session) (*cartDomain.Cart, error)
+	}
+)
+
+const (
+	// GuestWishlistSessionKey is the session key of the guest wishlist id
+	GuestWishlistSessionKey = "wishlist.guestid"
+)
+
+// Inject dependencies
+func (s *WishlistService) Inject(
+	guestWishlistService domain.GuestWishlistService,
+	customerWishlistService domain.CustomerWishlistService,
+	decoratedFactory *domain.DecoratedWishlistFactory,
+	productService productDomain.ProductService,
+	authManager *authApplication.AuthManager,
+	userService authApplication.UserServiceInterface,
+	cartService *cartApplication.CartService,
+	cartReceiverService *cartApplication.CartReceiverService,
+	logger flamingo.Logger,
+) {
+	s.guestWishlistService = guestWishlistService
+	s.customerWishlistService = customerWishlistService
+	s.decoratedFactory = decoratedFactory
+	s.productService = productService
+	s.authenticator = authManager
+	s.userService = userService
+	s.cartService = cartService
+	s.cartReceiver = cartReceiverService
+	s.logger = logger.WithField(flamingo.LogKeyModule, "wishlist").WithField(flamingo.LogKeyCategory, "application.WishlistService")
+}
+
+// GetWishlist returns the wishlist of the customer or guest - an empty wishlist if the guest has none
+func (s *WishlistService) GetWishlist(ctx context.Context, session *web.Session) (*domain.Wishlist, error) {
+	if s.userService.IsLoggedIn(ctx, session) {
+		auth, err := s.authenticator.Auth(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		return s.customerWishlistService.GetWishlist(ctx, auth)
+	}
+
+	wishlist, err := s.guestWishlist(ctx, session)
+	if errors.Cause(err) == domain.ErrWishlistNotFound {
+		return &domain.Wishlist{}, nil
+	}
+	return wishlist, err
+}
+
+// GetDecoratedWishlist returns the wishlist with the products of the items
+func (s *WishlistService) GetDecoratedWishlist(ctx context.Context, session *web.Session) (*domain.DecoratedWishlist, error) {
+	wishlist, err := s.GetWishlist(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return s.decoratedFactory.Create(ctx, *wishlist), nil
+}
+
+// AddProduct adds the product to the wishlist - a guest wishlist is created if required
+func (s *WishlistService) AddProduct(ctx context.Context, session *web.Session, addRequest domain.AddRequest) (*domain.Wishlist, error) {
+	product, err := s.productService.Get(ctx, addRequest.MarketplaceCode)
+	if err != nil {
+		return nil, err
+	}
+	if addRequest.VariantMarketplaceCode != "" {
+		configurable, ok := product.(productDomain.ConfigurableProduct)
+		if !ok || !configurable.HasVariant(addRequest.VariantMarketplaceCode) {
+			return nil, errors.Wrapf(productDomain.ProductNotFound{MarketplaceCode: addRequest.VariantMarketplaceCode}, "product %q has no variant %q", addRequest.MarketplaceCode, addRequest.VariantMarketplaceCode)
+		}
+	}
+
+	if s.userService.IsLoggedIn(ctx, session) {
+		auth, err := s.authenticator.Auth(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		return s.customerWishlistService.AddItem(ctx, auth, addRequest)
+	}
+
+	wishlist, err := s.guestWishlist(ctx, session)
+	if errors.Cause(err) == domain.ErrWishlistNotFound {
+		wishlist, err = s.guestWishlistService.GetNewWishlist(ctx)
+		if err == nil {
+			session.Store(GuestWishlistSessionKey, wishlist.ID)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.guestWishlistService.AddItem(ctx, wishlist.ID, addRequest)
+}
+
+// RemoveItem removes the item from the wishlist
+func (s *WishlistService) RemoveItem(ctx context.Context, session *web.Session, itemID string) (*domain.Wishlist, error) {
+	if s.userService.IsLoggedIn(ctx, session) {
+		auth, err := s.authenticator.Auth(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		return s.customerWishlistService.RemoveItem(ctx, auth, itemID)
+	}
+
+	wishlist, err := s.guestWishlist(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return s.guestWishlistService.RemoveItem(ctx, wishlist.ID, itemID)
+}
+
+// MoveToCart adds the item to the cart and removes it from the wishlist if it could be added
+func (s *WishlistService) MoveToCart(ctx context.Context, session *web.Session, itemID string, deliveryCode string) (productDomain.BasicProduct, error) {
+	wishlist, err := s.GetWishlist(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	item, err := wishlist.GetByItemID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.cartService.AddProduct(ctx, session, deliveryCode, cartDomain.AddRequest{
+		MarketplaceCode:        item.MarketplaceCode,
+		VariantMarketplaceCode: item.VariantMarketplaceCode,
+		BundleConfiguration:    item.BundleConfiguration,
+		Qty:                    item.Qty,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.RemoveItem(ctx, session, itemID)
+	return product, err
+}
+
+// SaveForLater moves the cart item to the wishlist - the delivery code is determined if empty
+func (s *WishlistService) SaveForLater(ctx context.Context, session *web.Session, cartItemID string, deliveryCode string) (*domain.Wishlist, error) {
+	cart, err := s.cartReceiver.ViewCart(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	item, err := cart.GetByItemID(cartItemID)
+	if err != nil {
+		return nil, err
+	}
+	if deliveryCode == "" {
+		deliveryCode = deliveryCodeOfItem(cart, cartItemID)
+	}
+
+	wishlist, err := s.AddProduct(ctx, session, domain.AddRequest{
+		MarketplaceCode:        item.MarketplaceCode,
+		VariantMarketplaceCode: item.VariantMarketPlaceCode,
+		BundleConfiguration:    item.BundleConfiguration(),
+		Qty:                    item.Qty,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wishlist, s.cartService.DeleteItem(ctx, session, cartItemID, deliveryCode)
+}
+
+// MergeGuestWishlist adds the items of the guest wishlist to the wishlist of the logged in customer -
+// products already on the customer wishlist are skipped, so a merge can be retried without duplicating items.
+// The guest wishlist is only deleted after all items are added.
+func (s *WishlistService) MergeGuestWishlist(ctx context.Context, session *web.Session) error {
+	if !s.userService.IsLoggedIn(ctx, session) {
+		return nil
+	}
+	guestWishlist, err := s.guestWishlist(ctx, session)
+	if errors.Cause(err) == domain.ErrWishlistNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	auth, err := s.authenticator.Auth(ctx, session)
+	if err != nil {
+		return err
+	}
+	customerWishlist, err := s.customerWishlistService.GetWishlist(ctx, auth)
+	if err != nil {
+		return err
+	}
+	for _, item := range guestWishlist.Items {
+		if _, found := customerWishlist.GetByProduct(item.MarketplaceCode, item.VariantMarketplaceCode); found {
+			continue
+		}
+		_, err := s.customerWishlistService.AddItem(ctx, auth, domain.AddRequest{
+			MarketplaceCode:        item.MarketplaceCode,
+			VariantMarketplaceCode: item.VariantMarketplaceCode,
+			BundleConfiguration:    item.BundleConfiguration,
+			Qty:                    item.Qty,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.guestWishlistService.DeleteWishlist(ctx, guestWishlist.ID)
+	if err != nil && errors.Cause(err) != domain.ErrWishlistNotFound {
+		return err
+	}
+	session.Delete(GuestWishlistSessionKey)
+	return nil
+}
+
+// guestWishlist returns the wishlist of the guest id stored in the session or domain.ErrWishlistNotFound
+func (s *WishlistService) guestWishlist(ctx context.Context, session *web.Session) (*domain.Wishlist, error) {
+	wishlistID, ok := session.Load(GuestWishlistSessionKey)
+	if !ok {
+		return nil, domain.ErrWishlistNotFound
+	}
+	id, ok := wishlistID.(string)
+	if !ok || id == "" {
+		return nil, domain.ErrWishlistNotFound
+	}
+	wishlist, err := s.guestWishlistService.GetWishlist(ctx, id)
+	if errors.Cause(err) == domain.ErrWishlistNotFound {
+		session.Delete(GuestWishlistSessionKey)
+	}
+	return wishlist, err
+}
+
+func deliveryCodeOfItem(cart *cartDomain.Cart, itemID string) string {
+	for _, delivery := range cart.Deliveries {
+		for _, item := range delivery.Cartitems {
+			if item.ID == itemID {
+				return delivery.DeliveryInfo.Code
+			}
+		}
+	}
+	return ""
+}
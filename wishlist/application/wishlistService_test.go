@@ -0,0 +1,262 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cartDomain "flamingo.me/flamingo-commerce/v3/cart/domain/cart"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	"flamingo.me/flamingo-commerce/v3/wishlist/infrastructure"
+	authDomain "flamingo.me/flamingo/v3/core/oauth/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	productServiceStub struct{}
+
+	authStub struct{}
+
+	cartStub struct {
+		cart    cartDomain.Cart
+		added   []cartDomain.AddRequest
+		deleted []string
+		addErr  error
+	}
+
+	failingGuestWishlistService struct {
+		domain.GuestWishlistService
+		deleteErr error
+	}
+
+	failingCustomerWishlistService struct {
+		domain.CustomerWishlistService
+		addErr error
+	}
+)
+
+func (s *failingGuestWishlistService) DeleteWishlist(ctx context.Context, wishlistID string) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	return s.GuestWishlistService.DeleteWishlist(ctx, wishlistID)
+}
+
+func (s *failingCustomerWishlistService) AddItem(ctx context.Context, auth authDomain.Auth, addRequest domain.AddRequest) (*domain.Wishlist, error) {
+	if s.addErr != nil {
+		return nil, s.addErr
+	}
+	return s.CustomerWishlistService.AddItem(ctx, auth, addRequest)
+}
+
+func (productServiceStub) Get(_ context.Context, marketplaceCode string) (productDomain.BasicProduct, error) {
+	switch marketplaceCode {
+	case "shirt":
+		return productDomain.ConfigurableProduct{
+			BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt"},
+			Variants:         []productDomain.Variant{{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt-red"}}},
+		}, nil
+	case "unknown":
+		return nil, productDomain.ProductNotFound{MarketplaceCode: marketplaceCode}
+	}
+	return productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: marketplaceCode}}, nil
+}
+
+func (authStub) Auth(_ context.Context, session *web.Session) (authDomain.Auth, error) {
+	if _, ok := session.Load("customer"); ok {
+		return authDomain.Auth{IDToken: &authDomain.IDToken{Subject: "customer-1"}}, nil
+	}
+	return authDomain.Auth{}, errors.New("not logged in")
+}
+
+func (authStub) GetUser(_ context.Context, session *web.Session) *authDomain.User {
+	if _, ok := session.Load("customer"); ok {
+		return &authDomain.User{Sub: "customer-1"}
+	}
+	return nil
+}
+
+func (a authStub) IsLoggedIn(ctx context.Context, session *web.Session) bool {
+	return a.GetUser(ctx, session) != nil
+}
+
+func (c *cartStub) AddProduct(_ context.Context, _ *web.Session, _ string, addRequest cartDomain.AddRequest) (productDomain.BasicProduct, error) {
+	if c.addErr != nil {
+		return nil, c.addErr
+	}
+	c.added = append(c.added, addRequest)
+	return productDomain.SimpleProduct{}, nil
+}
+
+func (c *cartStub) DeleteItem(_ context.Context, _ *web.Session, itemID string, deliveryCode string) error {
+	c.deleted = append(c.deleted, itemID+"@"+deliveryCode)
+	return nil
+}
+
+func (c *cartStub) ViewCart(context.Context, *web.Session) (*cartDomain.Cart, error) {
+	return &c.cart, nil
+}
+
+func wishlistService(cart *cartStub) *WishlistService {
+	storage := new(infrastructure.InMemoryStorage)
+	guestService := new(infrastructure.InMemoryGuestWishlistService)
+	guestService.Inject(storage)
+	customerService := new(infrastructure.InMemoryCustomerWishlistService)
+	customerService.Inject(storage)
+	factory := new(domain.DecoratedWishlistFactory)
+	factory.Inject(productServiceStub{}, flamingo.NullLogger{})
+
+	return &WishlistService{
+		guestWishlistService:    guestService,
+		customerWishlistService: customerService,
+		decoratedFactory:        factory,
+		productService:          productServiceStub{},
+		authenticator:           authStub{},
+		userService:             authStub{},
+		cartService:             cart,
+		cartReceiver:            cart,
+		logger:                  flamingo.NullLogger{},
+	}
+}
+
+func TestWishlistService_AddProduct(t *testing.T) {
+	s := wishlistService(new(cartStub))
+	ctx := context.Background()
+	session := web.EmptySession()
+
+	wishlist, err := s.GetWishlist(ctx, session)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, wishlist.ItemCount(), "guests without wishlist get an empty one")
+
+	_, err = s.AddProduct(ctx, session, domain.AddRequest{MarketplaceCode: "unknown"})
+	assert.Error(t, err)
+	_, err = s.AddProduct(ctx, session, domain.AddRequest{MarketplaceCode: "mug", VariantMarketplaceCode: "mug-red"})
+	assert.Error(t, err, "variants can only be added for configurables")
+
+	_, err = s.AddProduct(ctx, session, domain.AddRequest{MarketplaceCode: "mug", Qty: 1})
+	assert.NoError(t, err)
+	wishlist, err = s.AddProduct(ctx, session, domain.AddRequest{MarketplaceCode: "mug", Qty: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, wishlist.Items, 1) {
+		assert.Equal(t, 3, wishlist.Items[0].Qty)
+	}
+	_, ok := session.Load(GuestWishlistSessionKey)
+	assert.True(t, ok)
+
+	wishlist, err = s.AddProduct(ctx, session, domain.AddRequest{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-red"})
+	assert.NoError(t, err)
+	decorated, err := s.GetDecoratedWishlist(ctx, session)
+	assert.NoError(t, err)
+	if assert.Len(t, decorated.DecoratedItems, 2) {
+		assert.Equal(t, "shirt-red", decorated.DecoratedItems[1].Product.BaseData().MarketPlaceCode)
+	}
+
+	wishlist, err = s.RemoveItem(ctx, session, wishlist.Items[0].ID)
+	assert.NoError(t, err)
+	assert.False(t, wishlist.HasProduct("mug"))
+	assert.True(t, wishlist.HasProduct("shirt-red"))
+}
+
+func TestWishlistService_MergeGuestWishlist(t *testing.T) {
+	s := wishlistService(new(cartStub))
+	ctx := context.Background()
+	session := web.EmptySession()
+
+	_, err := s.AddProduct(ctx, session, domain.AddRequest{MarketplaceCode: "mug"})
+	assert.NoError(t, err)
+
+	customerSession := web.EmptySession().Store("customer", true)
+	_, err = s.AddProduct(ctx, customerSession, domain.AddRequest{MarketplaceCode: "cup"})
+	assert.NoError(t, err)
+
+	session.Store("customer", true)
+	receiver := new(EventReceiver)
+	receiver.Inject(s, flamingo.NullLogger{})
+	receiver.Notify(ctx, &authDomain.LoginEvent{Session: session})
+
+	_, ok := session.Load(GuestWishlistSessionKey)
+	assert.False(t, ok)
+	wishlist, err := s.GetWishlist(ctx, session)
+	assert.NoError(t, err)
+	assert.True(t, wishlist.HasProduct("cup"))
+	assert.True(t, wishlist.HasProduct("mug"), "the guest items are merged into the customer wishlist")
+}
+
+func TestWishlistService_MergeGuestWishlistFailures(t *testing.T) {
+	s := wishlistService(new(cartStub))
+	guestService := &failingGuestWishlistService{GuestWishlistService: s.guestWishlistService}
+	customerService := &failingCustomerWishlistService{CustomerWishlistService: s.customerWishlistService}
+	s.guestWishlistService = guestService
+	s.customerWishlistService = customerService
+	ctx := context.Background()
+	session := web.EmptySession()
+
+	_, err := s.AddProduct(ctx, session, domain.AddRequest{MarketplaceCode: "mug", Qty: 2})
+	assert.NoError(t, err)
+	_, err = s.AddProduct(ctx, session, domain.AddRequest{MarketplaceCode: "cup"})
+	assert.NoError(t, err)
+	guestID, _ := session.Load(GuestWishlistSessionKey)
+	session.Store("customer", true)
+
+	customerService.addErr = errors.New("write failed")
+	assert.Error(t, s.MergeGuestWishlist(ctx, session))
+	_, ok := session.Load(GuestWishlistSessionKey)
+	assert.True(t, ok, "the guest wishlist is kept if the customer wishlist could not be written")
+	_, err = guestService.GetWishlist(ctx, guestID.(string))
+	assert.NoError(t, err)
+
+	customerService.addErr = nil
+	guestService.deleteErr = errors.New("delete failed")
+	assert.Error(t, s.MergeGuestWishlist(ctx, session))
+	_, ok = session.Load(GuestWishlistSessionKey)
+	assert.True(t, ok, "the guest wishlist id is kept to retry the delete")
+
+	guestService.deleteErr = nil
+	assert.NoError(t, s.MergeGuestWishlist(ctx, session))
+	_, ok = session.Load(GuestWishlistSessionKey)
+	assert.False(t, ok)
+	_, err = guestService.GetWishlist(ctx, guestID.(string))
+	assert.Error(t, err, "the guest wishlist is deleted after the merge")
+
+	wishlist, err := s.GetWishlist(ctx, session)
+	assert.NoError(t, err)
+	if assert.Len(t, wishlist.Items, 2, "retried merges don't duplicate items") {
+		item, _ := wishlist.GetByProduct("mug", "")
+		assert.Equal(t, 2, item.Qty)
+	}
+}
+
+func TestWishlistService_MoveToCartAndSaveForLater(t *testing.T) {
+	cart := &cartStub{cart: cartDomain.Cart{Deliveries: []cartDomain.Delivery{{
+		DeliveryInfo: cartDomain.DeliveryInfo{Code: "delivery"},
+		Cartitems:    []cartDomain.Item{{ID: "cart-item", MarketplaceCode: "shirt", VariantMarketPlaceCode: "shirt-red", Qty: 2}},
+	}}}}
+	s := wishlistService(cart)
+	ctx := context.Background()
+	session := web.EmptySession()
+
+	wishlist, err := s.SaveForLater(ctx, session, "cart-item", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cart-item@delivery"}, cart.deleted)
+	if !assert.Len(t, wishlist.Items, 1) {
+		return
+	}
+	assert.Equal(t, 2, wishlist.Items[0].Qty)
+
+	cart.addErr = errors.New("not allowed")
+	_, err = s.MoveToCart(ctx, session, wishlist.Items[0].ID, "")
+	assert.Error(t, err)
+	wishlist, _ = s.GetWishlist(ctx, session)
+	assert.Len(t, wishlist.Items, 1, "items that could not be added to the cart stay on the wishlist")
+
+	cart.addErr = nil
+	_, err = s.MoveToCart(ctx, session, wishlist.Items[0].ID, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []cartDomain.AddRequest{{MarketplaceCode: "shirt", VariantMarketplaceCode: "shirt-red", Qty: 2}}, cart.added)
+	wishlist, _ = s.GetWishlist(ctx, session)
+	assert.Empty(t, wishlist.Items)
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	// DecoratedWishlist decorates the wishlist items with their products
+	DecoratedWishlist struct {
+		Wishlist       Wishlist
+		DecoratedItems []DecoratedItem
+	}
+
+	// DecoratedItem decorates a wishlist item with its product - Product is nil if the product is not available anymore
+	DecoratedItem struct {
+		Item    Item
+		Product productDomain.BasicProduct
+	}
+
+	// DecoratedWishlistFactory creates decorated wishlists
+	DecoratedWishlistFactory struct {
+		productService productDomain.ProductService
+		logger         flamingo.Logger
+	}
+)
+
+// Inject dependencies
+func (df *DecoratedWishlistFactory) Inject(
+	productService productDomain.ProductService,
+	logger flamingo.Logger,
+) {
+	df.productService = productService
+	df.logger = logger.WithField(flamingo.LogKeyModule, "wishlist").WithField(flamingo.LogKeyCategory, "domain.DecoratedWishlistFactory")
+}
+
+// Create loads the products of all items with one batch call - configurables are decorated with their variant and bundles with their choices
+func (df *DecoratedWishlistFactory) Create(ctx context.Context, wishlist Wishlist) *DecoratedWishlist {
+	marketplaceCodes := make([]string, len(wishlist.Items))
+	for i, item := range wishlist.Items {
+		marketplaceCodes[i] = item.MarketplaceCode
+	}
+	products, err := productDomain.GetMany(ctx, df.productService, marketplaceCodes...)
+	if err != nil {
+		df.logger.WithContext(ctx).Error("error loading products for items: ", err)
+	}
+
+	decorated := &DecoratedWishlist{Wishlist: wishlist}
+	for _, item := range wishlist.Items {
+		decorated.DecoratedItems = append(decorated.DecoratedItems, DecoratedItem{Item: item, Product: decorateProduct(products[item.MarketplaceCode], item)})
+	}
+	return decorated
+}
+
+func decorateProduct(product productDomain.BasicProduct, item Item) productDomain.BasicProduct {
+	switch p := product.(type) {
+	case productDomain.ConfigurableProduct:
+		if item.VariantMarketplaceCode == "" {
+			return p
+		}
+		withActiveVariant, err := p.GetConfigurableWithActiveVariant(item.VariantMarketplaceCode)
+		if err != nil {
+			return nil
+		}
+		return withActiveVariant
+	case productDomain.BundleProduct:
+		withActiveChoices, err := p.GetBundleWithActiveChoices(item.BundleConfiguration)
+		if err != nil {
+			return nil
+		}
+		return withActiveChoices
+	}
+	return product
+}
+
+// IsAvailable checks if the product of the item is still available
+func (di DecoratedItem) IsAvailable() bool {
+	return di.Product != nil
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo/v3/core/oauth/domain"
+)
+
+type (
+	// Wishlist of a guest or customer
+	Wishlist struct {
+		ID    string
+		Items []Item
+	}
+
+	// Item of a wishlist
+	Item struct {
+		ID                     string
+		MarketplaceCode        string
+		VariantMarketplaceCode string
+		// BundleConfiguration contains the chosen components - only relevant for bundle products
+		BundleConfiguration productDomain.BundleConfiguration
+		Qty                 int
+		AddedAt             time.Time
+	}
+
+	// AddRequest defines the product that is added to a wishlist
+	AddRequest struct {
+		MarketplaceCode        string
+		VariantMarketplaceCode string
+		BundleConfiguration    productDomain.BundleConfiguration
+		Qty                    int
+	}
+
+	// GuestWishlistService interface - Secondary PORT
+	GuestWishlistService interface {
+		GetWishlist(ctx context.Context, wishlistID string) (*Wishlist, error)
+		// GetNewWishlist - should return a new guest wishlist (including the id of the wishlist)
+		GetNewWishlist(ctx context.Context) (*Wishlist, error)
+		AddItem(ctx context.Context, wishlistID string, addRequest AddRequest) (*Wishlist, error)
+		RemoveItem(ctx context.Context, wishlistID string, itemID string) (*Wishlist, error)
+		// DeleteWishlist - should delete the guest wishlist (e.g. after it was merged into the customer wishlist)
+		DeleteWishlist(ctx context.Context, wishlistID string) error
+	}
+
+	// CustomerWishlistService interface - Secondary PORT
+	CustomerWishlistService interface {
+		GetWishlist(ctx context.Context, auth domain.Auth) (*Wishlist, error)
+		AddItem(ctx context.Context, auth domain.Auth, addRequest AddRequest) (*Wishlist, error)
+		RemoveItem(ctx context.Context, auth domain.Auth, itemID string) (*Wishlist, error)
+	}
+)
+
+var (
+	// ErrWishlistNotFound is used if a wishlist was not found
+	ErrWishlistNotFound = errors.New("wishlist not found")
+	// ErrItemNotFound is used if an item is not on the wishlist
+	ErrItemNotFound = errors.New("wishlist item not found")
+)
+
+// GetByItemID gets an item by its id
+func (w Wishlist) GetByItemID(itemID string) (*Item, error) {
+	for _, item := range w.Items {
+		if item.ID == itemID {
+			return &item, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrItemNotFound, "item %q", itemID)
+}
+
+// GetByProduct gets the item of the product (and variant)
+func (w Wishlist) GetByProduct(marketplaceCode string, variantMarketplaceCode string) (*Item, bool) {
+	for _, item := range w.Items {
+		if item.MarketplaceCode == marketplaceCode && item.VariantMarketplaceCode == variantMarketplaceCode {
+			return &item, true
+		}
+	}
+	return nil, false
+}
+
+// HasProduct checks if the product (given by the marketplace code of the product or of the variant) is on the wishlist
+func (w Wishlist) HasProduct(marketplaceCode string) bool {
+	for _, item := range w.Items {
+		if item.MarketplaceCode == marketplaceCode || item.VariantMarketplaceCode == marketplaceCode {
+			return true
+		}
+	}
+	return false
+}
+
+// ItemCount returns the number of items
+func (w Wishlist) ItemCount() int {
+	return len(w.Items)
+}
@@ -0,0 +1,128 @@
+package infrastructure
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+)
+
+type (
+	// InMemoryStorage keeps the wishlists in memory (e.g. for development) - it is bound as singleton
+	InMemoryStorage struct {
+		mutex     sync.Mutex
+		wishlists map[string]*domain.Wishlist
+		lastID    int
+	}
+)
+
+// create a new empty wishlist with the given id (an existing wishlist with this id is returned) or a generated id if empty
+func (s *InMemoryStorage) create(wishlistID string) *domain.Wishlist {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.init()
+	if existing, ok := s.wishlists[wishlistID]; ok {
+		return copyWishlist(existing)
+	}
+	if wishlistID == "" {
+		s.lastID++
+		wishlistID = "guest-" + strconv.Itoa(s.lastID)
+	}
+	wishlist := &domain.Wishlist{ID: wishlistID}
+	s.wishlists[wishlistID] = wishlist
+	return copyWishlist(wishlist)
+}
+
+// get returns a copy of the wishlist
+func (s *InMemoryStorage) get(wishlistID string) (*domain.Wishlist, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.init()
+	wishlist, ok := s.wishlists[wishlistID]
+	if !ok {
+		return nil, errors.Wrapf(domain.ErrWishlistNotFound, "wishlist %q", wishlistID)
+	}
+	return copyWishlist(wishlist), nil
+}
+
+// addItem adds the product - the quantity of an existing item of the same product is increased
+func (s *InMemoryStorage) addItem(wishlistID string, addRequest domain.AddRequest) (*domain.Wishlist, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.init()
+	wishlist, ok := s.wishlists[wishlistID]
+	if !ok {
+		return nil, errors.Wrapf(domain.ErrWishlistNotFound, "wishlist %q", wishlistID)
+	}
+
+	qty := addRequest.Qty
+	if qty < 1 {
+		qty = 1
+	}
+	for i, item := range wishlist.Items {
+		if item.MarketplaceCode == addRequest.MarketplaceCode && item.VariantMarketplaceCode == addRequest.VariantMarketplaceCode {
+			wishlist.Items[i].Qty += qty
+			wishlist.Items[i].BundleConfiguration = addRequest.BundleConfiguration
+			return copyWishlist(wishlist), nil
+		}
+	}
+
+	s.lastID++
+	wishlist.Items = append(wishlist.Items, domain.Item{
+		ID:                     strconv.Itoa(s.lastID),
+		MarketplaceCode:        addRequest.MarketplaceCode,
+		VariantMarketplaceCode: addRequest.VariantMarketplaceCode,
+		BundleConfiguration:    addRequest.BundleConfiguration,
+		Qty:                    qty,
+		AddedAt:                time.Now(),
+	})
+	return copyWishlist(wishlist), nil
+}
+
+func (s *InMemoryStorage) removeItem(wishlistID string, itemID string) (*domain.Wishlist, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.init()
+	wishlist, ok := s.wishlists[wishlistID]
+	if !ok {
+		return nil, errors.Wrapf(domain.ErrWishlistNotFound, "wishlist %q", wishlistID)
+	}
+	for i, item := range wishlist.Items {
+		if item.ID == itemID {
+			wishlist.Items = append(wishlist.Items[:i], wishlist.Items[i+1:]...)
+			return copyWishlist(wishlist), nil
+		}
+	}
+	return nil, errors.Wrapf(domain.ErrItemNotFound, "item %q", itemID)
+}
+
+func (s *InMemoryStorage) delete(wishlistID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.init()
+	if _, ok := s.wishlists[wishlistID]; !ok {
+		return errors.Wrapf(domain.ErrWishlistNotFound, "wishlist %q", wishlistID)
+	}
+	delete(s.wishlists, wishlistID)
+	return nil
+}
+
+func (s *InMemoryStorage) init() {
+	if s.wishlists == nil {
+		s.wishlists = make(map[string]*domain.Wishlist)
+	}
+}
+
+func copyWishlist(wishlist *domain.Wishlist) *domain.Wishlist {
+	result := *wishlist
+	result.Items = append([]domain.Item(nil), wishlist.Items...)
+	return &result
+}
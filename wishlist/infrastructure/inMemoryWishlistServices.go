@@ -0,0 +1,100 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	authDomain "flamingo.me/flamingo/v3/core/oauth/domain"
+)
+
+type (
+	// InMemoryGuestWishlistService defines the in memory guest wishlist service
+	InMemoryGuestWishlistService struct {
+		storage *InMemoryStorage
+	}
+
+	// InMemoryCustomerWishlistService defines the in memory customer wishlist service - the wishlists are keyed by the subject of the id token
+	InMemoryCustomerWishlistService struct {
+		storage *InMemoryStorage
+	}
+)
+
+var (
+	_ domain.GuestWishlistService    = (*InMemoryGuestWishlistService)(nil)
+	_ domain.CustomerWishlistService = (*InMemoryCustomerWishlistService)(nil)
+)
+
+// Inject dependencies
+func (s *InMemoryGuestWishlistService) Inject(storage *InMemoryStorage) {
+	s.storage = storage
+}
+
+// GetWishlist returns the guest wishlist
+func (s *InMemoryGuestWishlistService) GetWishlist(_ context.Context, wishlistID string) (*domain.Wishlist, error) {
+	return s.storage.get(wishlistID)
+}
+
+// GetNewWishlist creates a new guest wishlist
+func (s *InMemoryGuestWishlistService) GetNewWishlist(_ context.Context) (*domain.Wishlist, error) {
+	return s.storage.create(""), nil
+}
+
+// AddItem adds the product to the guest wishlist
+func (s *InMemoryGuestWishlistService) AddItem(_ context.Context, wishlistID string, addRequest domain.AddRequest) (*domain.Wishlist, error) {
+	return s.storage.addItem(wishlistID, addRequest)
+}
+
+// RemoveItem removes the item from the guest wishlist
+func (s *InMemoryGuestWishlistService) RemoveItem(_ context.Context, wishlistID string, itemID string) (*domain.Wishlist, error) {
+	return s.storage.removeItem(wishlistID, itemID)
+}
+
+// DeleteWishlist deletes the guest wishlist
+func (s *InMemoryGuestWishlistService) DeleteWishlist(_ context.Context, wishlistID string) error {
+	return s.storage.delete(wishlistID)
+}
+
+// Inject dependencies
+func (s *InMemoryCustomerWishlistService) Inject(storage *InMemoryStorage) {
+	s.storage = storage
+}
+
+// GetWishlist returns the wishlist of the customer - it is created on first access
+func (s *InMemoryCustomerWishlistService) GetWishlist(_ context.Context, auth authDomain.Auth) (*domain.Wishlist, error) {
+	wishlistID, err := customerWishlistID(auth)
+	if err != nil {
+		return nil, err
+	}
+	wishlist, err := s.storage.get(wishlistID)
+	if errors.Cause(err) == domain.ErrWishlistNotFound {
+		return s.storage.create(wishlistID), nil
+	}
+	return wishlist, err
+}
+
+// AddItem adds the product to the wishlist of the customer
+func (s *InMemoryCustomerWishlistService) AddItem(ctx context.Context, auth authDomain.Auth, addRequest domain.AddRequest) (*domain.Wishlist, error) {
+	wishlist, err := s.GetWishlist(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.addItem(wishlist.ID, addRequest)
+}
+
+// RemoveItem removes the item from the wishlist of the customer
+func (s *InMemoryCustomerWishlistService) RemoveItem(ctx context.Context, auth authDomain.Auth, itemID string) (*domain.Wishlist, error) {
+	wishlist, err := s.GetWishlist(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.removeItem(wishlist.ID, itemID)
+}
+
+func customerWishlistID(auth authDomain.Auth) (string, error) {
+	if auth.IDToken == nil || auth.IDToken.Subject == "" {
+		return "", errors.New("no id token subject given")
+	}
+	return "customer-" + auth.IDToken.Subject, nil
+}
@@ -0,0 +1,63 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	"flamingo.me/flamingo-commerce/v3/wishlist/infrastructure"
+	authDomain "flamingo.me/flamingo/v3/core/oauth/domain"
+)
+
+func TestInMemoryGuestWishlistService(t *testing.T) {
+	service := new(infrastructure.InMemoryGuestWishlistService)
+	service.Inject(new(infrastructure.InMemoryStorage))
+	ctx := context.Background()
+
+	_, err := service.GetWishlist(ctx, "unknown")
+	assert.Equal(t, domain.ErrWishlistNotFound, errors.Cause(err))
+
+	wishlist, err := service.GetNewWishlist(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, wishlist.ID)
+
+	_, err = service.AddItem(ctx, wishlist.ID, domain.AddRequest{MarketplaceCode: "mug", Qty: 2})
+	assert.NoError(t, err)
+	updated, err := service.AddItem(ctx, wishlist.ID, domain.AddRequest{MarketplaceCode: "mug"})
+	assert.NoError(t, err)
+	item, found := updated.GetByProduct("mug", "")
+	if assert.True(t, found) {
+		assert.Equal(t, 3, item.Qty, "the quantity of an existing item is increased")
+	}
+	assert.Empty(t, wishlist.Items, "returned wishlists are copies")
+
+	_, err = service.RemoveItem(ctx, wishlist.ID, "unknown")
+	assert.Equal(t, domain.ErrItemNotFound, errors.Cause(err))
+	updated, err = service.RemoveItem(ctx, wishlist.ID, item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, updated.ItemCount())
+}
+
+func TestInMemoryCustomerWishlistService(t *testing.T) {
+	service := new(infrastructure.InMemoryCustomerWishlistService)
+	service.Inject(new(infrastructure.InMemoryStorage))
+	ctx := context.Background()
+
+	_, err := service.GetWishlist(ctx, authDomain.Auth{})
+	assert.Error(t, err, "the customer is identified by the id token")
+
+	auth := authDomain.Auth{IDToken: &authDomain.IDToken{Subject: "customer-1"}}
+	_, err = service.AddItem(ctx, auth, domain.AddRequest{MarketplaceCode: "mug"})
+	assert.NoError(t, err)
+
+	wishlist, err := service.GetWishlist(ctx, auth)
+	assert.NoError(t, err)
+	assert.True(t, wishlist.HasProduct("mug"))
+
+	other, err := service.GetWishlist(ctx, authDomain.Auth{IDToken: &authDomain.IDToken{Subject: "customer-2"}})
+	assert.NoError(t, err)
+	assert.False(t, other.HasProduct("mug"))
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	productController "flamingo.me/flamingo-commerce/v3/product/interfaces/controller"
+	"flamingo.me/flamingo-commerce/v3/wishlist/application"
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// APIController handles the wishlist as JSON
+	APIController struct {
+		responder       *web.Responder
+		wishlistService *application.WishlistService
+		productAPI      *productController.APIController
+		logger          flamingo.Logger
+	}
+
+	// APIResult is the JSON result of the wishlist api
+	APIResult struct {
+		Success  bool         `json:"success"`
+		Error    *APIError    `json:"error,omitempty"`
+		Wishlist *APIWishlist `json:"wishlist,omitempty"`
+	}
+
+	// APIWishlist is the stable representation of the domain.DecoratedWishlist
+	APIWishlist struct {
+		ID    string            `json:"id"`
+		Items []APIWishlistItem `json:"items"`
+	}
+
+	// APIWishlistItem is an item of the wishlist - Product has the representation of the product api and is nil if the product is not available anymore
+	APIWishlistItem struct {
+		ID                     string                        `json:"id"`
+		MarketplaceCode        string                        `json:"marketplaceCode"`
+		VariantMarketplaceCode string                        `json:"variantMarketplaceCode,omitempty"`
+		BundleConfiguration    map[string]APIBundleChoice    `json:"bundleConfiguration,omitempty"`
+		Qty                    int                           `json:"qty"`
+		AddedAt                time.Time                     `json:"addedAt"`
+		IsAvailable            bool                          `json:"isAvailable"`
+		Product                *productController.APIProduct `json:"product,omitempty"`
+	}
+
+	// APIBundleChoice is the chosen product of a bundle option
+	APIBundleChoice struct {
+		MarketplaceCode string `json:"marketplaceCode"`
+		Qty             int    `json:"qty"`
+	}
+
+	// APIError contains details if success is false
+	APIError struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	}
+)
+
+// Inject dependencies
+func (c *APIController) Inject(responder *web.Responder, wishlistService *application.WishlistService, productAPI *productController.APIController, logger flamingo.Logger) {
+	c.responder = responder
+	c.wishlistService = wishlistService
+	c.productAPI = productAPI
+	c.logger = logger.WithField(flamingo.LogKeyModule, "wishlist").WithField(flamingo.LogKeyCategory, "controller.APIController")
+}
+
+// GetAction returns the decorated wishlist
+func (c *APIController) GetAction(ctx context.Context, r *web.Request) web.Result {
+	return c.wishlist(ctx, r)
+}
+
+// AddAction adds the product (with the optional variantcode and qty) and returns the wishlist
+func (c *APIController) AddAction(ctx context.Context, r *web.Request) web.Result {
+	if _, err := c.wishlistService.AddProduct(ctx, r.Session(), addRequest(r)); err != nil {
+		return c.error(ctx, err)
+	}
+	return c.wishlist(ctx, r)
+}
+
+// RemoveAction removes the item and returns the wishlist
+func (c *APIController) RemoveAction(ctx context.Context, r *web.Request) web.Result {
+	if _, err := c.wishlistService.RemoveItem(ctx, r.Session(), r.Params["id"]); err != nil {
+		return c.error(ctx, err)
+	}
+	return c.wishlist(ctx, r)
+}
+
+// MoveToCartAction moves the item to the cart and returns the wishlist
+func (c *APIController) MoveToCartAction(ctx context.Context, r *web.Request) web.Result {
+	if _, err := c.wishlistService.MoveToCart(ctx, r.Session(), r.Params["id"], r.Params["deliveryCode"]); err != nil {
+		return c.error(ctx, err)
+	}
+	return c.wishlist(ctx, r)
+}
+
+// SaveForLaterAction moves the cart item to the wishlist and returns the wishlist
+func (c *APIController) SaveForLaterAction(ctx context.Context, r *web.Request) web.Result {
+	if _, err := c.wishlistService.SaveForLater(ctx, r.Session(), r.Params["id"], r.Params["deliveryCode"]); err != nil {
+		return c.error(ctx, err)
+	}
+	return c.wishlist(ctx, r)
+}
+
+func (c *APIController) wishlist(ctx context.Context, r *web.Request) web.Result {
+	decoratedWishlist, err := c.wishlistService.GetDecoratedWishlist(ctx, r.Session())
+	if err != nil {
+		return c.error(ctx, err)
+	}
+	return c.responder.Data(APIResult{Success: true, Wishlist: c.apiWishlist(decoratedWishlist)})
+}
+
+// apiWishlist maps the decorated wishlist to the api representation
+func (c *APIController) apiWishlist(decoratedWishlist *domain.DecoratedWishlist) *APIWishlist {
+	result := &APIWishlist{
+		ID:    decoratedWishlist.Wishlist.ID,
+		Items: make([]APIWishlistItem, 0, len(decoratedWishlist.DecoratedItems)),
+	}
+	for _, decoratedItem := range decoratedWishlist.DecoratedItems {
+		item := APIWishlistItem{
+			ID:                     decoratedItem.Item.ID,
+			MarketplaceCode:        decoratedItem.Item.MarketplaceCode,
+			VariantMarketplaceCode: decoratedItem.Item.VariantMarketplaceCode,
+			Qty:                    decoratedItem.Item.Qty,
+			AddedAt:                decoratedItem.Item.AddedAt,
+			IsAvailable:            decoratedItem.IsAvailable(),
+		}
+		if len(decoratedItem.Item.BundleConfiguration) > 0 {
+			item.BundleConfiguration = make(map[string]APIBundleChoice, len(decoratedItem.Item.BundleConfiguration))
+			for optionCode, choice := range decoratedItem.Item.BundleConfiguration {
+				item.BundleConfiguration[optionCode] = APIBundleChoice{MarketplaceCode: choice.MarketplaceCode, Qty: choice.Qty}
+			}
+		}
+		if decoratedItem.IsAvailable() {
+			item.Product = c.productAPI.MapProduct(decoratedItem.Product)
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result
+}
+
+func (c *APIController) error(ctx context.Context, err error) web.Result {
+	code := errorCode(err)
+	status := uint(http.StatusBadRequest)
+	switch code {
+	case errorProductNotFound, errorItemNotFound:
+		status = http.StatusNotFound
+	case errorGeneral:
+		status = http.StatusInternalServerError
+		c.logger.WithContext(ctx).Error(err)
+	}
+	return c.responder.Data(APIResult{Error: &APIError{Message: err.Error(), Code: code}}).Status(status)
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	productApplication "flamingo.me/flamingo-commerce/v3/product/application"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	productController "flamingo.me/flamingo-commerce/v3/product/interfaces/controller"
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+func TestAPIController_apiWishlist(t *testing.T) {
+	productAPI := new(productController.APIController)
+	productAPI.Inject(new(web.Responder), nil, new(productApplication.URLService), nil, nil, flamingo.NullLogger{})
+	controller := new(APIController)
+	controller.Inject(new(web.Responder), nil, productAPI, flamingo.NullLogger{})
+
+	addedAt := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	decorated := &domain.DecoratedWishlist{
+		Wishlist: domain.Wishlist{ID: "list-1"},
+		DecoratedItems: []domain.DecoratedItem{
+			{
+				Item:    domain.Item{ID: "1", MarketplaceCode: "shirt", Qty: 2, AddedAt: addedAt},
+				Product: productDomain.SimpleProduct{BasicProductData: productDomain.BasicProductData{MarketPlaceCode: "shirt", Title: "Shirt"}},
+			},
+			{
+				Item: domain.Item{ID: "2", MarketplaceCode: "deleted", Qty: 1, AddedAt: addedAt},
+			},
+		},
+	}
+
+	data, err := json.Marshal(controller.apiWishlist(decorated))
+	assert.NoError(t, err)
+
+	var result struct {
+		ID    string                       `json:"id"`
+		Items []map[string]json.RawMessage `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &result))
+	assert.Equal(t, "list-1", result.ID)
+	if !assert.Len(t, result.Items, 2) {
+		return
+	}
+	for _, key := range []string{"id", "marketplaceCode", "qty", "addedAt", "isAvailable", "product"} {
+		assert.Contains(t, result.Items[0], key)
+	}
+	assert.JSONEq(t, `true`, string(result.Items[0]["isAvailable"]))
+	assert.Contains(t, string(result.Items[0]["product"]), `"marketplaceCode":"shirt"`)
+	assert.JSONEq(t, `false`, string(result.Items[1]["isAvailable"]))
+	assert.NotContains(t, result.Items[1], "product", "unavailable items have no product")
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	cartValidation "flamingo.me/flamingo-commerce/v3/cart/domain/validation"
+	productDomain "flamingo.me/flamingo-commerce/v3/product/domain"
+	"flamingo.me/flamingo-commerce/v3/wishlist/application"
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// ViewController renders the wishlist and handles the wishlist actions
+	ViewController struct {
+		responder       *web.Responder
+		wishlistService *application.WishlistService
+		logger          flamingo.Logger
+		template        string
+	}
+
+	// viewData is used for the wishlist rendering
+	viewData struct {
+		DecoratedWishlist *domain.DecoratedWishlist
+		// ErrorMessageKey is set if the last action failed
+		ErrorMessageKey string
+	}
+)
+
+const (
+	errorFlashKey = "wishlist.view.error"
+
+	errorProductNotFound = "product_not_found"
+	errorItemNotFound    = "item_not_found"
+	errorGeneral         = "wishlist_error"
+)
+
+// Inject dependencies
+func (c *ViewController) Inject(
+	responder *web.Responder,
+	wishlistService *application.WishlistService,
+	logger flamingo.Logger,
+	config *struct {
+		Template string `inject:"config:commerce.wishlist.template,optional"`
+	},
+) {
+	c.responder = responder
+	c.wishlistService = wishlistService
+	c.logger = logger.WithField(flamingo.LogKeyModule, "wishlist").WithField(flamingo.LogKeyCategory, "controller.ViewController")
+	if config != nil {
+		c.template = config.Template
+	}
+}
+
+// ViewAction renders the decorated wishlist
+func (c *ViewController) ViewAction(ctx context.Context, r *web.Request) web.Result {
+	decoratedWishlist, err := c.wishlistService.GetDecoratedWishlist(ctx, r.Session())
+	if err != nil {
+		return c.responder.ServerError(err)
+	}
+
+	data := viewData{DecoratedWishlist: decoratedWishlist}
+	if flashes := r.Session().Flashes(errorFlashKey); len(flashes) > 0 {
+		data.ErrorMessageKey, _ = flashes[0].(string)
+	}
+	return c.responder.Render(c.template, data)
+}
+
+// AddAction adds the product to the wishlist and redirects to the wishlist
+func (c *ViewController) AddAction(ctx context.Context, r *web.Request) web.Result {
+	if _, err := c.wishlistService.AddProduct(ctx, r.Session(), addRequest(r)); err != nil {
+		c.logger.WithContext(ctx).Warn("product could not be added: ", err)
+		r.Session().AddFlash(errorCode(err), errorFlashKey)
+	}
+	return c.responder.RouteRedirect("wishlist.view", nil)
+}
+
+// RemoveAction removes the item and redirects to the wishlist
+func (c *ViewController) RemoveAction(ctx context.Context, r *web.Request) web.Result {
+	if _, err := c.wishlistService.RemoveItem(ctx, r.Session(), r.Params["id"]); err != nil {
+		c.logger.WithContext(ctx).Warn("item could not be removed: ", err)
+		r.Session().AddFlash(errorCode(err), errorFlashKey)
+	}
+	return c.responder.RouteRedirect("wishlist.view", nil)
+}
+
+// MoveToCartAction moves the item to the cart and redirects to the cart - on errors it redirects to the wishlist
+func (c *ViewController) MoveToCartAction(ctx context.Context, r *web.Request) web.Result {
+	if _, err := c.wishlistService.MoveToCart(ctx, r.Session(), r.Params["id"], r.Params["deliveryCode"]); err != nil {
+		c.logger.WithContext(ctx).Warn("item could not be moved to the cart: ", err)
+		r.Session().AddFlash(errorCode(err), errorFlashKey)
+		return c.responder.RouteRedirect("wishlist.view", nil)
+	}
+	return c.responder.RouteRedirect("cart.view", nil)
+}
+
+// SaveForLaterAction moves the cart item to the wishlist and redirects to the cart
+func (c *ViewController) SaveForLaterAction(ctx context.Context, r *web.Request) web.Result {
+	if _, err := c.wishlistService.SaveForLater(ctx, r.Session(), r.Params["id"], r.Params["deliveryCode"]); err != nil {
+		c.logger.WithContext(ctx).Warn("cart item could not be saved for later: ", err)
+	}
+	return c.responder.RouteRedirect("cart.view", nil)
+}
+
+func addRequest(r *web.Request) domain.AddRequest {
+	qty, err := strconv.Atoi(r.Params["qty"])
+	if err != nil || qty < 1 {
+		qty = 1
+	}
+	return domain.AddRequest{
+		MarketplaceCode:        r.Params["marketplacecode"],
+		VariantMarketplaceCode: r.Params["variantcode"],
+		Qty:                    qty,
+	}
+}
+
+// errorCode maps the errors of the wishlist actions
+func errorCode(err error) string {
+	cause := errors.Cause(err)
+	if _, ok := cause.(productDomain.ProductNotFound); ok {
+		return errorProductNotFound
+	}
+	if notAllowed, ok := cause.(*cartValidation.AddToCartNotAllowed); ok {
+		return notAllowed.MessageCode()
+	}
+	if cause == domain.ErrItemNotFound || cause == domain.ErrWishlistNotFound {
+		return errorItemNotFound
+	}
+	return errorGeneral
+}
@@ -0,0 +1,68 @@
+package templatefunctions
+
+import (
+	"context"
+
+	"flamingo.me/flamingo-commerce/v3/wishlist/application"
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// GetWishlist is exported as a template function
+	GetWishlist struct {
+		wishlistService *application.WishlistService
+		logger          flamingo.Logger
+	}
+
+	// GetDecoratedWishlist is exported as a template function
+	GetDecoratedWishlist struct {
+		wishlistService *application.WishlistService
+		logger          flamingo.Logger
+	}
+)
+
+// Inject dependencies
+func (tf *GetWishlist) Inject(wishlistService *application.WishlistService, logger flamingo.Logger) {
+	tf.wishlistService = wishlistService
+	tf.logger = logger.WithField(flamingo.LogKeyModule, "wishlist").WithField(flamingo.LogKeyCategory, "templatefunctions.GetWishlist")
+}
+
+// Func returns the wishlist of the current session (e.g. to check HasProduct)
+func (tf *GetWishlist) Func(ctx context.Context) interface{} {
+	return func() domain.Wishlist {
+		session := web.SessionFromContext(ctx)
+		if session == nil {
+			return domain.Wishlist{}
+		}
+		wishlist, err := tf.wishlistService.GetWishlist(ctx, session)
+		if err != nil {
+			tf.logger.WithContext(ctx).Error(err)
+			return domain.Wishlist{}
+		}
+		return *wishlist
+	}
+}
+
+// Inject dependencies
+func (tf *GetDecoratedWishlist) Inject(wishlistService *application.WishlistService, logger flamingo.Logger) {
+	tf.wishlistService = wishlistService
+	tf.logger = logger.WithField(flamingo.LogKeyModule, "wishlist").WithField(flamingo.LogKeyCategory, "templatefunctions.GetDecoratedWishlist")
+}
+
+// Func returns the wishlist of the current session decorated with the products
+func (tf *GetDecoratedWishlist) Func(ctx context.Context) interface{} {
+	return func() domain.DecoratedWishlist {
+		session := web.SessionFromContext(ctx)
+		if session == nil {
+			return domain.DecoratedWishlist{}
+		}
+		decoratedWishlist, err := tf.wishlistService.GetDecoratedWishlist(ctx, session)
+		if err != nil {
+			tf.logger.WithContext(ctx).Error(err)
+			return domain.DecoratedWishlist{}
+		}
+		return *decoratedWishlist
+	}
+}
@@ -0,0 +1,104 @@
+package wishlist
+
+import (
+	"flamingo.me/dingo"
+
+	"flamingo.me/flamingo-commerce/v3/cart"
+	"flamingo.me/flamingo-commerce/v3/product"
+	"flamingo.me/flamingo-commerce/v3/wishlist/application"
+	"flamingo.me/flamingo-commerce/v3/wishlist/domain"
+	"flamingo.me/flamingo-commerce/v3/wishlist/infrastructure"
+	"flamingo.me/flamingo-commerce/v3/wishlist/interfaces/controller"
+	"flamingo.me/flamingo-commerce/v3/wishlist/interfaces/templatefunctions"
+	"flamingo.me/flamingo/v3/core/oauth"
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+// Module for wishlists
+type Module struct {
+	useInMemoryAdapters bool
+}
+
+// Inject dependencies
+func (m *Module) Inject(
+	config *struct {
+		UseInMemoryAdapters bool `inject:"config:commerce.wishlist.useInMemoryWishlistServiceAdapters,optional"`
+	},
+) {
+	if config != nil {
+		m.useInMemoryAdapters = config.UseInMemoryAdapters
+	}
+}
+
+// Configure module
+func (m *Module) Configure(injector *dingo.Injector) {
+	if m.useInMemoryAdapters {
+		injector.Bind(new(infrastructure.InMemoryStorage)).AsEagerSingleton()
+		injector.Bind((*domain.GuestWishlistService)(nil)).To(infrastructure.InMemoryGuestWishlistService{})
+		injector.Bind((*domain.CustomerWishlistService)(nil)).To(infrastructure.InMemoryCustomerWishlistService{})
+	}
+
+	flamingo.BindEventSubscriber(injector).To(application.EventReceiver{})
+
+	flamingo.BindTemplateFunc(injector, "getWishlist", new(templatefunctions.GetWishlist))
+	flamingo.BindTemplateFunc(injector, "getDecoratedWishlist", new(templatefunctions.GetDecoratedWishlist))
+
+	web.BindRoutes(injector, new(routes))
+}
+
+// DefaultConfig for this module
+func (m *Module) DefaultConfig() config.Map {
+	return config.Map{
+		"commerce.wishlist": config.Map{
+			"useInMemoryWishlistServiceAdapters": true,
+			"template":                           "wishlist/wishlist",
+		},
+	}
+}
+
+// Depends on other modules
+func (m *Module) Depends() []dingo.Module {
+	return []dingo.Module{
+		new(product.Module),
+		new(cart.Module),
+		new(oauth.Module),
+	}
+}
+
+type routes struct {
+	viewController *controller.ViewController
+	apiController  *controller.APIController
+}
+
+// Inject required dependencies
+func (r *routes) Inject(viewController *controller.ViewController, apiController *controller.APIController) {
+	r.viewController = viewController
+	r.apiController = apiController
+}
+
+// Routes of the wishlist module
+func (r *routes) Routes(registry *web.RouterRegistry) {
+	registry.HandleGet("wishlist.view", r.viewController.ViewAction)
+	registry.Route("/wishlist", "wishlist.view")
+	registry.HandleAny("wishlist.add", r.viewController.AddAction)
+	registry.Route("/wishlist/add/:marketplacecode", `wishlist.add(marketplacecode, variantcode?="", qty?="1")`)
+	registry.HandleAny("wishlist.remove", r.viewController.RemoveAction)
+	registry.Route("/wishlist/remove/:id", "wishlist.remove(id)")
+	registry.HandleAny("wishlist.moveToCart", r.viewController.MoveToCartAction)
+	registry.Route("/wishlist/movetocart/:id", `wishlist.moveToCart(id, deliveryCode?="")`)
+	registry.HandleAny("wishlist.saveForLater", r.viewController.SaveForLaterAction)
+	registry.Route("/wishlist/saveforlater/:id", `wishlist.saveForLater(id, deliveryCode?="")`)
+
+	registry.HandleGet("wishlist.api.get", r.apiController.GetAction)
+	registry.Route("/api/wishlist", "wishlist.api.get")
+	registry.HandlePost("wishlist.api.add", r.apiController.AddAction)
+	registry.Route("/api/wishlist/add/:marketplacecode", `wishlist.api.add(marketplacecode, variantcode?="", qty?="1")`)
+	registry.HandlePost("wishlist.api.remove", r.apiController.RemoveAction)
+	registry.Route("/api/wishlist/remove/:id", "wishlist.api.remove(id)")
+	registry.HandlePost("wishlist.api.moveToCart", r.apiController.MoveToCartAction)
+	registry.Route("/api/wishlist/movetocart/:id", `wishlist.api.moveToCart(id, deliveryCode?="")`)
+	registry.HandlePost("wishlist.api.saveForLater", r.apiController.SaveForLaterAction)
+	registry.Route("/api/wishlist/saveforlater/:id", `wishlist.api.saveForLater(id, deliveryCode?="")`)
+}
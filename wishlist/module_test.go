@@ -0,0 +1,14 @@
+package wishlist_test
+
+import (
+	"testing"
+
+	"flamingo.me/dingo"
+	"flamingo.me/flamingo-commerce/v3/wishlist"
+)
+
+func TestModule_Configure(t *testing.T) {
+	if err := dingo.TryModule(new(wishlist.Module)); err != nil {
+		t.Error(err)
+	}
+}